@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	endpoint1 "bash_exec/pkg/endpoint"
+	pb "bash_exec/pkg/grpc/pb"
+	service "bash_exec/pkg/service"
+	"context"
+	"errors"
+	"time"
+
+	endpoint "github.com/go-kit/kit/endpoint"
+	grpc1 "github.com/go-kit/kit/transport/grpc"
+	grpc "google.golang.org/grpc"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrNotSupported is returned by the methods that have no gRPC transport.
+var ErrNotSupported = errors.New("method not supported over gRPC")
+
+// New returns a BashExecService backed by a gRPC server at the other end
+// of the conn. The caller is responsible for constructing the conn, and
+// eventually closing the underlying transport. Only ExecCmd is available
+// over gRPC, the other methods fail with ErrNotSupported.
+func New(conn *grpc.ClientConn, options map[string][]grpc1.ClientOption) (service.BashExecService, error) {
+	var execCmdEndpoint endpoint.Endpoint
+	{
+		execCmdEndpoint = grpc1.NewClient(conn, "pb.BashExec", "ExecCmd", encodeExecCmdRequest, decodeExecCmdResponse, &pb.ExecCmdReply{}, options["ExecCmd"]...).Endpoint()
+	}
+
+	return endpoint1.Endpoints{
+		CancelJobEndpoint:      notSupported,
+		CreateScheduleEndpoint: notSupported,
+		CreateTemplateEndpoint: notSupported,
+		DeleteScheduleEndpoint: notSupported,
+		DeleteTemplateEndpoint: notSupported,
+		ExecBatchEndpoint:      notSupported,
+		ExecCmdEndpoint:        execCmdEndpoint,
+		ExecTemplateEndpoint:   notSupported,
+		GetJobEndpoint:         notSupported,
+		GetScheduleEndpoint:    notSupported,
+		GetTemplateEndpoint:    notSupported,
+		ListSchedulesEndpoint:  notSupported,
+		ListTemplatesEndpoint:  notSupported,
+		SubmitJobEndpoint:      notSupported,
+		UpdateScheduleEndpoint: notSupported,
+		UpdateTemplateEndpoint: notSupported,
+	}, nil
+}
+
+func notSupported(context.Context, interface{}) (interface{}, error) {
+	return nil, ErrNotSupported
+}
+
+// encodeExecCmdRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain ExecCmd request to a gRPC request.
+func encodeExecCmdRequest(_ context.Context, request interface{}) (interface{}, error) {
+	r := request.(endpoint1.ExecCmdRequest)
+	stdin, err := r.StdinBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ExecCmdRequest{
+		Cmd:      r.Cmd,
+		Mode:     r.Mode,
+		Timeout:  r.Timeout,
+		Cwd:      r.Cwd,
+		Env:      r.Env,
+		CleanEnv: r.CleanEnv,
+		Stdin:    stdin,
+		Limits:   encodeLimits(r.Limits),
+	}, nil
+}
+
+func encodeLimits(l *endpoint1.ExecLimits) *pb.Limits {
+	if l == nil {
+		return nil
+	}
+	return &pb.Limits{
+		CpuTime:     l.CPUTime,
+		MemoryBytes: l.MemoryBytes,
+		OpenFiles:   l.OpenFiles,
+		Processes:   l.Processes,
+		OutputBytes: l.OutputBytes,
+	}
+}
+
+// decodeExecCmdResponse is a transport/grpc.DecodeResponseFunc that converts
+// a gRPC ExecCmd reply to a user-domain ExecCmd response.
+func decodeExecCmdResponse(_ context.Context, reply interface{}) (interface{}, error) {
+	r := reply.(*pb.ExecCmdReply)
+	resp := endpoint1.ExecCmdResponse{
+		StdOut:        r.StdOut,
+		StdErr:        r.StdErr,
+		ExitCode:      int(r.ExitCode),
+		HistoryID:     r.HistoryId,
+		LimitExceeded: r.LimitExceeded,
+		OutputStats: service.OutputStats{
+			StdoutBytes:     r.StdoutBytes,
+			StdoutTruncated: r.StdoutTruncated,
+			StderrBytes:     r.StderrBytes,
+			StderrTruncated: r.StderrTruncated,
+		},
+	}
+	if r.Err != "" {
+		resp.Err = errors.New(r.Err)
+	}
+	if s := r.Stats; s != nil {
+		resp.ExecStats = service.ExecStats{
+			StartedAt:  asTime(s.StartedAt),
+			FinishedAt: asTime(s.FinishedAt),
+			Duration:   s.Duration.AsDuration(),
+			UserTime:   s.UserTime.AsDuration(),
+			SystemTime: s.SystemTime.AsDuration(),
+			PeakRSS:    s.PeakRssBytes,
+			Host:       s.Host,
+			InstanceID: s.InstanceId,
+		}
+	}
+	return resp, nil
+}
+
+// asTime converts t to a time.Time, the zero time for nil.
+func asTime(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
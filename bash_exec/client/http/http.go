@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	endpoint "github.com/go-kit/kit/endpoint"
 	http "github.com/go-kit/kit/transport/http"
 	"io/ioutil"
@@ -31,7 +32,99 @@ func New(instance string, options map[string][]http.ClientOption) (service.BashE
 		execCmdEndpoint = http.NewClient("POST", copyURL(u, "/exec-cmd"), encodeHTTPGenericRequest, decodeExecCmdResponse, options["ExecCmd"]...).Endpoint()
 	}
 
-	return endpoint1.Endpoints{ExecCmdEndpoint: execCmdEndpoint}, nil
+	var submitJobEndpoint endpoint.Endpoint
+	{
+		submitJobEndpoint = http.NewClient("POST", copyURL(u, "/jobs"), encodeHTTPGenericRequest, decodeSubmitJobResponse, options["SubmitJob"]...).Endpoint()
+	}
+
+	var getJobEndpoint endpoint.Endpoint
+	{
+		getJobEndpoint = http.NewClient("GET", copyURL(u, "/jobs/"), encodeJobIDRequest, decodeGetJobResponse, options["GetJob"]...).Endpoint()
+	}
+
+	var cancelJobEndpoint endpoint.Endpoint
+	{
+		cancelJobEndpoint = http.NewClient("DELETE", copyURL(u, "/jobs/"), encodeJobIDRequest, decodeCancelJobResponse, options["CancelJob"]...).Endpoint()
+	}
+
+	var execBatchEndpoint endpoint.Endpoint
+	{
+		execBatchEndpoint = http.NewClient("POST", copyURL(u, "/exec-batch"), encodeHTTPGenericRequest, decodeExecBatchResponse, options["ExecBatch"]...).Endpoint()
+	}
+
+	var createScheduleEndpoint endpoint.Endpoint
+	{
+		createScheduleEndpoint = http.NewClient("POST", copyURL(u, "/schedules"), encodeHTTPGenericRequest, decodeCreateScheduleResponse, options["CreateSchedule"]...).Endpoint()
+	}
+
+	var listSchedulesEndpoint endpoint.Endpoint
+	{
+		listSchedulesEndpoint = http.NewClient("GET", copyURL(u, "/schedules"), encodeListSchedulesRequest, decodeListSchedulesResponse, options["ListSchedules"]...).Endpoint()
+	}
+
+	var getScheduleEndpoint endpoint.Endpoint
+	{
+		getScheduleEndpoint = http.NewClient("GET", copyURL(u, "/schedules/"), encodeScheduleIDRequest, decodeGetScheduleResponse, options["GetSchedule"]...).Endpoint()
+	}
+
+	var updateScheduleEndpoint endpoint.Endpoint
+	{
+		updateScheduleEndpoint = http.NewClient("PUT", copyURL(u, "/schedules/"), encodeScheduleIDRequest, decodeUpdateScheduleResponse, options["UpdateSchedule"]...).Endpoint()
+	}
+
+	var deleteScheduleEndpoint endpoint.Endpoint
+	{
+		deleteScheduleEndpoint = http.NewClient("DELETE", copyURL(u, "/schedules/"), encodeScheduleIDRequest, decodeDeleteScheduleResponse, options["DeleteSchedule"]...).Endpoint()
+	}
+
+	var createTemplateEndpoint endpoint.Endpoint
+	{
+		createTemplateEndpoint = http.NewClient("POST", copyURL(u, "/templates"), encodeHTTPGenericRequest, decodeCreateTemplateResponse, options["CreateTemplate"]...).Endpoint()
+	}
+
+	var listTemplatesEndpoint endpoint.Endpoint
+	{
+		listTemplatesEndpoint = http.NewClient("GET", copyURL(u, "/templates"), encodeListSchedulesRequest, decodeListTemplatesResponse, options["ListTemplates"]...).Endpoint()
+	}
+
+	var getTemplateEndpoint endpoint.Endpoint
+	{
+		getTemplateEndpoint = http.NewClient("GET", copyURL(u, "/templates/"), encodeTemplateNameRequest, decodeGetTemplateResponse, options["GetTemplate"]...).Endpoint()
+	}
+
+	var updateTemplateEndpoint endpoint.Endpoint
+	{
+		updateTemplateEndpoint = http.NewClient("PUT", copyURL(u, "/templates/"), encodeTemplateNameRequest, decodeUpdateTemplateResponse, options["UpdateTemplate"]...).Endpoint()
+	}
+
+	var deleteTemplateEndpoint endpoint.Endpoint
+	{
+		deleteTemplateEndpoint = http.NewClient("DELETE", copyURL(u, "/templates/"), encodeTemplateNameRequest, decodeDeleteTemplateResponse, options["DeleteTemplate"]...).Endpoint()
+	}
+
+	var execTemplateEndpoint endpoint.Endpoint
+	{
+		execTemplateEndpoint = http.NewClient("POST", copyURL(u, "/exec-template/"), encodeTemplateNameRequest, decodeExecTemplateResponse, options["ExecTemplate"]...).Endpoint()
+	}
+
+	return endpoint1.Endpoints{
+		CancelJobEndpoint:      cancelJobEndpoint,
+		CreateScheduleEndpoint: createScheduleEndpoint,
+		CreateTemplateEndpoint: createTemplateEndpoint,
+		DeleteScheduleEndpoint: deleteScheduleEndpoint,
+		DeleteTemplateEndpoint: deleteTemplateEndpoint,
+		ExecBatchEndpoint:      execBatchEndpoint,
+		ExecCmdEndpoint:        execCmdEndpoint,
+		ExecTemplateEndpoint:   execTemplateEndpoint,
+		GetJobEndpoint:         getJobEndpoint,
+		GetScheduleEndpoint:    getScheduleEndpoint,
+		GetTemplateEndpoint:    getTemplateEndpoint,
+		ListSchedulesEndpoint:  listSchedulesEndpoint,
+		ListTemplatesEndpoint:  listTemplatesEndpoint,
+		SubmitJobEndpoint:      submitJobEndpoint,
+		UpdateScheduleEndpoint: updateScheduleEndpoint,
+		UpdateTemplateEndpoint: updateTemplateEndpoint,
+	}, nil
 }
 
 // EncodeHTTPGenericRequest is a transport/http.EncodeRequestFunc that
@@ -58,9 +151,328 @@ func decodeExecCmdResponse(_ context.Context, r *http1.Response) (interface{}, e
 	err := json.NewDecoder(r.Body).Decode(&resp)
 	return resp, err
 }
+// decodeSubmitJobResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeSubmitJobResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.SubmitJobResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// encodeJobIDRequest is a transport/http.EncodeRequestFunc that appends the
+// job ID of a GetJobRequest or CancelJobRequest to the request path.
+func encodeJobIDRequest(_ context.Context, r *http1.Request, request interface{}) error {
+	var id string
+	switch req := request.(type) {
+	case endpoint1.GetJobRequest:
+		id = req.ID
+	case endpoint1.CancelJobRequest:
+		id = req.ID
+	}
+	r.URL.Path += url.PathEscape(id)
+	return nil
+}
+
+// decodeGetJobResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeGetJobResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.GetJobResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeCancelJobResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeCancelJobResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.CancelJobResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeExecBatchResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeExecBatchResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.ExecBatchResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// encodeListSchedulesRequest is a transport/http.EncodeRequestFunc for a
+// ListSchedulesRequest or a ListTemplatesRequest, that have no parameters.
+func encodeListSchedulesRequest(context.Context, *http1.Request, interface{}) error {
+	return nil
+}
+
+// encodeScheduleIDRequest is a transport/http.EncodeRequestFunc that appends
+// the schedule ID of a GetScheduleRequest, UpdateScheduleRequest or
+// DeleteScheduleRequest to the request path, and JSON-encodes the new
+// definition of an UpdateScheduleRequest to the request body.
+func encodeScheduleIDRequest(ctx context.Context, r *http1.Request, request interface{}) error {
+	var id string
+	switch req := request.(type) {
+	case endpoint1.GetScheduleRequest:
+		id = req.ID
+	case endpoint1.UpdateScheduleRequest:
+		id = req.ID
+		if err := encodeHTTPGenericRequest(ctx, r, req); err != nil {
+			return err
+		}
+	case endpoint1.DeleteScheduleRequest:
+		id = req.ID
+	}
+	r.URL.Path += url.PathEscape(id)
+	return nil
+}
+
+// decodeCreateScheduleResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeCreateScheduleResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.CreateScheduleResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeListSchedulesResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeListSchedulesResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.ListSchedulesResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeGetScheduleResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeGetScheduleResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.GetScheduleResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeUpdateScheduleResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeUpdateScheduleResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.UpdateScheduleResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeDeleteScheduleResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeDeleteScheduleResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.DeleteScheduleResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// encodeTemplateNameRequest is a transport/http.EncodeRequestFunc that appends
+// the template name of a GetTemplateRequest, UpdateTemplateRequest,
+// DeleteTemplateRequest or ExecTemplateRequest to the request path, and
+// JSON-encodes the new definition of an UpdateTemplateRequest, or the
+// parameters of an ExecTemplateRequest, to the request body.
+func encodeTemplateNameRequest(ctx context.Context, r *http1.Request, request interface{}) error {
+	var name string
+	switch req := request.(type) {
+	case endpoint1.GetTemplateRequest:
+		name = req.Name
+	case endpoint1.UpdateTemplateRequest:
+		name = req.Name
+		if err := encodeHTTPGenericRequest(ctx, r, req); err != nil {
+			return err
+		}
+	case endpoint1.DeleteTemplateRequest:
+		name = req.Name
+	case endpoint1.ExecTemplateRequest:
+		name = req.Name
+		if err := encodeHTTPGenericRequest(ctx, r, req); err != nil {
+			return err
+		}
+	}
+	r.URL.Path += url.PathEscape(name)
+	return nil
+}
+
+// decodeCreateTemplateResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeCreateTemplateResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.CreateTemplateResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeListTemplatesResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeListTemplatesResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.ListTemplatesResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeGetTemplateResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeGetTemplateResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.GetTemplateResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeUpdateTemplateResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeUpdateTemplateResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.UpdateTemplateResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeDeleteTemplateResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeDeleteTemplateResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.DeleteTemplateResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeExecTemplateResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeExecTemplateResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.ExecTemplateResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
 func copyURL(base *url.URL, path string) (next *url.URL) {
 	n := *base
 	n.Path = path
 	next = &n
 	return
 }
+
+// StreamExecCmd runs cmd on the remote instance through the /exec-cmd/stream
+// endpoint, and returns a channel that yields the output frames as they are
+// produced. The last frame has Done set and carries the exit code. The channel
+// is closed after it, or as soon as ctx is done, which also stops the command.
+func StreamExecCmd(ctx context.Context, instance string, cmd string, opts service.ExecOptions) (<-chan endpoint1.ExecCmdFrame, error) {
+	if !strings.HasPrefix(instance, "http") {
+		instance = "http://" + instance
+	}
+	u, err := url.Parse(instance)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(endpoint1.NewExecCmdRequest(cmd, opts))
+	if err != nil {
+		return nil, err
+	}
+	req, err := http1.NewRequestWithContext(ctx, "POST", copyURL(u, "/exec-cmd/stream").String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http1.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http1.StatusOK {
+		defer resp.Body.Close()
+		return nil, http2.ErrorDecoder(resp)
+	}
+
+	frames := make(chan endpoint1.ExecCmdFrame)
+	go func() {
+		defer close(frames)
+		defer resp.Body.Close()
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var frame endpoint1.ExecCmdFrame
+			if err := dec.Decode(&frame); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				frame = endpoint1.ExecCmdFrame{Done: true, Err: errUnexpectedEndOfStream.Error()}
+			}
+			select {
+			case frames <- frame:
+			case <-ctx.Done():
+				return
+			}
+			if frame.Done {
+				return
+			}
+		}
+	}()
+	return frames, nil
+}
+
+var errUnexpectedEndOfStream = errors.New("unexpected end of stream")
@@ -0,0 +1,225 @@
+package thrift
+
+import (
+	endpoint1 "bash_exec/pkg/endpoint"
+	service "bash_exec/pkg/service"
+	thrift1 "bash_exec/pkg/thrift"
+	bashexec "bash_exec/pkg/thrift/gen-go/bashexec"
+	"context"
+	"errors"
+	auth "github.com/gigi214/services_example/pkg/auth"
+	"sync"
+	"time"
+
+	thrift "github.com/apache/thrift/lib/go/thrift"
+	endpoint "github.com/go-kit/kit/endpoint"
+)
+
+// Dial opens a connection to the Thrift server at addr, with the protocol,
+// buffer size and framing the server uses. The caller is responsible for
+// closing the returned transport.
+func Dial(addr, protocol string, buffer int, framed bool) (*bashexec.BashExecServiceClient, thrift.TTransport, error) {
+	protocolFactory, err := thrift1.NewProtocolFactory(protocol)
+	if err != nil {
+		return nil, nil, err
+	}
+	socket, err := thrift.NewTSocket(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	transport, err := thrift1.NewTransportFactory(buffer, framed).GetTransport(socket)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = transport.Open(); err != nil {
+		return nil, nil, err
+	}
+	return bashexec.NewBashExecServiceClientFactory(transport, protocolFactory), transport, nil
+}
+
+// ErrNotSupported is returned by the methods that have no Thrift transport.
+var ErrNotSupported = errors.New("method not supported over Thrift")
+
+// New returns a BashExecService backed by a Thrift server at the other end of
+// the client. A Thrift client holds a single connection, the calls are
+// serialized. The credentials are sent in the headers of the requests, with
+// the header protocol. ExecBatch, the schedule and the template methods are
+// only available over HTTP, they fail with ErrNotSupported.
+func New(client *bashexec.BashExecServiceClient, credentials auth.Credentials) (service.BashExecService, error) {
+	var mtx sync.Mutex
+	serialize := func(e endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			ctx, err := thrift1.CredentialsToContext(ctx, credentials)
+			if err != nil {
+				return nil, err
+			}
+			mtx.Lock()
+			defer mtx.Unlock()
+			return e(ctx, request)
+		}
+	}
+	return endpoint1.Endpoints{
+		CancelJobEndpoint:      serialize(makeCancelJobEndpoint(client)),
+		CreateScheduleEndpoint: notSupported,
+		CreateTemplateEndpoint: notSupported,
+		DeleteScheduleEndpoint: notSupported,
+		DeleteTemplateEndpoint: notSupported,
+		ExecBatchEndpoint:      notSupported,
+		ExecCmdEndpoint:        serialize(makeExecCmdEndpoint(client)),
+		ExecTemplateEndpoint:   notSupported,
+		GetJobEndpoint:         serialize(makeGetJobEndpoint(client)),
+		GetScheduleEndpoint:    notSupported,
+		GetTemplateEndpoint:    notSupported,
+		ListSchedulesEndpoint:  notSupported,
+		ListTemplatesEndpoint:  notSupported,
+		SubmitJobEndpoint:      serialize(makeSubmitJobEndpoint(client)),
+		UpdateScheduleEndpoint: notSupported,
+		UpdateTemplateEndpoint: notSupported,
+	}, nil
+}
+
+func notSupported(context.Context, interface{}) (interface{}, error) {
+	return nil, ErrNotSupported
+}
+
+func makeExecCmdEndpoint(client *bashexec.BashExecServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := encodeExecCmdRequest(request.(endpoint1.ExecCmdRequest))
+		if err != nil {
+			return nil, err
+		}
+		reply, err := client.ExecCmd(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		resp := endpoint1.ExecCmdResponse{
+			StdOut:        reply.StdOut,
+			StdErr:        reply.StdErr,
+			ExitCode:      int(reply.ExitCode),
+			HistoryID:     reply.HistoryID,
+			LimitExceeded: reply.LimitExceeded,
+			OutputStats: service.OutputStats{
+				StdoutBytes:     reply.StdoutBytes,
+				StdoutTruncated: reply.StdoutTruncated,
+				StderrBytes:     reply.StderrBytes,
+				StderrTruncated: reply.StderrTruncated,
+			},
+			Err: replyErr(reply.Err),
+		}
+		if s := reply.Stats; s != nil {
+			resp.ExecStats = service.ExecStats{
+				StartedAt:  fromUnixNano(s.StartedAt),
+				FinishedAt: fromUnixNano(s.FinishedAt),
+				Duration:   time.Duration(s.DurationNs),
+				UserTime:   time.Duration(s.UserTimeNs),
+				SystemTime: time.Duration(s.SystemTimeNs),
+				PeakRSS:    s.PeakRssBytes,
+				Host:       s.Host,
+				InstanceID: s.InstanceID,
+			}
+		}
+		return resp, nil
+	}
+}
+
+func makeSubmitJobEndpoint(client *bashexec.BashExecServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := encodeExecCmdRequest(request.(endpoint1.SubmitJobRequest).ExecCmdRequest)
+		if err != nil {
+			return nil, err
+		}
+		reply, err := client.SubmitJob(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return endpoint1.SubmitJobResponse{ID: reply.ID, Err: replyErr(reply.Err)}, nil
+	}
+}
+
+func makeGetJobEndpoint(client *bashexec.BashExecServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reply, err := client.GetJob(ctx, request.(endpoint1.GetJobRequest).ID)
+		if err != nil {
+			return nil, err
+		}
+		resp := endpoint1.GetJobResponse{Err: replyErr(reply.Err)}
+		if j := reply.Job; j != nil {
+			resp.Job = service.Job{
+				ID:            j.ID,
+				Cmd:           j.Cmd,
+				Status:        service.JobStatus(j.Status),
+				StdOut:        j.StdOut,
+				StdErr:        j.StdErr,
+				ExitCode:      int(j.ExitCode),
+				HistoryID:     j.HistoryID,
+				Err:           j.Err,
+				CreatedAt:     fromUnixNano(j.CreatedAt),
+				StartedAt:     fromUnixNano(j.StartedAt),
+				FinishedAt:    fromUnixNano(j.FinishedAt),
+				LimitExceeded: j.LimitExceeded,
+				OutputStats: service.OutputStats{
+					StdoutBytes:     j.StdoutBytes,
+					StdoutTruncated: j.StdoutTruncated,
+					StderrBytes:     j.StderrBytes,
+					StderrTruncated: j.StderrTruncated,
+				},
+			}
+		}
+		return resp, nil
+	}
+}
+
+func makeCancelJobEndpoint(client *bashexec.BashExecServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reply, err := client.CancelJob(ctx, request.(endpoint1.CancelJobRequest).ID)
+		if err != nil {
+			return nil, err
+		}
+		return endpoint1.CancelJobResponse{Err: replyErr(reply.Err)}, nil
+	}
+}
+
+func encodeExecCmdRequest(r endpoint1.ExecCmdRequest) (*bashexec.ExecCmdRequest, error) {
+	stdin, err := r.StdinBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &bashexec.ExecCmdRequest{
+		Cmd:      r.Cmd,
+		Mode:     r.Mode,
+		Timeout:  r.Timeout,
+		Cwd:      r.Cwd,
+		Env:      r.Env,
+		CleanEnv: r.CleanEnv,
+		Stdin:    stdin,
+		Limits:   encodeLimits(r.Limits),
+	}, nil
+}
+
+func encodeLimits(l *endpoint1.ExecLimits) *bashexec.Limits {
+	if l == nil {
+		return nil
+	}
+	return &bashexec.Limits{
+		CPUTime:     l.CPUTime,
+		MemoryBytes: l.MemoryBytes,
+		OpenFiles:   l.OpenFiles,
+		Processes:   l.Processes,
+		OutputBytes: l.OutputBytes,
+	}
+}
+
+func replyErr(s string) error {
+	if s == "" {
+		return nil
+	}
+	return errors.New(s)
+}
+
+// fromUnixNano converts Unix nanoseconds to a time.Time, the zero time for 0.
+func fromUnixNano(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns).UTC()
+}
@@ -2,19 +2,32 @@ package service
 
 import (
 	endpoint "bash_exec/pkg/endpoint"
+	grpc2 "bash_exec/pkg/grpc"
+	pb "bash_exec/pkg/grpc/pb"
 	http1 "bash_exec/pkg/http"
 	service "bash_exec/pkg/service"
+	thrift2 "bash_exec/pkg/thrift"
+	bashexec "bash_exec/pkg/thrift/gen-go/bashexec"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"net"
 	http2 "net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
+	thrift "github.com/apache/thrift/lib/go/thrift"
+	auth "github.com/gigi214/services_example/pkg/auth"
 	endpoint1 "github.com/go-kit/kit/endpoint"
 	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	sd "github.com/go-kit/kit/sd"
+	grpc "github.com/go-kit/kit/transport/grpc"
+	httptransport "github.com/go-kit/kit/transport/http"
 	log "github.com/go-kit/log"
 	lightsteptracergo "github.com/lightstep/lightstep-tracer-go"
 	group "github.com/oklog/oklog/pkg/group"
@@ -24,29 +37,67 @@ import (
 	http "github.com/openzipkin/zipkin-go/reporter/http"
 	prometheus1 "github.com/prometheus/client_golang/prometheus"
 	promhttp "github.com/prometheus/client_golang/prometheus/promhttp"
+	grpc1 "google.golang.org/grpc"
 	appdash "sourcegraph.com/sourcegraph/appdash"
 	opentracing "sourcegraph.com/sourcegraph/appdash/opentracing"
 )
 
 var tracer opentracinggo.Tracer
 var logger log.Logger
+var authenticator *auth.Authenticator
 
 // Define our flags
 var fs = flag.NewFlagSet("bashExec", flag.ExitOnError)
 var debugAddr = fs.String("debug-addr", ":8080", "Debug and metrics listen address")
 var httpAddr = fs.String("http-addr", ":8081", "HTTP listen address")
+var grpcAddr = fs.String("grpc-addr", ":8082", "gRPC listen address")
 var zipkinURL = fs.String("zipkin-url", "", "Enable Zipkin tracing via a collector URL e.g. http://localhost:9411/api/v1/spans")
 var lightstepToken = fs.String("lightstep-token", "", "Enable LightStep tracing via a LightStep access token")
 var appdashAddr = fs.String("appdash-addr", "", "Enable Appdash tracing via an Appdash server host:port")
-var storeServiceAddr = fs.String("store-service-addr", "store-cmds:8081", "Address of the microservice that expose database functions")
-
-// var grpcAddr = fs.String("grpc-addr", ":8082", "gRPC listen address")
-// var thriftAddr = fs.String("thrift-addr", ":8083", "Thrift listen address")
-// var thriftProtocol = fs.String("thrift-protocol", "binary", "binary, compact, json, simplejson")
-// var thriftBuffer = fs.Int("thrift-buffer", 0, "0 for unbuffered")
-// var thriftFramed = fs.Bool("thrift-framed", false, "true to enable framing")
+var storeServiceAddr = fs.String("store-service-addr", "store-cmds:8081", "Address of the microservice that expose database functions, grpc://host:port to call it over gRPC. Its instances can be found with dnssrv://name, file:///path or consul://host:port/service, add ?transport=grpc to call them over gRPC")
+var policyFile = fs.String("policy-file", "", "YAML or JSON file with the policy that allows or denies commands, empty to allow everything")
+var maxExecTimeout = fs.Duration("max-exec-timeout", 5*time.Minute, "Maximum execution time of a command, also used when a request sets no timeout. 0 for no limit")
+var maxOutput = fs.Int("max-output", 1<<20, "Bytes of the output of a command kept on each stream, its head and its tail beyond, 0 to keep all of it")
+var jobWorkers = fs.Int("job-workers", 4, "Number of asynchronous jobs that run at the same time")
+var jobQueueSize = fs.Int("job-queue-size", 100, "Number of asynchronous jobs that can wait for a worker")
+var jobRetention = fs.Duration("job-retention", time.Hour, "How long a finished job can be retrieved, 0 to keep them forever")
+var maxBatchSteps = fs.Int("max-batch-steps", 100, "Number of commands a batch can run, 0 for no limit")
+var schedulesPath = fs.String("schedules-path", "schedules.json", "File where the schedules are kept, empty to disable the scheduler")
+var templatesPath = fs.String("templates-path", "templates.json", "File where the command templates are kept, empty to disable them")
+var instanceID = fs.String("instance-id", "", "Identifier of this instance in the history records, random when empty")
+var outboxPath = fs.String("outbox-path", "outbox.jsonl", "File where the history records are kept until the store service accepts them, empty to send them only once")
+var thriftAddr = fs.String("thrift-addr", ":8083", "Thrift listen address")
+var thriftProtocol = fs.String("thrift-protocol", "binary", "binary, compact, json, simplejson, or header, the only one that carries credentials, with its own framing")
+var thriftBuffer = fs.Int("thrift-buffer", 0, "0 for unbuffered")
+var thriftFramed = fs.Bool("thrift-framed", false, "true to enable framing")
+var authKeysFile = fs.String("auth-keys-file", "", "YAML or JSON file with the API keys and HMAC secrets of the callers")
+var authJWKSFile = fs.String("auth-jwks-file", "", "JSON Web Key Set that verifies the JWT bearer tokens of the callers")
+var authJWTIssuer = fs.String("auth-jwt-issuer", "", "Issuer the JWT bearer tokens must have, any when empty")
+var authJWTAudience = fs.String("auth-jwt-audience", "", "Audience the JWT bearer tokens must have, any when empty")
+var storeQPS = fs.Float64("store-qps", 100, "Requests per second sent to each store service instance, beyond which they fail, 0 for no limit")
+var storeBurst = fs.Int("store-burst", 0, "Requests a store service instance can be sent at once above -store-qps, -store-qps when 0")
+var storeMaxAttempts = fs.Int("store-max-attempts", 3, "Times a history record is sent to the store service instances before it fails")
+var storeAttemptTimeout = fs.Duration("store-attempt-timeout", 0, "Time each attempt to send a history record can take, 0 for no limit")
+var storeTimeout = fs.Duration("store-timeout", 250*time.Millisecond, "Time sending a history record can take with all its attempts, 0 for no limit")
+var storeBreakerFailures = fs.Uint("store-breaker-failures", 6, "Failures in a row that open the circuit breaker of a store service instance")
+var storeBreakerTimeout = fs.Duration("store-breaker-timeout", time.Minute, "Time an open circuit breaker fails the requests before it lets probes through")
+var storeBreakerProbes = fs.Uint("store-breaker-probes", 1, "Requests a half-open circuit breaker lets through, it closes when they all succeed")
+var storeBreakerInterval = fs.Duration("store-breaker-interval", 0, "How often a closed circuit breaker clears its failure counts, 0 to never clear them")
+var storeCredentialsFile = fs.String("store-credentials-file", "", "YAML or JSON file with the credentials sent to the store service, none when empty")
+var allowedDirs = fs.String("allowed-dirs", "", "Comma-separated directories, with their subdirectories, a request can run its command in, none when empty")
+var allowedEnv = fs.String("allowed-env", "", "Comma-separated environment variables a request can set, by name or by prefix followed by *, none when empty")
+var limitCPUTime = fs.Duration("limit-cpu-time", 0, "CPU time of every process of a command, 0 for no limit. Requests can lower it")
+var limitMemory = fs.Int64("limit-memory", 0, "Memory of a command in bytes, of its cgroup or else of the address space of every process, 0 for no limit. Requests can lower it")
+var limitOpenFiles = fs.Int64("limit-open-files", 0, "Files every process of a command can open, 0 for no limit. Requests can lower it")
+var limitProcesses = fs.Int64("limit-processes", 0, "Processes of a command, in its cgroup or else of the user of the service, 0 for no limit. Requests can lower it")
+var limitOutput = fs.Int64("limit-output", 0, "Size of the output of a command in bytes, stdout and stderr together, 0 for no limit. Requests can lower it")
+var executor = fs.String("executor", service.ExecutorHost, "How the commands are run: host, as processes of the service, or sandbox, in their own namespaces with a read-only root")
+var sandboxScratchSize = fs.Int64("sandbox-scratch-size", 64<<20, "Size in bytes of the writable /tmp of a sandboxed command")
+var cgroupRoot = fs.String("cgroup-root", "", "cgroup v2 directory, writable by the service, where every command gets a cgroup for its memory and process limits. Rlimits are used when empty")
 
 func Run() {
+	// The service is also the helper that applies the limits of a command.
+	service.RunLimitsHelper()
 	fs.Parse(os.Args[1:])
 
 	// Create a single logger, which we'll use and give to other components.
@@ -86,17 +137,88 @@ func Run() {
 		tracer = opentracinggo.GlobalTracer()
 	}
 
-	svc := service.New(getServiceMiddleware(logger))
+	if *instanceID == "" {
+		*instanceID = newInstanceID()
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		logger.Log("during", "Hostname", "err", err)
+	}
+	logger.Log("instance", *instanceID, "host", host)
+
+	// Without credentials to check, every request is let through anonymously.
+	authCfg := auth.Config{KeysFile: *authKeysFile, JWKSFile: *authJWKSFile, Issuer: *authJWTIssuer, Audience: *authJWTAudience}
+	if authCfg.Enabled() {
+		if authenticator, err = auth.New(authCfg); err != nil {
+			logger.Log("auth", "enabled", "err", err)
+			os.Exit(1)
+		}
+		logger.Log("auth", "enabled", "keys", *authKeysFile, "jwks", *authJWKSFile)
+	} else {
+		logger.Log("auth", "disabled")
+	}
+
+	cfg := service.Config{
+		MaxTimeout:    *maxExecTimeout,
+		MaxOutput:     *maxOutput,
+		JobWorkers:    *jobWorkers,
+		JobQueueSize:  *jobQueueSize,
+		JobRetention:  *jobRetention,
+		MaxBatchSteps: *maxBatchSteps,
+		Host:          host,
+		InstanceID:    *instanceID,
+		AllowedDirs:   splitList(*allowedDirs),
+		AllowedEnv:    splitList(*allowedEnv),
+		Limits: service.Limits{
+			CPUTime:   *limitCPUTime,
+			Memory:    *limitMemory,
+			OpenFiles: *limitOpenFiles,
+			Processes: *limitProcesses,
+			Output:    *limitOutput,
+		},
+	}
+	if *cgroupRoot != "" {
+		if err := service.EnableCgroup(*cgroupRoot); err != nil {
+			logger.Log("cgroup", *cgroupRoot, "err", err)
+			os.Exit(1)
+		}
+		logger.Log("cgroup", *cgroupRoot)
+	}
+	switch *executor {
+	case service.ExecutorHost:
+		cfg.Executor = service.NewHostExecutor(*cgroupRoot)
+	case service.ExecutorSandbox:
+		cfg.Executor, err = service.NewSandboxExecutor(service.SandboxConfig{CgroupRoot: *cgroupRoot, ScratchSize: *sandboxScratchSize})
+	default:
+		err = fmt.Errorf("unknown executor %q", *executor)
+	}
+	if err != nil {
+		logger.Log("executor", *executor, "err", err)
+		os.Exit(1)
+	}
+	logger.Log("executor", *executor)
+	cfg.Scheduler = openScheduler(logger)
+	cfg.Templates = openTemplates(logger)
+	// ctx ends the background work of the middleware, e.g. the discovery of
+	// the store instances, when the service stops.
+	ctx, cancel := context.WithCancel(context.Background())
+	svc := service.New(cfg, getServiceMiddleware(ctx, logger))
 	eps := endpoint.New(svc, getEndpointMiddleware(logger))
 	g := createService(eps)
+	initScheduler(cfg.Scheduler, g)
 	initMetricsEndpoint(g)
-	initCancelInterrupt(g)
+	initCancelInterrupt(g, cancel)
 	logger.Log("exit", g.Run())
 
 }
 func initHttpHandler(endpoints endpoint.Endpoints, g *group.Group) {
 	options := defaultHttpOptions(logger, tracer)
 	// Add your http options here
+	if authenticator != nil {
+		for method := range options {
+			options[method] = append(options[method], httptransport.ServerBefore(http1.AuthToContext(authenticator)))
+		}
+	}
 
 	httpHandler := http1.NewHTTPHandler(endpoints, options)
 	httpListener, err := net.Listen("tcp", *httpAddr)
@@ -111,13 +233,206 @@ func initHttpHandler(endpoints endpoint.Endpoints, g *group.Group) {
 	})
 
 }
-func getServiceMiddleware(logger log.Logger) (mw []service.Middleware) {
+func initGRPCHandler(endpoints endpoint.Endpoints, g *group.Group) {
+	options := defaultGRPCOptions(logger, tracer)
+	// Add your GRPC options here
+	if authenticator != nil {
+		for method := range options {
+			options[method] = append(options[method], grpc.ServerBefore(grpc2.AuthToContext(authenticator)))
+		}
+	}
+
+	grpcServer := grpc2.NewGRPCServer(endpoints, options)
+	grpcListener, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		logger.Log("transport", "gRPC", "during", "Listen", "err", err)
+	}
+	g.Add(func() error {
+		logger.Log("transport", "gRPC", "addr", *grpcAddr)
+		baseServer := grpc1.NewServer()
+		pb.RegisterBashExecServer(baseServer, grpcServer)
+		return baseServer.Serve(grpcListener)
+	}, func(error) {
+		grpcListener.Close()
+	})
+
+}
+func initThriftHandler(endpoints endpoint.Endpoints, g *group.Group) {
+	protocolFactory, err := thrift2.NewProtocolFactory(*thriftProtocol)
+	if err != nil {
+		logger.Log("transport", "Thrift", "during", "NewProtocolFactory", "err", err)
+		os.Exit(1)
+	}
+	transportFactory := thrift2.NewTransportFactory(*thriftBuffer, *thriftFramed)
+	thriftSocket, err := thrift.NewTServerSocket(*thriftAddr)
+	if err != nil {
+		logger.Log("transport", "Thrift", "during", "Listen", "err", err)
+	}
+	var processor thrift.TProcessor = bashexec.NewBashExecServiceProcessor(thrift2.NewThriftServer(endpoints))
+	if authenticator != nil {
+		processor = thrift2.AuthProcessor(processor, authenticator)
+	}
+	g.Add(func() error {
+		logger.Log("transport", "Thrift", "addr", *thriftAddr, "protocol", *thriftProtocol, "buffer", *thriftBuffer, "framed", *thriftFramed)
+		return thrift.NewTSimpleServer4(
+			processor,
+			thriftSocket,
+			transportFactory,
+			protocolFactory,
+		).Serve()
+	}, func(error) {
+		thriftSocket.Close()
+	})
+
+}
+func getServiceMiddleware(ctx context.Context, logger log.Logger) (mw []service.Middleware) {
 	mw = []service.Middleware{}
 	mw = addDefaultServiceMiddleware(logger, mw)
-	mw = append(mw, service.ProxyStoreMiddleware(context.Background(), *storeServiceAddr, logger))
+	if *policyFile != "" {
+		policy, err := service.LoadPolicy(*policyFile)
+		if err != nil {
+			logger.Log("policy", *policyFile, "err", err)
+			os.Exit(1)
+		}
+		mw = append(mw, service.PolicyMiddleware(policy, logger))
+	}
+	// ProxyStoreMiddleware is the outermost, so that rejected commands are stored too
+	mw = append(mw, service.ProxyStoreMiddleware(ctx, storeInstancer(logger), storeClientConfig(), *instanceID, storeCredentials(logger), openOutbox(logger), logger))
 
 	return
 }
+// newInstanceID returns a random identifier, that tells apart the successive
+// runs of an instance as well as the instances sharing a host name.
+func newInstanceID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		logger.Log("during", "newInstanceID", "err", err)
+		os.Exit(1)
+	}
+	return hex.EncodeToString(b)
+}
+func storeInstancer(logger log.Logger) sd.Instancer {
+	if *storeServiceAddr == "" {
+		return nil
+	}
+	instancer, err := service.NewStoreInstancer(*storeServiceAddr, logger)
+	if err != nil {
+		logger.Log("store-service-addr", *storeServiceAddr, "err", err)
+		os.Exit(1)
+	}
+	return instancer
+}
+func storeClientConfig() service.StoreClientConfig {
+	return service.StoreClientConfig{
+		QPS:                *storeQPS,
+		Burst:              *storeBurst,
+		MaxAttempts:        *storeMaxAttempts,
+		AttemptTimeout:     *storeAttemptTimeout,
+		Timeout:            *storeTimeout,
+		BreakerFailures:    uint32(*storeBreakerFailures),
+		BreakerOpenTimeout: *storeBreakerTimeout,
+		BreakerProbes:      uint32(*storeBreakerProbes),
+		BreakerInterval:    *storeBreakerInterval,
+		BreakerState: prometheus.NewGaugeFrom(prometheus1.GaugeOpts{
+			Help:      "State of the circuit breaker of a store service instance, 0 closed, 1 half-open and 2 open.",
+			Name:      "store_breaker_state",
+			Namespace: "example",
+			Subsystem: "bashExec",
+		}, []string{"instance"}),
+		BreakerTransitions: prometheus.NewCounterFrom(prometheus1.CounterOpts{
+			Help:      "Number of changes of state of the circuit breakers of the store service instances.",
+			Name:      "store_breaker_transitions_total",
+			Namespace: "example",
+			Subsystem: "bashExec",
+		}, []string{"instance", "state"}),
+		Retries: prometheus.NewCounterFrom(prometheus1.CounterOpts{
+			Help:      "Number of attempts to send a history record to the store service after the first.",
+			Name:      "store_retries_total",
+			Namespace: "example",
+			Subsystem: "bashExec",
+		}, []string{}),
+	}
+}
+func storeCredentials(logger log.Logger) (credentials auth.Credentials) {
+	if *storeCredentialsFile == "" {
+		return
+	}
+	credentials, err := auth.LoadCredentials(*storeCredentialsFile)
+	if err != nil {
+		logger.Log("store-credentials", *storeCredentialsFile, "err", err)
+		os.Exit(1)
+	}
+	return credentials
+}
+
+// splitList returns the non-empty elements of a comma-separated list.
+func splitList(s string) (list []string) {
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			list = append(list, e)
+		}
+	}
+	return list
+}
+func openOutbox(logger log.Logger) *service.Outbox {
+	if *outboxPath == "" {
+		return nil
+	}
+	depth := prometheus.NewGaugeFrom(prometheus1.GaugeOpts{
+		Help:      "Number of history records waiting to be delivered to the store service.",
+		Name:      "outbox_depth",
+		Namespace: "example",
+		Subsystem: "bashExec",
+	}, []string{})
+	failures := prometheus.NewCounterFrom(prometheus1.CounterOpts{
+		Help:      "Number of failed deliveries of history records to the store service.",
+		Name:      "outbox_delivery_failures_total",
+		Namespace: "example",
+		Subsystem: "bashExec",
+	}, []string{})
+	outbox, err := service.OpenOutbox(*outboxPath, depth, failures)
+	if err != nil {
+		logger.Log("outbox", *outboxPath, "err", err)
+		os.Exit(1)
+	}
+	return outbox
+}
+func openScheduler(logger log.Logger) *service.Scheduler {
+	if *schedulesPath == "" {
+		logger.Log("scheduler", "disabled")
+		return nil
+	}
+	scheduler, err := service.OpenScheduler(*schedulesPath, logger)
+	if err != nil {
+		logger.Log("schedules", *schedulesPath, "err", err)
+		os.Exit(1)
+	}
+	return scheduler
+}
+func openTemplates(logger log.Logger) *service.TemplateRegistry {
+	if *templatesPath == "" {
+		logger.Log("templates", "disabled")
+		return nil
+	}
+	templates, err := service.OpenTemplateRegistry(*templatesPath)
+	if err != nil {
+		logger.Log("templates", *templatesPath, "err", err)
+		os.Exit(1)
+	}
+	return templates
+}
+func initScheduler(scheduler *service.Scheduler, g *group.Group) {
+	if scheduler == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	g.Add(func() error {
+		logger.Log("scheduler", "running", "schedules", *schedulesPath)
+		return scheduler.Run(ctx)
+	}, func(error) {
+		cancel()
+	})
+}
 func getEndpointMiddleware(logger log.Logger) (mw map[string][]endpoint1.Middleware) {
 	mw = map[string][]endpoint1.Middleware{}
 	duration := prometheus.NewSummaryFrom(prometheus1.SummaryOpts{
@@ -128,6 +443,11 @@ func getEndpointMiddleware(logger log.Logger) (mw map[string][]endpoint1.Middlew
 	}, []string{"method", "success"})
 	addDefaultEndpointMiddleware(logger, duration, mw)
 	// Add you endpoint middleware here
+	if authenticator != nil {
+		// Thrift carries credentials with the header protocol only, the
+		// requests of the other protocols are all rejected.
+		addEndpointMiddlewareToAllMethods(mw, auth.Middleware())
+	}
 
 	return
 }
@@ -144,7 +464,7 @@ func initMetricsEndpoint(g *group.Group) {
 		debugListener.Close()
 	})
 }
-func initCancelInterrupt(g *group.Group) {
+func initCancelInterrupt(g *group.Group, cancel context.CancelFunc) {
 	cancelInterrupt := make(chan struct{})
 	g.Add(func() error {
 		c := make(chan os.Signal, 1)
@@ -157,5 +477,6 @@ func initCancelInterrupt(g *group.Group) {
 		}
 	}, func(error) {
 		close(cancelInterrupt)
+		cancel()
 	})
 }
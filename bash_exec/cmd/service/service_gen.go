@@ -9,6 +9,7 @@ import (
 	log "github.com/go-kit/log"
 	prometheus "github.com/go-kit/kit/metrics/prometheus"
 	opentracing "github.com/go-kit/kit/tracing/opentracing"
+	grpc "github.com/go-kit/kit/transport/grpc"
 	http "github.com/go-kit/kit/transport/http"
 	group "github.com/oklog/oklog/pkg/group"
 	opentracinggo "github.com/opentracing/opentracing-go"
@@ -17,20 +18,58 @@ import (
 func createService(endpoints endpoint.Endpoints) (g *group.Group) {
 	g = &group.Group{}
 	initHttpHandler(endpoints, g)
+	initGRPCHandler(endpoints, g)
+	initThriftHandler(endpoints, g)
 	return g
 }
 func defaultHttpOptions(logger log.Logger, tracer opentracinggo.Tracer) map[string][]http.ServerOption {
-	options := map[string][]http.ServerOption{"ExecCmd": {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "ExecCmd", logger))}}
+	options := map[string][]http.ServerOption{
+		"CancelJob":      {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "CancelJob", logger))},
+		"CreateSchedule": {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "CreateSchedule", logger))},
+		"CreateTemplate": {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "CreateTemplate", logger))},
+		"DeleteSchedule": {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "DeleteSchedule", logger))},
+		"DeleteTemplate": {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "DeleteTemplate", logger))},
+		"ExecBatch":      {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "ExecBatch", logger))},
+		"ExecCmd":        {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "ExecCmd", logger))},
+		"ExecTemplate":   {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "ExecTemplate", logger))},
+		"GetJob":         {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "GetJob", logger))},
+		"GetSchedule":    {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "GetSchedule", logger))},
+		"GetTemplate":    {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "GetTemplate", logger))},
+		"ListSchedules":  {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "ListSchedules", logger))},
+		"ListTemplates":  {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "ListTemplates", logger))},
+		"SubmitJob":      {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "SubmitJob", logger))},
+		"UpdateSchedule": {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "UpdateSchedule", logger))},
+		"UpdateTemplate": {http.ServerErrorEncoder(http1.ErrorEncoder), http.ServerErrorLogger(logger), http.ServerBefore(opentracing.HTTPToContext(tracer, "UpdateTemplate", logger))},
+	}
+	return options
+}
+func defaultGRPCOptions(logger log.Logger, tracer opentracinggo.Tracer) map[string][]grpc.ServerOption {
+	options := map[string][]grpc.ServerOption{"ExecCmd": {grpc.ServerErrorLogger(logger), grpc.ServerBefore(opentracing.GRPCToContext(tracer, "ExecCmd", logger))}}
 	return options
 }
 func addDefaultEndpointMiddleware(logger log.Logger, duration *prometheus.Summary, mw map[string][]endpoint1.Middleware) {
 	mw["ExecCmd"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "ExecCmd")), endpoint.InstrumentingMiddleware(duration.With("method", "ExecCmd"))}
+	mw["SubmitJob"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "SubmitJob")), endpoint.InstrumentingMiddleware(duration.With("method", "SubmitJob"))}
+	mw["GetJob"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "GetJob")), endpoint.InstrumentingMiddleware(duration.With("method", "GetJob"))}
+	mw["CancelJob"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "CancelJob")), endpoint.InstrumentingMiddleware(duration.With("method", "CancelJob"))}
+	mw["ExecBatch"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "ExecBatch")), endpoint.InstrumentingMiddleware(duration.With("method", "ExecBatch"))}
+	mw["CreateSchedule"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "CreateSchedule")), endpoint.InstrumentingMiddleware(duration.With("method", "CreateSchedule"))}
+	mw["ListSchedules"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "ListSchedules")), endpoint.InstrumentingMiddleware(duration.With("method", "ListSchedules"))}
+	mw["GetSchedule"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "GetSchedule")), endpoint.InstrumentingMiddleware(duration.With("method", "GetSchedule"))}
+	mw["UpdateSchedule"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "UpdateSchedule")), endpoint.InstrumentingMiddleware(duration.With("method", "UpdateSchedule"))}
+	mw["DeleteSchedule"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "DeleteSchedule")), endpoint.InstrumentingMiddleware(duration.With("method", "DeleteSchedule"))}
+	mw["CreateTemplate"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "CreateTemplate")), endpoint.InstrumentingMiddleware(duration.With("method", "CreateTemplate"))}
+	mw["ListTemplates"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "ListTemplates")), endpoint.InstrumentingMiddleware(duration.With("method", "ListTemplates"))}
+	mw["GetTemplate"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "GetTemplate")), endpoint.InstrumentingMiddleware(duration.With("method", "GetTemplate"))}
+	mw["UpdateTemplate"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "UpdateTemplate")), endpoint.InstrumentingMiddleware(duration.With("method", "UpdateTemplate"))}
+	mw["DeleteTemplate"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "DeleteTemplate")), endpoint.InstrumentingMiddleware(duration.With("method", "DeleteTemplate"))}
+	mw["ExecTemplate"] = []endpoint1.Middleware{endpoint.LoggingMiddleware(log.With(logger, "method", "ExecTemplate")), endpoint.InstrumentingMiddleware(duration.With("method", "ExecTemplate"))}
 }
 func addDefaultServiceMiddleware(logger log.Logger, mw []service.Middleware) []service.Middleware {
 	return append(mw, service.LoggingMiddleware(logger))
 }
 func addEndpointMiddlewareToAllMethods(mw map[string][]endpoint1.Middleware, m endpoint1.Middleware) {
-	methods := []string{"ExecCmd"}
+	methods := []string{"ExecCmd", "SubmitJob", "GetJob", "CancelJob", "ExecBatch", "CreateSchedule", "ListSchedules", "GetSchedule", "UpdateSchedule", "DeleteSchedule", "CreateTemplate", "ListTemplates", "GetTemplate", "UpdateTemplate", "DeleteTemplate", "ExecTemplate"}
 	for _, v := range methods {
 		mw[v] = append(mw[v], m)
 	}
@@ -3,6 +3,10 @@ package endpoint
 import (
 	service "bash_exec/pkg/service"
 	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+	"unicode/utf8"
 
 	endpoint "github.com/go-kit/kit/endpoint"
 )
@@ -16,28 +20,197 @@ type Failure interface {
 
 // ExecCmdRequest collects the request parameters for the ExecCmd method.
 type ExecCmdRequest struct {
-	Cmd string `json:"cmd"`
+	Cmd     string `json:"cmd"`
+	Mode    string `json:"mode,omitempty"`
+	Timeout string `json:"timeout,omitempty"`
+	// Cwd, Env and CleanEnv are the environment of the command, see service.ExecOptions.
+	Cwd      string            `json:"cwd,omitempty"`
+	Env      map[string]string `json:"env,omitempty"`
+	CleanEnv bool              `json:"clean_env,omitempty"`
+	// Stdin is the standard input of the command, in the StdinEncoding:
+	// StdinRaw when empty, or StdinBase64 for binary data.
+	Stdin         string `json:"stdin,omitempty"`
+	StdinEncoding string `json:"stdin_encoding,omitempty"`
+	// Limits lower the limits of the server, nil to keep them.
+	Limits *ExecLimits `json:"limits,omitempty"`
+
+	// Output receives the output of the command while it runs, it is set by
+	// the streaming transports and never sent over the wire.
+	Output service.OutputFunc `json:"-"`
+}
+
+// ExecLimits are the limits of a command, see service.Limits. CPUTime is a
+// duration, such as "10s", and the zero values keep the server limits.
+type ExecLimits struct {
+	CPUTime     string `json:"cpu_time,omitempty"`
+	MemoryBytes int64  `json:"memory_bytes,omitempty"`
+	OpenFiles   int64  `json:"open_files,omitempty"`
+	Processes   int64  `json:"processes,omitempty"`
+	OutputBytes int64  `json:"output_bytes,omitempty"`
+}
+
+// NewExecLimits returns the ExecLimits for l, nil when it sets no limit.
+func NewExecLimits(l service.Limits) *ExecLimits {
+	if l == (service.Limits{}) {
+		return nil
+	}
+	res := &ExecLimits{MemoryBytes: l.Memory, OpenFiles: l.OpenFiles, Processes: l.Processes, OutputBytes: l.Output}
+	if l.CPUTime != 0 {
+		res.CPUTime = l.CPUTime.String()
+	}
+	return res
+}
+
+// Limits returns the service.Limits of l.
+func (l *ExecLimits) Limits() (res service.Limits, err error) {
+	if l == nil {
+		return
+	}
+	if l.CPUTime != "" {
+		if res.CPUTime, err = time.ParseDuration(l.CPUTime); err != nil {
+			return res, fmt.Errorf("%w: cpu time %q", service.ErrInvalidLimits, l.CPUTime)
+		}
+	}
+	res.Memory, res.OpenFiles, res.Processes, res.Output = l.MemoryBytes, l.OpenFiles, l.Processes, l.OutputBytes
+	return
+}
+
+// Encodings of ExecCmdRequest.Stdin.
+const (
+	StdinRaw    = "raw"
+	StdinBase64 = "base64"
+)
+
+// NewExecCmdRequest returns the ExecCmdRequest for cmd and opts. Stdin is
+// encoded in base64 when it is not valid UTF-8.
+func NewExecCmdRequest(cmd string, opts service.ExecOptions) ExecCmdRequest {
+	request := ExecCmdRequest{
+		Cmd:      cmd,
+		Mode:     string(opts.Mode),
+		Cwd:      opts.Cwd,
+		Env:      opts.Env,
+		CleanEnv: opts.CleanEnv,
+		Stdin:    string(opts.Stdin),
+		Limits:   NewExecLimits(opts.Limits),
+		Output:   opts.Output,
+	}
+	if opts.Timeout > 0 {
+		request.Timeout = opts.Timeout.String()
+	}
+	if !utf8.Valid(opts.Stdin) {
+		request.Stdin, request.StdinEncoding = base64.StdEncoding.EncodeToString(opts.Stdin), StdinBase64
+	}
+	return request
+}
+
+// StdinBytes returns the decoded Stdin, nil when it is empty.
+func (r ExecCmdRequest) StdinBytes() ([]byte, error) {
+	if r.Stdin == "" {
+		return nil, nil
+	}
+	switch r.StdinEncoding {
+	case "", StdinRaw:
+		return []byte(r.Stdin), nil
+	case StdinBase64:
+		b, err := base64.StdEncoding.DecodeString(r.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", service.ErrInvalidStdin, err)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("%w: unknown encoding %q", service.ErrInvalidStdin, r.StdinEncoding)
 }
 
-// ExecCmdResponse collects the response parameters for the ExecCmd method.
+// options converts the optional request parameters into service.ExecOptions.
+func (r ExecCmdRequest) options() (opts service.ExecOptions, err error) {
+	if opts.Mode, err = service.ParseExecMode(r.Mode); err != nil {
+		return
+	}
+	if r.Timeout != "" {
+		if opts.Timeout, err = time.ParseDuration(r.Timeout); err != nil || opts.Timeout < 0 {
+			err = fmt.Errorf("%w: %q", service.ErrInvalidTimeout, r.Timeout)
+			return
+		}
+	}
+	if opts.Stdin, err = r.StdinBytes(); err != nil {
+		return
+	}
+	if opts.Limits, err = r.Limits.Limits(); err != nil {
+		return
+	}
+	opts.Cwd, opts.Env, opts.CleanEnv = r.Cwd, r.Env, r.CleanEnv
+	opts.Output = r.Output
+	return
+}
+
+// ExecCmdResponse collects the response parameters for the ExecCmd method. A
+// command that ran is a successful call whatever its ExitCode, with its output,
+// and OutputStats tells whether the output was truncated. Err is set when the
+// command could not run, e.g. service.ErrSpawn, and is encoded by the
+// transports as an error instead of the response.
 type ExecCmdResponse struct {
-	StdOut   string `json:"std_out"`
-	StdErr   string `json:"std_err"`
-	ExitCode int    `json:"exit_code"`
-	Err      error  `json:"err"`
+	StdOut    string `json:"std_out"`
+	StdErr    string `json:"std_err"`
+	ExitCode  int    `json:"exit_code"`
+	HistoryID string `json:"history_id,omitempty"`
+	// LimitExceeded is the limit that stopped the command, see service.ExecResult.
+	LimitExceeded string `json:"limit_exceeded,omitempty"`
+	Err           error  `json:"-"`
+
+	service.OutputStats
+	service.ExecStats
+}
+
+// ExecCmdFrame is a message of a streamed ExecCmd response. Output frames carry
+// Stream and Data, the last frame of a stream has Done set and carries ExitCode,
+// HistoryID, LimitExceeded, Stats and Err.
+type ExecCmdFrame struct {
+	Stream        string             `json:"stream,omitempty"`
+	Data          string             `json:"data,omitempty"`
+	Done          bool               `json:"done,omitempty"`
+	ExitCode      *int               `json:"exit_code,omitempty"`
+	HistoryID     string             `json:"history_id,omitempty"`
+	LimitExceeded string             `json:"limit_exceeded,omitempty"`
+	Stats         *service.ExecStats `json:"stats,omitempty"`
+	Err           string             `json:"err,omitempty"`
 }
 
 // MakeExecCmdEndpoint returns an endpoint that invokes ExecCmd on the service.
 func MakeExecCmdEndpoint(s service.BashExecService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(ExecCmdRequest)
-		stdOut, stdErr, exitCode, err := s.ExecCmd(ctx, req.Cmd)
-		return ExecCmdResponse{
-			Err:      err,
-			ExitCode: exitCode,
-			StdErr:   stdErr,
-			StdOut:   stdOut,
-		}, nil
+		opts, err := req.options()
+		if err != nil {
+			return ExecCmdResponse{Err: err, ExitCode: -999}, nil
+		}
+		res, err := s.ExecCmd(ctx, req.Cmd, opts)
+		return newExecCmdResponse(res, err), nil
+	}
+}
+
+func newExecCmdResponse(res service.ExecResult, err error) ExecCmdResponse {
+	return ExecCmdResponse{
+		Err:           err,
+		ExitCode:      res.ExitCode,
+		HistoryID:     res.HistoryID,
+		LimitExceeded: res.LimitExceeded,
+		StdErr:        res.StdErr,
+		StdOut:        res.StdOut,
+		OutputStats:   res.OutputStats,
+		ExecStats:     res.ExecStats,
+	}
+}
+
+// result converts r back into a service.ExecResult.
+func (r ExecCmdResponse) result() service.ExecResult {
+	return service.ExecResult{
+		StdOut:        r.StdOut,
+		StdErr:        r.StdErr,
+		ExitCode:      r.ExitCode,
+		HistoryID:     r.HistoryID,
+		LimitExceeded: r.LimitExceeded,
+		OutputStats:   r.OutputStats,
+		ExecStats:     r.ExecStats,
 	}
 }
 
@@ -47,11 +220,816 @@ func (r ExecCmdResponse) Failed() error {
 }
 
 // ExecCmd implements Service. Primarily useful in a client.
-func (e Endpoints) ExecCmd(ctx context.Context, cmd string) (stdOut string, stdErr string, exitCode int, err error) {
-	request := ExecCmdRequest{Cmd: cmd}
+func (e Endpoints) ExecCmd(ctx context.Context, cmd string, opts service.ExecOptions) (res service.ExecResult, err error) {
+	request := NewExecCmdRequest(cmd, opts)
 	response, err := e.ExecCmdEndpoint(ctx, request)
 	if err != nil {
 		return
 	}
-	return response.(ExecCmdResponse).StdOut, response.(ExecCmdResponse).StdErr, response.(ExecCmdResponse).ExitCode, response.(ExecCmdResponse).Err
+	r := response.(ExecCmdResponse)
+	return r.result(), r.Err
+}
+
+// SubmitJobRequest collects the request parameters for the SubmitJob method.
+type SubmitJobRequest struct {
+	ExecCmdRequest
+}
+
+// SubmitJobResponse collects the response parameters for the SubmitJob method.
+type SubmitJobResponse struct {
+	ID  string `json:"id"`
+	Err error  `json:"-"`
+}
+
+// MakeSubmitJobEndpoint returns an endpoint that invokes SubmitJob on the service.
+func MakeSubmitJobEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(SubmitJobRequest)
+		opts, err := req.options()
+		if err != nil {
+			return SubmitJobResponse{Err: err}, nil
+		}
+		id, err := s.SubmitJob(ctx, req.Cmd, opts)
+		return SubmitJobResponse{
+			Err: err,
+			ID:  id,
+		}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r SubmitJobResponse) Failed() error {
+	return r.Err
+}
+
+// SubmitJob implements Service. Primarily useful in a client.
+func (e Endpoints) SubmitJob(ctx context.Context, cmd string, opts service.ExecOptions) (id string, err error) {
+	request := SubmitJobRequest{NewExecCmdRequest(cmd, opts)}
+	response, err := e.SubmitJobEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	return response.(SubmitJobResponse).ID, response.(SubmitJobResponse).Err
+}
+
+// GetJobRequest collects the request parameters for the GetJob method.
+type GetJobRequest struct {
+	ID string `json:"id"`
+}
+
+// GetJobResponse collects the response parameters for the GetJob method.
+type GetJobResponse struct {
+	Job service.Job `json:"job"`
+	Err error       `json:"-"`
+}
+
+// MakeGetJobEndpoint returns an endpoint that invokes GetJob on the service.
+func MakeGetJobEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetJobRequest)
+		job, err := s.GetJob(ctx, req.ID)
+		return GetJobResponse{
+			Err: err,
+			Job: job,
+		}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r GetJobResponse) Failed() error {
+	return r.Err
+}
+
+// GetJob implements Service. Primarily useful in a client.
+func (e Endpoints) GetJob(ctx context.Context, id string) (job service.Job, err error) {
+	request := GetJobRequest{ID: id}
+	response, err := e.GetJobEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	return response.(GetJobResponse).Job, response.(GetJobResponse).Err
+}
+
+// CancelJobRequest collects the request parameters for the CancelJob method.
+type CancelJobRequest struct {
+	ID string `json:"id"`
+}
+
+// CancelJobResponse collects the response parameters for the CancelJob method.
+type CancelJobResponse struct {
+	Err error `json:"-"`
+}
+
+// MakeCancelJobEndpoint returns an endpoint that invokes CancelJob on the service.
+func MakeCancelJobEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CancelJobRequest)
+		err := s.CancelJob(ctx, req.ID)
+		return CancelJobResponse{Err: err}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r CancelJobResponse) Failed() error {
+	return r.Err
+}
+
+// CancelJob implements Service. Primarily useful in a client.
+func (e Endpoints) CancelJob(ctx context.Context, id string) (err error) {
+	request := CancelJobRequest{ID: id}
+	response, err := e.CancelJobEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	return response.(CancelJobResponse).Err
+}
+
+// ExecBatchRequest collects the request parameters for the ExecBatch method.
+type ExecBatchRequest struct {
+	Steps []BatchStepRequest `json:"steps"`
+}
+
+// BatchStepRequest is a step of an ExecBatchRequest, see service.BatchStep.
+// Cmd is a template, and the other parameters of ExecCmdRequest apply to it.
+type BatchStepRequest struct {
+	Name string `json:"name,omitempty"`
+	ExecCmdRequest
+	ContinueOnError bool               `json:"continue_on_error,omitempty"`
+	Parallel        []BatchStepRequest `json:"parallel,omitempty"`
+}
+
+// NewBatchStepRequests returns the BatchStepRequests for steps.
+func NewBatchStepRequests(steps []service.BatchStep) []BatchStepRequest {
+	var res []BatchStepRequest
+	for _, s := range steps {
+		res = append(res, BatchStepRequest{
+			Name:            s.Name,
+			ExecCmdRequest:  NewExecCmdRequest(s.Cmd, s.Options),
+			ContinueOnError: s.ContinueOnError,
+			Parallel:        NewBatchStepRequests(s.Parallel),
+		})
+	}
+	return res
+}
+
+// batchSteps converts the steps of a request into service.BatchSteps.
+func batchSteps(steps []BatchStepRequest) (res []service.BatchStep, err error) {
+	for _, s := range steps {
+		step := service.BatchStep{Name: s.Name, Cmd: s.Cmd, ContinueOnError: s.ContinueOnError}
+		if step.Options, err = s.options(); err != nil {
+			return nil, err
+		}
+		if step.Parallel, err = batchSteps(s.Parallel); err != nil {
+			return nil, err
+		}
+		res = append(res, step)
+	}
+	return
+}
+
+// ExecBatchResponse collects the response parameters for the ExecBatch method.
+// A batch that ran is a successful call whatever the outcome of its steps.
+type ExecBatchResponse struct {
+	service.BatchResult
+	Err error `json:"-"`
+}
+
+// MakeExecBatchEndpoint returns an endpoint that invokes ExecBatch on the service.
+func MakeExecBatchEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ExecBatchRequest)
+		steps, err := batchSteps(req.Steps)
+		if err != nil {
+			return ExecBatchResponse{Err: err}, nil
+		}
+		res, err := s.ExecBatch(ctx, steps)
+		return ExecBatchResponse{BatchResult: res, Err: err}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r ExecBatchResponse) Failed() error {
+	return r.Err
+}
+
+// ExecBatch implements Service. Primarily useful in a client.
+func (e Endpoints) ExecBatch(ctx context.Context, steps []service.BatchStep) (res service.BatchResult, err error) {
+	request := ExecBatchRequest{Steps: NewBatchStepRequests(steps)}
+	response, err := e.ExecBatchEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	return response.(ExecBatchResponse).BatchResult, response.(ExecBatchResponse).Err
+}
+
+// ScheduleRequest is the definition of a schedule, see service.ScheduleSpec.
+// Jitter is a duration, such as "30s", and the parameters of ExecCmdRequest
+// apply to the command.
+type ScheduleRequest struct {
+	Cron     string `json:"cron"`
+	Timezone string `json:"timezone,omitempty"`
+	Overlap  string `json:"overlap,omitempty"`
+	Jitter   string `json:"jitter,omitempty"`
+	ExecCmdRequest
+}
+
+// NewScheduleRequest returns the ScheduleRequest for spec.
+func NewScheduleRequest(spec service.ScheduleSpec) ScheduleRequest {
+	request := ScheduleRequest{
+		Cron:           spec.Cron,
+		Timezone:       spec.Timezone,
+		Overlap:        string(spec.Overlap),
+		ExecCmdRequest: NewExecCmdRequest(spec.Cmd, spec.Options),
+	}
+	if spec.Jitter != 0 {
+		request.Jitter = spec.Jitter.String()
+	}
+	return request
+}
+
+// spec converts the request into a service.ScheduleSpec.
+func (r ScheduleRequest) spec() (spec service.ScheduleSpec, err error) {
+	spec = service.ScheduleSpec{
+		Cron:     r.Cron,
+		Timezone: r.Timezone,
+		Overlap:  service.OverlapPolicy(r.Overlap),
+		Cmd:      r.Cmd,
+	}
+	if r.Jitter != "" {
+		if spec.Jitter, err = time.ParseDuration(r.Jitter); err != nil {
+			err = fmt.Errorf("%w: jitter %q", service.ErrInvalidSchedule, r.Jitter)
+			return
+		}
+	}
+	spec.Options, err = r.options()
+	return
+}
+
+// Schedule is a service.Schedule, with its spec in the form of a ScheduleRequest.
+type Schedule struct {
+	ID string `json:"id"`
+	ScheduleRequest
+	Principal string               `json:"principal,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	NextRun   time.Time            `json:"next_run"`
+	LastRun   *service.ScheduleRun `json:"last_run,omitempty"`
+	Running   int                  `json:"running"`
+	Skipped   int                  `json:"skipped"`
+}
+
+// NewSchedule returns the Schedule for s.
+func NewSchedule(s service.Schedule) Schedule {
+	return Schedule{
+		ID:              s.ID,
+		ScheduleRequest: NewScheduleRequest(s.ScheduleSpec),
+		Principal:       s.Principal,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+		NextRun:         s.NextRun,
+		LastRun:         s.LastRun,
+		Running:         s.Running,
+		Skipped:         s.Skipped,
+	}
+}
+
+// Schedule converts s back into a service.Schedule.
+func (s Schedule) Schedule() (res service.Schedule, err error) {
+	res = service.Schedule{
+		ID:        s.ID,
+		Principal: s.Principal,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+		NextRun:   s.NextRun,
+		LastRun:   s.LastRun,
+		Running:   s.Running,
+		Skipped:   s.Skipped,
+	}
+	res.ScheduleSpec, err = s.spec()
+	return
+}
+
+// CreateScheduleRequest collects the request parameters for the CreateSchedule method.
+type CreateScheduleRequest struct {
+	ScheduleRequest
+}
+
+// CreateScheduleResponse collects the response parameters for the CreateSchedule method.
+type CreateScheduleResponse struct {
+	Schedule Schedule `json:"schedule"`
+	Err      error    `json:"-"`
+}
+
+// MakeCreateScheduleEndpoint returns an endpoint that invokes CreateSchedule on the service.
+func MakeCreateScheduleEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CreateScheduleRequest)
+		spec, err := req.spec()
+		if err != nil {
+			return CreateScheduleResponse{Err: err}, nil
+		}
+		schedule, err := s.CreateSchedule(ctx, spec)
+		return CreateScheduleResponse{
+			Err:      err,
+			Schedule: NewSchedule(schedule),
+		}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r CreateScheduleResponse) Failed() error {
+	return r.Err
+}
+
+// CreateSchedule implements Service. Primarily useful in a client.
+func (e Endpoints) CreateSchedule(ctx context.Context, spec service.ScheduleSpec) (schedule service.Schedule, err error) {
+	request := CreateScheduleRequest{NewScheduleRequest(spec)}
+	response, err := e.CreateScheduleEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	r := response.(CreateScheduleResponse)
+	if r.Err != nil {
+		return schedule, r.Err
+	}
+	return r.Schedule.Schedule()
+}
+
+// ListSchedulesRequest collects the request parameters for the ListSchedules method.
+type ListSchedulesRequest struct{}
+
+// ListSchedulesResponse collects the response parameters for the ListSchedules method.
+type ListSchedulesResponse struct {
+	Schedules []Schedule `json:"schedules"`
+	Err       error      `json:"-"`
+}
+
+// MakeListSchedulesEndpoint returns an endpoint that invokes ListSchedules on the service.
+func MakeListSchedulesEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		schedules, err := s.ListSchedules(ctx)
+		res := ListSchedulesResponse{Err: err, Schedules: []Schedule{}}
+		for _, schedule := range schedules {
+			res.Schedules = append(res.Schedules, NewSchedule(schedule))
+		}
+		return res, nil
+	}
+}
+
+// Failed implements Failer.
+func (r ListSchedulesResponse) Failed() error {
+	return r.Err
+}
+
+// ListSchedules implements Service. Primarily useful in a client.
+func (e Endpoints) ListSchedules(ctx context.Context) (schedules []service.Schedule, err error) {
+	response, err := e.ListSchedulesEndpoint(ctx, ListSchedulesRequest{})
+	if err != nil {
+		return
+	}
+	r := response.(ListSchedulesResponse)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	for _, s := range r.Schedules {
+		schedule, err := s.Schedule()
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
+// GetScheduleRequest collects the request parameters for the GetSchedule method.
+type GetScheduleRequest struct {
+	ID string `json:"id"`
+}
+
+// GetScheduleResponse collects the response parameters for the GetSchedule method.
+type GetScheduleResponse struct {
+	Schedule Schedule `json:"schedule"`
+	Err      error    `json:"-"`
+}
+
+// MakeGetScheduleEndpoint returns an endpoint that invokes GetSchedule on the service.
+func MakeGetScheduleEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetScheduleRequest)
+		schedule, err := s.GetSchedule(ctx, req.ID)
+		return GetScheduleResponse{
+			Err:      err,
+			Schedule: NewSchedule(schedule),
+		}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r GetScheduleResponse) Failed() error {
+	return r.Err
+}
+
+// GetSchedule implements Service. Primarily useful in a client.
+func (e Endpoints) GetSchedule(ctx context.Context, id string) (schedule service.Schedule, err error) {
+	request := GetScheduleRequest{ID: id}
+	response, err := e.GetScheduleEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	r := response.(GetScheduleResponse)
+	if r.Err != nil {
+		return schedule, r.Err
+	}
+	return r.Schedule.Schedule()
+}
+
+// UpdateScheduleRequest collects the request parameters for the UpdateSchedule method.
+type UpdateScheduleRequest struct {
+	ID string `json:"id"`
+	ScheduleRequest
+}
+
+// UpdateScheduleResponse collects the response parameters for the UpdateSchedule method.
+type UpdateScheduleResponse struct {
+	Schedule Schedule `json:"schedule"`
+	Err      error    `json:"-"`
+}
+
+// MakeUpdateScheduleEndpoint returns an endpoint that invokes UpdateSchedule on the service.
+func MakeUpdateScheduleEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdateScheduleRequest)
+		spec, err := req.spec()
+		if err != nil {
+			return UpdateScheduleResponse{Err: err}, nil
+		}
+		schedule, err := s.UpdateSchedule(ctx, req.ID, spec)
+		return UpdateScheduleResponse{
+			Err:      err,
+			Schedule: NewSchedule(schedule),
+		}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r UpdateScheduleResponse) Failed() error {
+	return r.Err
+}
+
+// UpdateSchedule implements Service. Primarily useful in a client.
+func (e Endpoints) UpdateSchedule(ctx context.Context, id string, spec service.ScheduleSpec) (schedule service.Schedule, err error) {
+	request := UpdateScheduleRequest{ID: id, ScheduleRequest: NewScheduleRequest(spec)}
+	response, err := e.UpdateScheduleEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	r := response.(UpdateScheduleResponse)
+	if r.Err != nil {
+		return schedule, r.Err
+	}
+	return r.Schedule.Schedule()
+}
+
+// DeleteScheduleRequest collects the request parameters for the DeleteSchedule method.
+type DeleteScheduleRequest struct {
+	ID string `json:"id"`
+}
+
+// DeleteScheduleResponse collects the response parameters for the DeleteSchedule method.
+type DeleteScheduleResponse struct {
+	Err error `json:"-"`
+}
+
+// MakeDeleteScheduleEndpoint returns an endpoint that invokes DeleteSchedule on the service.
+func MakeDeleteScheduleEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteScheduleRequest)
+		err := s.DeleteSchedule(ctx, req.ID)
+		return DeleteScheduleResponse{Err: err}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r DeleteScheduleResponse) Failed() error {
+	return r.Err
+}
+
+// DeleteSchedule implements Service. Primarily useful in a client.
+func (e Endpoints) DeleteSchedule(ctx context.Context, id string) (err error) {
+	request := DeleteScheduleRequest{ID: id}
+	response, err := e.DeleteScheduleEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	return response.(DeleteScheduleResponse).Err
+}
+
+// TemplateRequest is the definition of a command template, see
+// service.TemplateSpec. The parameters of ExecCmdRequest are the options of
+// every execution, and Mode can only be argv.
+type TemplateRequest struct {
+	Description string                  `json:"description,omitempty"`
+	Params      []service.TemplateParam `json:"params,omitempty"`
+	ExecCmdRequest
+}
+
+// NewTemplateRequest returns the TemplateRequest for spec.
+func NewTemplateRequest(spec service.TemplateSpec) TemplateRequest {
+	return TemplateRequest{
+		Description:    spec.Description,
+		Params:         spec.Params,
+		ExecCmdRequest: NewExecCmdRequest(spec.Cmd, spec.Options),
+	}
+}
+
+// spec converts the request into a service.TemplateSpec.
+func (r TemplateRequest) spec() (spec service.TemplateSpec, err error) {
+	spec = service.TemplateSpec{
+		Description: r.Description,
+		Cmd:         r.Cmd,
+		Params:      r.Params,
+	}
+	spec.Options, err = r.options()
+	return
+}
+
+// Template is a service.CommandTemplate, with its spec in the form of a
+// TemplateRequest.
+type Template struct {
+	Name string `json:"name"`
+	TemplateRequest
+	Principal string    `json:"principal,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewTemplate returns the Template for t.
+func NewTemplate(t service.CommandTemplate) Template {
+	return Template{
+		Name:            t.Name,
+		TemplateRequest: NewTemplateRequest(t.TemplateSpec),
+		Principal:       t.Principal,
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+	}
+}
+
+// Template converts t back into a service.CommandTemplate.
+func (t Template) Template() (res service.CommandTemplate, err error) {
+	res = service.CommandTemplate{
+		Name:      t.Name,
+		Principal: t.Principal,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+	res.TemplateSpec, err = t.spec()
+	return
+}
+
+// CreateTemplateRequest collects the request parameters for the CreateTemplate method.
+type CreateTemplateRequest struct {
+	Name string `json:"name"`
+	TemplateRequest
+}
+
+// CreateTemplateResponse collects the response parameters for the CreateTemplate method.
+type CreateTemplateResponse struct {
+	Template Template `json:"template"`
+	Err      error    `json:"-"`
+}
+
+// MakeCreateTemplateEndpoint returns an endpoint that invokes CreateTemplate on the service.
+func MakeCreateTemplateEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CreateTemplateRequest)
+		spec, err := req.spec()
+		if err != nil {
+			return CreateTemplateResponse{Err: err}, nil
+		}
+		tmpl, err := s.CreateTemplate(ctx, req.Name, spec)
+		return CreateTemplateResponse{
+			Err:      err,
+			Template: NewTemplate(tmpl),
+		}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r CreateTemplateResponse) Failed() error {
+	return r.Err
+}
+
+// CreateTemplate implements Service. Primarily useful in a client.
+func (e Endpoints) CreateTemplate(ctx context.Context, name string, spec service.TemplateSpec) (tmpl service.CommandTemplate, err error) {
+	request := CreateTemplateRequest{Name: name, TemplateRequest: NewTemplateRequest(spec)}
+	response, err := e.CreateTemplateEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	r := response.(CreateTemplateResponse)
+	if r.Err != nil {
+		return tmpl, r.Err
+	}
+	return r.Template.Template()
+}
+
+// ListTemplatesRequest collects the request parameters for the ListTemplates method.
+type ListTemplatesRequest struct{}
+
+// ListTemplatesResponse collects the response parameters for the ListTemplates method.
+type ListTemplatesResponse struct {
+	Templates []Template `json:"templates"`
+	Err       error      `json:"-"`
+}
+
+// MakeListTemplatesEndpoint returns an endpoint that invokes ListTemplates on the service.
+func MakeListTemplatesEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		tmpls, err := s.ListTemplates(ctx)
+		res := ListTemplatesResponse{Err: err, Templates: []Template{}}
+		for _, tmpl := range tmpls {
+			res.Templates = append(res.Templates, NewTemplate(tmpl))
+		}
+		return res, nil
+	}
+}
+
+// Failed implements Failer.
+func (r ListTemplatesResponse) Failed() error {
+	return r.Err
+}
+
+// ListTemplates implements Service. Primarily useful in a client.
+func (e Endpoints) ListTemplates(ctx context.Context) (tmpls []service.CommandTemplate, err error) {
+	response, err := e.ListTemplatesEndpoint(ctx, ListTemplatesRequest{})
+	if err != nil {
+		return
+	}
+	r := response.(ListTemplatesResponse)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	for _, t := range r.Templates {
+		tmpl, err := t.Template()
+		if err != nil {
+			return nil, err
+		}
+		tmpls = append(tmpls, tmpl)
+	}
+	return tmpls, nil
+}
+
+// GetTemplateRequest collects the request parameters for the GetTemplate method.
+type GetTemplateRequest struct {
+	Name string `json:"name"`
+}
+
+// GetTemplateResponse collects the response parameters for the GetTemplate method.
+type GetTemplateResponse struct {
+	Template Template `json:"template"`
+	Err      error    `json:"-"`
+}
+
+// MakeGetTemplateEndpoint returns an endpoint that invokes GetTemplate on the service.
+func MakeGetTemplateEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetTemplateRequest)
+		tmpl, err := s.GetTemplate(ctx, req.Name)
+		return GetTemplateResponse{
+			Err:      err,
+			Template: NewTemplate(tmpl),
+		}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r GetTemplateResponse) Failed() error {
+	return r.Err
+}
+
+// GetTemplate implements Service. Primarily useful in a client.
+func (e Endpoints) GetTemplate(ctx context.Context, name string) (tmpl service.CommandTemplate, err error) {
+	request := GetTemplateRequest{Name: name}
+	response, err := e.GetTemplateEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	r := response.(GetTemplateResponse)
+	if r.Err != nil {
+		return tmpl, r.Err
+	}
+	return r.Template.Template()
+}
+
+// UpdateTemplateRequest collects the request parameters for the UpdateTemplate method.
+type UpdateTemplateRequest struct {
+	Name string `json:"name"`
+	TemplateRequest
+}
+
+// UpdateTemplateResponse collects the response parameters for the UpdateTemplate method.
+type UpdateTemplateResponse struct {
+	Template Template `json:"template"`
+	Err      error    `json:"-"`
+}
+
+// MakeUpdateTemplateEndpoint returns an endpoint that invokes UpdateTemplate on the service.
+func MakeUpdateTemplateEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdateTemplateRequest)
+		spec, err := req.spec()
+		if err != nil {
+			return UpdateTemplateResponse{Err: err}, nil
+		}
+		tmpl, err := s.UpdateTemplate(ctx, req.Name, spec)
+		return UpdateTemplateResponse{
+			Err:      err,
+			Template: NewTemplate(tmpl),
+		}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r UpdateTemplateResponse) Failed() error {
+	return r.Err
+}
+
+// UpdateTemplate implements Service. Primarily useful in a client.
+func (e Endpoints) UpdateTemplate(ctx context.Context, name string, spec service.TemplateSpec) (tmpl service.CommandTemplate, err error) {
+	request := UpdateTemplateRequest{Name: name, TemplateRequest: NewTemplateRequest(spec)}
+	response, err := e.UpdateTemplateEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	r := response.(UpdateTemplateResponse)
+	if r.Err != nil {
+		return tmpl, r.Err
+	}
+	return r.Template.Template()
+}
+
+// DeleteTemplateRequest collects the request parameters for the DeleteTemplate method.
+type DeleteTemplateRequest struct {
+	Name string `json:"name"`
+}
+
+// DeleteTemplateResponse collects the response parameters for the DeleteTemplate method.
+type DeleteTemplateResponse struct {
+	Err error `json:"-"`
+}
+
+// MakeDeleteTemplateEndpoint returns an endpoint that invokes DeleteTemplate on the service.
+func MakeDeleteTemplateEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteTemplateRequest)
+		err := s.DeleteTemplate(ctx, req.Name)
+		return DeleteTemplateResponse{Err: err}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r DeleteTemplateResponse) Failed() error {
+	return r.Err
+}
+
+// DeleteTemplate implements Service. Primarily useful in a client.
+func (e Endpoints) DeleteTemplate(ctx context.Context, name string) (err error) {
+	request := DeleteTemplateRequest{Name: name}
+	response, err := e.DeleteTemplateEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	return response.(DeleteTemplateResponse).Err
+}
+
+// ExecTemplateRequest collects the request parameters for the ExecTemplate method.
+type ExecTemplateRequest struct {
+	Name   string            `json:"name"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// ExecTemplateResponse collects the response parameters for the ExecTemplate
+// method, the ones of ExecCmd.
+type ExecTemplateResponse struct {
+	ExecCmdResponse
+}
+
+// MakeExecTemplateEndpoint returns an endpoint that invokes ExecTemplate on the service.
+func MakeExecTemplateEndpoint(s service.BashExecService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ExecTemplateRequest)
+		res, err := s.ExecTemplate(ctx, req.Name, req.Params)
+		return ExecTemplateResponse{newExecCmdResponse(res, err)}, nil
+	}
+}
+
+// ExecTemplate implements Service. Primarily useful in a client.
+func (e Endpoints) ExecTemplate(ctx context.Context, name string, params map[string]string) (res service.ExecResult, err error) {
+	request := ExecTemplateRequest{Name: name, Params: params}
+	response, err := e.ExecTemplateEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	r := response.(ExecTemplateResponse)
+	return r.result(), r.Err
 }
@@ -10,15 +10,92 @@ import (
 // meant to be used as a helper struct, to collect all of the endpoints into a
 // single parameter.
 type Endpoints struct {
-	ExecCmdEndpoint endpoint.Endpoint
+	ExecCmdEndpoint        endpoint.Endpoint
+	SubmitJobEndpoint      endpoint.Endpoint
+	GetJobEndpoint         endpoint.Endpoint
+	CancelJobEndpoint      endpoint.Endpoint
+	ExecBatchEndpoint      endpoint.Endpoint
+	CreateScheduleEndpoint endpoint.Endpoint
+	ListSchedulesEndpoint  endpoint.Endpoint
+	GetScheduleEndpoint    endpoint.Endpoint
+	UpdateScheduleEndpoint endpoint.Endpoint
+	DeleteScheduleEndpoint endpoint.Endpoint
+	CreateTemplateEndpoint endpoint.Endpoint
+	ListTemplatesEndpoint  endpoint.Endpoint
+	GetTemplateEndpoint    endpoint.Endpoint
+	UpdateTemplateEndpoint endpoint.Endpoint
+	DeleteTemplateEndpoint endpoint.Endpoint
+	ExecTemplateEndpoint   endpoint.Endpoint
 }
 
 // New returns a Endpoints struct that wraps the provided service, and wires in all of the
 // expected endpoint middlewares
 func New(s service.BashExecService, mdw map[string][]endpoint.Middleware) Endpoints {
-	eps := Endpoints{ExecCmdEndpoint: MakeExecCmdEndpoint(s)}
+	eps := Endpoints{
+		CancelJobEndpoint:      MakeCancelJobEndpoint(s),
+		CreateScheduleEndpoint: MakeCreateScheduleEndpoint(s),
+		CreateTemplateEndpoint: MakeCreateTemplateEndpoint(s),
+		DeleteScheduleEndpoint: MakeDeleteScheduleEndpoint(s),
+		DeleteTemplateEndpoint: MakeDeleteTemplateEndpoint(s),
+		ExecBatchEndpoint:      MakeExecBatchEndpoint(s),
+		ExecCmdEndpoint:        MakeExecCmdEndpoint(s),
+		ExecTemplateEndpoint:   MakeExecTemplateEndpoint(s),
+		GetJobEndpoint:         MakeGetJobEndpoint(s),
+		GetScheduleEndpoint:    MakeGetScheduleEndpoint(s),
+		GetTemplateEndpoint:    MakeGetTemplateEndpoint(s),
+		ListSchedulesEndpoint:  MakeListSchedulesEndpoint(s),
+		ListTemplatesEndpoint:  MakeListTemplatesEndpoint(s),
+		SubmitJobEndpoint:      MakeSubmitJobEndpoint(s),
+		UpdateScheduleEndpoint: MakeUpdateScheduleEndpoint(s),
+		UpdateTemplateEndpoint: MakeUpdateTemplateEndpoint(s),
+	}
 	for _, m := range mdw["ExecCmd"] {
 		eps.ExecCmdEndpoint = m(eps.ExecCmdEndpoint)
 	}
+	for _, m := range mdw["SubmitJob"] {
+		eps.SubmitJobEndpoint = m(eps.SubmitJobEndpoint)
+	}
+	for _, m := range mdw["GetJob"] {
+		eps.GetJobEndpoint = m(eps.GetJobEndpoint)
+	}
+	for _, m := range mdw["CancelJob"] {
+		eps.CancelJobEndpoint = m(eps.CancelJobEndpoint)
+	}
+	for _, m := range mdw["ExecBatch"] {
+		eps.ExecBatchEndpoint = m(eps.ExecBatchEndpoint)
+	}
+	for _, m := range mdw["CreateSchedule"] {
+		eps.CreateScheduleEndpoint = m(eps.CreateScheduleEndpoint)
+	}
+	for _, m := range mdw["ListSchedules"] {
+		eps.ListSchedulesEndpoint = m(eps.ListSchedulesEndpoint)
+	}
+	for _, m := range mdw["GetSchedule"] {
+		eps.GetScheduleEndpoint = m(eps.GetScheduleEndpoint)
+	}
+	for _, m := range mdw["UpdateSchedule"] {
+		eps.UpdateScheduleEndpoint = m(eps.UpdateScheduleEndpoint)
+	}
+	for _, m := range mdw["DeleteSchedule"] {
+		eps.DeleteScheduleEndpoint = m(eps.DeleteScheduleEndpoint)
+	}
+	for _, m := range mdw["CreateTemplate"] {
+		eps.CreateTemplateEndpoint = m(eps.CreateTemplateEndpoint)
+	}
+	for _, m := range mdw["ListTemplates"] {
+		eps.ListTemplatesEndpoint = m(eps.ListTemplatesEndpoint)
+	}
+	for _, m := range mdw["GetTemplate"] {
+		eps.GetTemplateEndpoint = m(eps.GetTemplateEndpoint)
+	}
+	for _, m := range mdw["UpdateTemplate"] {
+		eps.UpdateTemplateEndpoint = m(eps.UpdateTemplateEndpoint)
+	}
+	for _, m := range mdw["DeleteTemplate"] {
+		eps.DeleteTemplateEndpoint = m(eps.DeleteTemplateEndpoint)
+	}
+	for _, m := range mdw["ExecTemplate"] {
+		eps.ExecTemplateEndpoint = m(eps.ExecTemplateEndpoint)
+	}
 	return eps
 }
@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	endpoint "bash_exec/pkg/endpoint"
+	pb "bash_exec/pkg/grpc/pb"
+	service "bash_exec/pkg/service"
+	"context"
+	auth "github.com/gigi214/services_example/pkg/auth"
+	"time"
+
+	grpc "github.com/go-kit/kit/transport/grpc"
+	metadata "google.golang.org/grpc/metadata"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuthToContext returns a transport/grpc.ServerRequestFunc that authenticates
+// the request with the API key or the bearer token of its metadata, like
+// the AuthToContext of the HTTP transport.
+func AuthToContext(a *auth.Authenticator) grpc.ServerRequestFunc {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		p, err := a.Metadata(md)
+		ctx = auth.ToContext(ctx, p, err)
+		if err != nil {
+			return ctx
+		}
+		return service.ContextWithCaller(ctx, p.ID)
+	}
+}
+
+// makeExecCmdHandler creates the handler logic
+func makeExecCmdHandler(endpoints endpoint.Endpoints, options []grpc.ServerOption) grpc.Handler {
+	return grpc.NewServer(endpoints.ExecCmdEndpoint, decodeExecCmdRequest, encodeExecCmdResponse, options...)
+}
+
+// decodeExecCmdRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain ExecCmd request.
+func decodeExecCmdRequest(_ context.Context, r interface{}) (interface{}, error) {
+	req := r.(*pb.ExecCmdRequest)
+	return endpoint.ExecCmdRequest{
+		Cmd:      req.Cmd,
+		Mode:     req.Mode,
+		Timeout:  req.Timeout,
+		Cwd:      req.Cwd,
+		Env:      req.Env,
+		CleanEnv: req.CleanEnv,
+		Stdin:    string(req.Stdin),
+		Limits:   decodeLimits(req.Limits),
+	}, nil
+}
+
+func decodeLimits(l *pb.Limits) *endpoint.ExecLimits {
+	if l == nil {
+		return nil
+	}
+	return &endpoint.ExecLimits{
+		CPUTime:     l.CpuTime,
+		MemoryBytes: l.MemoryBytes,
+		OpenFiles:   l.OpenFiles,
+		Processes:   l.Processes,
+		OutputBytes: l.OutputBytes,
+	}
+}
+
+// encodeExecCmdResponse is a transport/grpc.EncodeResponseFunc that converts
+// a user-domain response to a gRPC reply.
+func encodeExecCmdResponse(_ context.Context, r interface{}) (interface{}, error) {
+	resp := r.(endpoint.ExecCmdResponse)
+	reply := &pb.ExecCmdReply{
+		StdOut:          resp.StdOut,
+		StdErr:          resp.StdErr,
+		ExitCode:        int32(resp.ExitCode),
+		HistoryId:       resp.HistoryID,
+		Stats:           encodeExecStats(resp.ExecStats),
+		LimitExceeded:   resp.LimitExceeded,
+		StdoutBytes:     resp.StdoutBytes,
+		StdoutTruncated: resp.StdoutTruncated,
+		StderrBytes:     resp.StderrBytes,
+		StderrTruncated: resp.StderrTruncated,
+	}
+	if resp.Err != nil {
+		reply.Err = resp.Err.Error()
+	}
+	return reply, nil
+}
+func (g *grpcServer) ExecCmd(ctx context.Context, req *pb.ExecCmdRequest) (*pb.ExecCmdReply, error) {
+	_, rep, err := g.execCmd.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*pb.ExecCmdReply), nil
+}
+
+func encodeExecStats(s service.ExecStats) *pb.ExecStats {
+	return &pb.ExecStats{
+		StartedAt:    timestamp(s.StartedAt),
+		FinishedAt:   timestamp(s.FinishedAt),
+		Duration:     durationpb.New(s.Duration),
+		UserTime:     durationpb.New(s.UserTime),
+		SystemTime:   durationpb.New(s.SystemTime),
+		PeakRssBytes: s.PeakRSS,
+		Host:         s.Host,
+		InstanceId:   s.InstanceID,
+	}
+}
+
+// timestamp converts t to a protobuf Timestamp, nil for the zero time.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
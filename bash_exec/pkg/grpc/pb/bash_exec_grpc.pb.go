@@ -0,0 +1,101 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// BashExecClient is the client API for BashExec service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BashExecClient interface {
+	ExecCmd(ctx context.Context, in *ExecCmdRequest, opts ...grpc.CallOption) (*ExecCmdReply, error)
+}
+
+type bashExecClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBashExecClient(cc grpc.ClientConnInterface) BashExecClient {
+	return &bashExecClient{cc}
+}
+
+func (c *bashExecClient) ExecCmd(ctx context.Context, in *ExecCmdRequest, opts ...grpc.CallOption) (*ExecCmdReply, error) {
+	out := new(ExecCmdReply)
+	err := c.cc.Invoke(ctx, "/pb.BashExec/ExecCmd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BashExecServer is the server API for BashExec service.
+// All implementations must embed UnimplementedBashExecServer
+// for forward compatibility
+type BashExecServer interface {
+	ExecCmd(context.Context, *ExecCmdRequest) (*ExecCmdReply, error)
+	mustEmbedUnimplementedBashExecServer()
+}
+
+// UnimplementedBashExecServer must be embedded to have forward compatible implementations.
+type UnimplementedBashExecServer struct {
+}
+
+func (UnimplementedBashExecServer) ExecCmd(context.Context, *ExecCmdRequest) (*ExecCmdReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecCmd not implemented")
+}
+func (UnimplementedBashExecServer) mustEmbedUnimplementedBashExecServer() {}
+
+// UnsafeBashExecServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BashExecServer will
+// result in compilation errors.
+type UnsafeBashExecServer interface {
+	mustEmbedUnimplementedBashExecServer()
+}
+
+func RegisterBashExecServer(s grpc.ServiceRegistrar, srv BashExecServer) {
+	s.RegisterService(&BashExec_ServiceDesc, srv)
+}
+
+func _BashExec_ExecCmd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecCmdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BashExecServer).ExecCmd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BashExec/ExecCmd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BashExecServer).ExecCmd(ctx, req.(*ExecCmdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BashExec_ServiceDesc is the grpc.ServiceDesc for BashExec service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BashExec_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.BashExec",
+	HandlerType: (*BashExecServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ExecCmd",
+			Handler:    _BashExec_ExecCmd_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bash_exec.proto",
+}
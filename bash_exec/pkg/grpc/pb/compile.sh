@@ -0,0 +1,6 @@
+#!/usr/bin/env sh
+
+# Install proto3 from https://github.com/protocolbuffers/protobuf/releases and the Go plugins:
+#  go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.28.1
+#  go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.1.0
+protoc bash_exec.proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative
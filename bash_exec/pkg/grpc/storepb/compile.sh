@@ -0,0 +1,5 @@
+#!/usr/bin/env sh
+
+# store_cmds.proto is a copy of store_cmds/pkg/grpc/pb/store_cmds.proto, only
+# the go_package differs. The store proxy needs the client only.
+protoc store_cmds.proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative
@@ -0,0 +1,137 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package storepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// StoreCmdsClient is the client API for StoreCmds service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StoreCmdsClient interface {
+	Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreReply, error)
+	GetFromTo(ctx context.Context, in *GetFromToRequest, opts ...grpc.CallOption) (*GetFromToReply, error)
+}
+
+type storeCmdsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStoreCmdsClient(cc grpc.ClientConnInterface) StoreCmdsClient {
+	return &storeCmdsClient{cc}
+}
+
+func (c *storeCmdsClient) Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreReply, error) {
+	out := new(StoreReply)
+	err := c.cc.Invoke(ctx, "/pb.StoreCmds/Store", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeCmdsClient) GetFromTo(ctx context.Context, in *GetFromToRequest, opts ...grpc.CallOption) (*GetFromToReply, error) {
+	out := new(GetFromToReply)
+	err := c.cc.Invoke(ctx, "/pb.StoreCmds/GetFromTo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StoreCmdsServer is the server API for StoreCmds service.
+// All implementations must embed UnimplementedStoreCmdsServer
+// for forward compatibility
+type StoreCmdsServer interface {
+	Store(context.Context, *StoreRequest) (*StoreReply, error)
+	GetFromTo(context.Context, *GetFromToRequest) (*GetFromToReply, error)
+	mustEmbedUnimplementedStoreCmdsServer()
+}
+
+// UnimplementedStoreCmdsServer must be embedded to have forward compatible implementations.
+type UnimplementedStoreCmdsServer struct {
+}
+
+func (UnimplementedStoreCmdsServer) Store(context.Context, *StoreRequest) (*StoreReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Store not implemented")
+}
+func (UnimplementedStoreCmdsServer) GetFromTo(context.Context, *GetFromToRequest) (*GetFromToReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFromTo not implemented")
+}
+func (UnimplementedStoreCmdsServer) mustEmbedUnimplementedStoreCmdsServer() {}
+
+// UnsafeStoreCmdsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StoreCmdsServer will
+// result in compilation errors.
+type UnsafeStoreCmdsServer interface {
+	mustEmbedUnimplementedStoreCmdsServer()
+}
+
+func RegisterStoreCmdsServer(s grpc.ServiceRegistrar, srv StoreCmdsServer) {
+	s.RegisterService(&StoreCmds_ServiceDesc, srv)
+}
+
+func _StoreCmds_Store_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreCmdsServer).Store(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.StoreCmds/Store",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreCmdsServer).Store(ctx, req.(*StoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreCmds_GetFromTo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFromToRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreCmdsServer).GetFromTo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.StoreCmds/GetFromTo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreCmdsServer).GetFromTo(ctx, req.(*GetFromToRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StoreCmds_ServiceDesc is the grpc.ServiceDesc for StoreCmds service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StoreCmds_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.StoreCmds",
+	HandlerType: (*StoreCmdsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Store",
+			Handler:    _StoreCmds_Store_Handler,
+		},
+		{
+			MethodName: "GetFromTo",
+			Handler:    _StoreCmds_GetFromTo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "store_cmds.proto",
+}
@@ -2,34 +2,67 @@ package http
 
 import (
 	endpoint "bash_exec/pkg/endpoint"
+	service "bash_exec/pkg/service"
 	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
+	auth "github.com/gigi214/services_example/pkg/auth"
+	errs "github.com/gigi214/services_example/pkg/errs"
 	"net/http"
+	"strings"
+	"sync"
 
 	http1 "github.com/go-kit/kit/transport/http"
 )
 
+// ErrorEncoder writes err as {"error": {"code", "message", "details"}}, with
+// the HTTP status of its kind, see errs.Error.
 func ErrorEncoder(_ context.Context, err error, w http.ResponseWriter) {
-	w.WriteHeader(err2code(err))
-	json.NewEncoder(w).Encode(errorWrapper{Error: err.Error()})
+	e := errs.From(err)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(err2code(e))
+	json.NewEncoder(w).Encode(errorWrapper{Error: e})
 }
+
+// ErrorDecoder rebuilds the error written by ErrorEncoder, of the same kind,
+// so that the callers can check it with errors.Is against the errors of the
+// service. A body that cannot be decoded gives an error of the status alone.
 func ErrorDecoder(r *http.Response) error {
 	var w errorWrapper
-	if err := json.NewDecoder(r.Body).Decode(&w); err != nil {
-		return err
+	if err := json.NewDecoder(r.Body).Decode(&w); err != nil || w.Error == nil {
+		return errs.Decode(&errs.Error{}, r.StatusCode)
 	}
-	return errors.New(w.Error)
+	return errs.Decode(w.Error, r.StatusCode)
 }
 
 // This is used to set the http status, see an example here :
 // https://github.com/go-kit/kit/blob/master/examples/addsvc/pkg/addtransport/http.go#L133
-func err2code(err error) int {
-	return http.StatusInternalServerError
+func err2code(e *errs.Error) int {
+	return e.Status
 }
 
 type errorWrapper struct {
-	Error string `json:"error"`
+	Error *errs.Error `json:"error"`
+}
+
+// malformed reports the errors of the decoding of a request body.
+func malformed(err error) error {
+	return fmt.Errorf("%w: %v", errs.ErrMalformedRequest, err)
+}
+
+// AuthToContext returns a transport/http.RequestFunc that authenticates the
+// request with a, and stores the outcome in the context. The principal is the
+// caller seen by the service, see service.CallerFromContext. The requests that
+// failed are rejected by auth.Middleware.
+func AuthToContext(a *auth.Authenticator) http1.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		p, err := a.Request(r)
+		ctx = auth.ToContext(ctx, p, err)
+		if err != nil {
+			return ctx
+		}
+		return service.ContextWithCaller(ctx, p.ID)
+	}
 }
 
 // makeExecCmdHandler creates the handler logic
@@ -41,8 +74,10 @@ func makeExecCmdHandler(m *http.ServeMux, endpoints endpoint.Endpoints, options
 // JSON-encoded request from the HTTP request body.
 func decodeExecCmdRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := endpoint.ExecCmdRequest{}
-	err := json.NewDecoder(r.Body).Decode(&req)
-	return req, err
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
+	}
+	return req, nil
 }
 
 // encodeExecCmdResponse is a transport/http.EncodeResponseFunc that encodes
@@ -56,3 +91,297 @@ func encodeExecCmdResponse(ctx context.Context, w http.ResponseWriter, response
 	err = json.NewEncoder(w).Encode(response)
 	return
 }
+
+// makeExecCmdStreamHandler creates the handler logic of the streaming variant
+// of ExecCmd, that writes the output as newline-delimited JSON ExecCmdFrames
+// while the command runs.
+func makeExecCmdStreamHandler(m *http.ServeMux, endpoints endpoint.Endpoints, options []http1.ServerOption) {
+	server := http1.NewServer(endpoints.ExecCmdEndpoint, decodeExecCmdStreamRequest, encodeExecCmdStreamResponse, options...)
+	m.Handle("/exec-cmd/stream", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fw := &frameWriter{w: w}
+		server.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), frameWriterKey{}, fw)))
+	}))
+}
+
+// decodeExecCmdStreamRequest is a transport/http.DecodeRequestFunc that decodes
+// a JSON-encoded request from the HTTP request body, and streams its output
+// to the frameWriter of the request.
+func decodeExecCmdStreamRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := endpoint.ExecCmdRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
+	}
+	req.Output = ctx.Value(frameWriterKey{}).(*frameWriter).writeOutput
+	return req, nil
+}
+
+// encodeExecCmdStreamResponse is a transport/http.EncodeResponseFunc that
+// terminates the stream with the exit code frame. Failures that happen
+// before any output has been streamed are encoded as plain errors.
+func encodeExecCmdStreamResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	fw := ctx.Value(frameWriterKey{}).(*frameWriter)
+	resp := response.(endpoint.ExecCmdResponse)
+	if resp.Err != nil && !fw.started() {
+		ErrorEncoder(ctx, resp.Err, w)
+		return nil
+	}
+	frame := endpoint.ExecCmdFrame{Done: true, ExitCode: &resp.ExitCode, HistoryID: resp.HistoryID, LimitExceeded: resp.LimitExceeded, Stats: &resp.ExecStats}
+	if resp.Err != nil {
+		frame.Err = resp.Err.Error()
+	}
+	return fw.write(frame)
+}
+
+type frameWriterKey struct{}
+
+// frameWriter writes ExecCmdFrames to the response, flushing each of them.
+type frameWriter struct {
+	mtx      sync.Mutex
+	w        http.ResponseWriter
+	enc      *json.Encoder
+	writeErr error
+}
+
+func (f *frameWriter) started() bool {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.enc != nil
+}
+
+func (f *frameWriter) writeOutput(stream string, data []byte) {
+	f.write(endpoint.ExecCmdFrame{Stream: stream, Data: string(data)})
+}
+
+func (f *frameWriter) write(frame endpoint.ExecCmdFrame) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.writeErr != nil {
+		// The client is gone, the command is cancelled through the context.
+		return f.writeErr
+	}
+	if f.enc == nil {
+		f.w.Header().Set("Content-Type", "application/x-ndjson")
+		f.w.WriteHeader(http.StatusOK)
+		f.enc = json.NewEncoder(f.w)
+	}
+	if f.writeErr = f.enc.Encode(frame); f.writeErr != nil {
+		return f.writeErr
+	}
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// makeSubmitJobHandler creates the handler logic
+func makeSubmitJobHandler(m *http.ServeMux, endpoints endpoint.Endpoints, options []http1.ServerOption) {
+	m.Handle("/jobs", methods{"POST": http1.NewServer(endpoints.SubmitJobEndpoint, decodeSubmitJobRequest, encodeGenericResponse, options...)})
+}
+
+// decodeSubmitJobRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+func decodeSubmitJobRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := endpoint.SubmitJobRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
+	}
+	return req, nil
+}
+
+// makeJobHandler creates the handler logic of GET and DELETE /jobs/{id}
+func makeJobHandler(m *http.ServeMux, endpoints endpoint.Endpoints, getOptions, cancelOptions []http1.ServerOption) {
+	m.Handle("/jobs/", methods{
+		"GET":    http1.NewServer(endpoints.GetJobEndpoint, decodeGetJobRequest, encodeGenericResponse, getOptions...),
+		"DELETE": http1.NewServer(endpoints.CancelJobEndpoint, decodeCancelJobRequest, encodeGenericResponse, cancelOptions...),
+	})
+}
+
+// decodeGetJobRequest is a transport/http.DecodeRequestFunc that decodes the
+// job ID from the request path.
+func decodeGetJobRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return endpoint.GetJobRequest{ID: jobID(r)}, nil
+}
+
+// decodeCancelJobRequest is a transport/http.DecodeRequestFunc that decodes the
+// job ID from the request path.
+func decodeCancelJobRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return endpoint.CancelJobRequest{ID: jobID(r)}, nil
+}
+
+func jobID(r *http.Request) string {
+	return strings.TrimPrefix(r.URL.Path, "/jobs/")
+}
+
+// makeExecBatchHandler creates the handler logic
+func makeExecBatchHandler(m *http.ServeMux, endpoints endpoint.Endpoints, options []http1.ServerOption) {
+	m.Handle("/exec-batch", methods{"POST": http1.NewServer(endpoints.ExecBatchEndpoint, decodeExecBatchRequest, encodeGenericResponse, options...)})
+}
+
+// decodeExecBatchRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+func decodeExecBatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := endpoint.ExecBatchRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
+	}
+	return req, nil
+}
+
+// makeSchedulesHandler creates the handler logic of POST and GET /schedules
+func makeSchedulesHandler(m *http.ServeMux, endpoints endpoint.Endpoints, createOptions, listOptions []http1.ServerOption) {
+	m.Handle("/schedules", methods{
+		"POST": http1.NewServer(endpoints.CreateScheduleEndpoint, decodeCreateScheduleRequest, encodeGenericResponse, createOptions...),
+		"GET":  http1.NewServer(endpoints.ListSchedulesEndpoint, decodeListSchedulesRequest, encodeGenericResponse, listOptions...),
+	})
+}
+
+// decodeCreateScheduleRequest is a transport/http.DecodeRequestFunc that
+// decodes a JSON-encoded request from the HTTP request body.
+func decodeCreateScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := endpoint.CreateScheduleRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
+	}
+	return req, nil
+}
+
+func decodeListSchedulesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return endpoint.ListSchedulesRequest{}, nil
+}
+
+// makeScheduleHandler creates the handler logic of GET, PUT and DELETE /schedules/{id}
+func makeScheduleHandler(m *http.ServeMux, endpoints endpoint.Endpoints, getOptions, updateOptions, deleteOptions []http1.ServerOption) {
+	m.Handle("/schedules/", methods{
+		"GET":    http1.NewServer(endpoints.GetScheduleEndpoint, decodeGetScheduleRequest, encodeGenericResponse, getOptions...),
+		"PUT":    http1.NewServer(endpoints.UpdateScheduleEndpoint, decodeUpdateScheduleRequest, encodeGenericResponse, updateOptions...),
+		"DELETE": http1.NewServer(endpoints.DeleteScheduleEndpoint, decodeDeleteScheduleRequest, encodeGenericResponse, deleteOptions...),
+	})
+}
+
+// decodeGetScheduleRequest is a transport/http.DecodeRequestFunc that decodes
+// the schedule ID from the request path.
+func decodeGetScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return endpoint.GetScheduleRequest{ID: scheduleID(r)}, nil
+}
+
+// decodeUpdateScheduleRequest is a transport/http.DecodeRequestFunc that
+// decodes the schedule ID from the request path, and its new definition from
+// the JSON-encoded request body.
+func decodeUpdateScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := endpoint.UpdateScheduleRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
+	}
+	req.ID = scheduleID(r)
+	return req, nil
+}
+
+// decodeDeleteScheduleRequest is a transport/http.DecodeRequestFunc that
+// decodes the schedule ID from the request path.
+func decodeDeleteScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return endpoint.DeleteScheduleRequest{ID: scheduleID(r)}, nil
+}
+
+func scheduleID(r *http.Request) string {
+	return strings.TrimPrefix(r.URL.Path, "/schedules/")
+}
+
+// makeTemplatesHandler creates the handler logic of POST and GET /templates
+func makeTemplatesHandler(m *http.ServeMux, endpoints endpoint.Endpoints, createOptions, listOptions []http1.ServerOption) {
+	m.Handle("/templates", methods{
+		"POST": http1.NewServer(endpoints.CreateTemplateEndpoint, decodeCreateTemplateRequest, encodeGenericResponse, createOptions...),
+		"GET":  http1.NewServer(endpoints.ListTemplatesEndpoint, decodeListTemplatesRequest, encodeGenericResponse, listOptions...),
+	})
+}
+
+// decodeCreateTemplateRequest is a transport/http.DecodeRequestFunc that
+// decodes a JSON-encoded request from the HTTP request body.
+func decodeCreateTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := endpoint.CreateTemplateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
+	}
+	return req, nil
+}
+
+func decodeListTemplatesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return endpoint.ListTemplatesRequest{}, nil
+}
+
+// makeTemplateHandler creates the handler logic of GET, PUT and DELETE /templates/{name}
+func makeTemplateHandler(m *http.ServeMux, endpoints endpoint.Endpoints, getOptions, updateOptions, deleteOptions []http1.ServerOption) {
+	m.Handle("/templates/", methods{
+		"GET":    http1.NewServer(endpoints.GetTemplateEndpoint, decodeGetTemplateRequest, encodeGenericResponse, getOptions...),
+		"PUT":    http1.NewServer(endpoints.UpdateTemplateEndpoint, decodeUpdateTemplateRequest, encodeGenericResponse, updateOptions...),
+		"DELETE": http1.NewServer(endpoints.DeleteTemplateEndpoint, decodeDeleteTemplateRequest, encodeGenericResponse, deleteOptions...),
+	})
+}
+
+// decodeGetTemplateRequest is a transport/http.DecodeRequestFunc that decodes
+// the template name from the request path.
+func decodeGetTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return endpoint.GetTemplateRequest{Name: templateName(r, "/templates/")}, nil
+}
+
+// decodeUpdateTemplateRequest is a transport/http.DecodeRequestFunc that
+// decodes the template name from the request path, and its new definition
+// from the JSON-encoded request body.
+func decodeUpdateTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := endpoint.UpdateTemplateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
+	}
+	req.Name = templateName(r, "/templates/")
+	return req, nil
+}
+
+// decodeDeleteTemplateRequest is a transport/http.DecodeRequestFunc that
+// decodes the template name from the request path.
+func decodeDeleteTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return endpoint.DeleteTemplateRequest{Name: templateName(r, "/templates/")}, nil
+}
+
+// makeExecTemplateHandler creates the handler logic of POST /exec-template/{name}
+func makeExecTemplateHandler(m *http.ServeMux, endpoints endpoint.Endpoints, options []http1.ServerOption) {
+	m.Handle("/exec-template/", methods{"POST": http1.NewServer(endpoints.ExecTemplateEndpoint, decodeExecTemplateRequest, encodeGenericResponse, options...)})
+}
+
+// decodeExecTemplateRequest is a transport/http.DecodeRequestFunc that
+// decodes the template name from the request path, and the parameters from
+// the JSON-encoded request body, {"params": {"name": "value"}}.
+func decodeExecTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := endpoint.ExecTemplateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
+	}
+	req.Name = templateName(r, "/exec-template/")
+	return req, nil
+}
+
+func templateName(r *http.Request, prefix string) string {
+	return strings.TrimPrefix(r.URL.Path, prefix)
+}
+
+// encodeGenericResponse is a transport/http.EncodeResponseFunc that encodes
+// the response as JSON to the response writer
+func encodeGenericResponse(ctx context.Context, w http.ResponseWriter, response interface{}) (err error) {
+	if f, ok := response.(endpoint.Failure); ok && f.Failed() != nil {
+		ErrorEncoder(ctx, f.Failed(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	err = json.NewEncoder(w).Encode(response)
+	return
+}
+
+// methods routes a request to the handler registered for its HTTP method.
+type methods map[string]http.Handler
+
+func (m methods) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h, ok := m[r.Method]
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	h.ServeHTTP(w, r)
+}
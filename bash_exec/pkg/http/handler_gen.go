@@ -12,5 +12,14 @@ import (
 func NewHTTPHandler(endpoints endpoint.Endpoints, options map[string][]http.ServerOption) http1.Handler {
 	m := http1.NewServeMux()
 	makeExecCmdHandler(m, endpoints, options["ExecCmd"])
+	makeExecCmdStreamHandler(m, endpoints, options["ExecCmd"])
+	makeSubmitJobHandler(m, endpoints, options["SubmitJob"])
+	makeJobHandler(m, endpoints, options["GetJob"], options["CancelJob"])
+	makeExecBatchHandler(m, endpoints, options["ExecBatch"])
+	makeSchedulesHandler(m, endpoints, options["CreateSchedule"], options["ListSchedules"])
+	makeScheduleHandler(m, endpoints, options["GetSchedule"], options["UpdateSchedule"], options["DeleteSchedule"])
+	makeTemplatesHandler(m, endpoints, options["CreateTemplate"], options["ListTemplates"])
+	makeTemplateHandler(m, endpoints, options["GetTemplate"], options["UpdateTemplate"], options["DeleteTemplate"])
+	makeExecTemplateHandler(m, endpoints, options["ExecTemplate"])
 	return m
 }
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+)
+
+var ErrInvalidBatch = errs.New(http.StatusBadRequest, "invalid_batch", "invalid batch")
+
+// BatchStep is a step of a batch, a command or a group of commands that run in
+// parallel.
+type BatchStep struct {
+	// Name identifies the step in the results and in the templates of the
+	// later steps: "step1", "step2"... by position when empty, and
+	// "<group>_1", "<group>_2"... in a group.
+	Name string `json:"name,omitempty"`
+	// Cmd is a text/template rendered with the BatchData of the steps that
+	// ran before, e.g. "rm -r {{trim .Steps.mktemp.Stdout}}", where the
+	// function trim removes the blanks around a string. Cmd is split into
+	// words like with ExecModeArgv before the values of its actions are put
+	// in, so a value stays inside the word of its action, whatever it holds.
+	// The command of a step in ExecModeShell cannot have actions, no quoting
+	// keeps a value from being run by the shell in every context.
+	Cmd     string      `json:"cmd,omitempty"`
+	Options ExecOptions `json:"options"`
+	// ContinueOnError lets the batch go on when the step fails, when it cannot
+	// run or exits with a non-zero status.
+	ContinueOnError bool `json:"continue_on_error,omitempty"`
+	// Parallel are the steps of a group, that run at the same time instead of
+	// Cmd once the previous steps are done, and cannot refer to one another
+	// nor be groups. The group fails when one of them fails without
+	// ContinueOnError.
+	Parallel []BatchStep `json:"parallel,omitempty"`
+}
+
+// StepStatus is the outcome of a step of a batch.
+type StepStatus string
+
+const (
+	StepSucceeded StepStatus = "succeeded"
+	StepFailed    StepStatus = "failed"
+	// StepSkipped is the status of the steps after a failure, or after the
+	// batch was cancelled.
+	StepSkipped StepStatus = "skipped"
+)
+
+// StepResult is the outcome of a BatchStep.
+type StepResult struct {
+	Name   string     `json:"name"`
+	Status StepStatus `json:"status"`
+	// Cmd is the command rendered from the template of the step.
+	Cmd string `json:"cmd,omitempty"`
+	// Err tells why the step could not run, the exit status is given by
+	// Result.ExitCode.
+	Err string `json:"err,omitempty"`
+	// Result is the outcome of the execution of Cmd, nil when it did not start
+	// or for a group.
+	Result *ExecResult `json:"result,omitempty"`
+	// Parallel are the results of the steps of a group.
+	Parallel []StepResult `json:"parallel,omitempty"`
+}
+
+// BatchResult is the outcome of a batch.
+type BatchResult struct {
+	// ID is the ID of the batch, that links its executions in the history.
+	ID string `json:"id"`
+	// Success tells whether every step succeeded, but the ones that continue
+	// on error.
+	Success bool         `json:"success"`
+	Steps   []StepResult `json:"steps"`
+}
+
+// BatchData is what the command templates of a batch are rendered with.
+type BatchData struct {
+	ID string
+	// Steps are the outputs of the steps that ran before, by name, the steps
+	// of the groups included.
+	Steps map[string]StepOutput
+	// Prev is the output of the previous step, of a group it is the one of
+	// the step before the group.
+	Prev StepOutput
+}
+
+// StepOutput is the outcome of a step as seen by the templates of the later
+// steps. The output of a group is only its Status.
+type StepOutput struct {
+	Status   StepStatus
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// batchValueFunc is the function added at the end of the pipeline of every
+// action of a step template, that renders a marker of the value instead of
+// the value, see batchStep.render.
+const batchValueFunc = "batchValue"
+
+// The markers of the values are private use characters that the command of a
+// step cannot hold.
+const (
+	valueMarkerStart = "\uE000"
+	valueMarkerEnd   = "\uE001"
+)
+
+var valueMarkerRe = regexp.MustCompile(valueMarkerStart + `([0-9]+)` + valueMarkerEnd)
+
+var batchFuncs = template.FuncMap{
+	"trim":         strings.TrimSpace,
+	batchValueFunc: func(v interface{}) string { return fmt.Sprint(v) },
+}
+
+// batchStep is a BatchStep with its template compiled, or the steps of its group.
+type batchStep struct {
+	BatchStep
+	tmpl  *template.Template
+	group []*batchStep
+}
+
+// compileBatch validates steps, names them and compiles their templates. A
+// batch cannot have more than max commands, unless max is 0.
+func compileBatch(steps []BatchStep, max int) ([]*batchStep, error) {
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("%w: no step", ErrInvalidBatch)
+	}
+	names := map[string]bool{}
+	commands := 0
+	var compile func(s BatchStep, name string, inGroup bool) (*batchStep, error)
+	compile = func(s BatchStep, name string, inGroup bool) (*batchStep, error) {
+		if s.Name == "" {
+			s.Name = name
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("%w: duplicate step %s", ErrInvalidBatch, s.Name)
+		}
+		names[s.Name] = true
+
+		bs := &batchStep{BatchStep: s}
+		switch {
+		case len(s.Parallel) > 0 && inGroup:
+			return nil, fmt.Errorf("%w: step %s: a group cannot hold groups", ErrInvalidBatch, s.Name)
+		case len(s.Parallel) > 0 && s.Cmd != "":
+			return nil, fmt.Errorf("%w: step %s: both a command and a group", ErrInvalidBatch, s.Name)
+		case len(s.Parallel) > 0:
+			for i, p := range s.Parallel {
+				ps, err := compile(p, fmt.Sprintf("%s_%d", s.Name, i+1), true)
+				if err != nil {
+					return nil, err
+				}
+				bs.group = append(bs.group, ps)
+			}
+			return bs, nil
+		case strings.TrimSpace(s.Cmd) == "":
+			return nil, fmt.Errorf("%w: step %s: no command", ErrInvalidBatch, s.Name)
+		}
+
+		commands++
+		if strings.Contains(s.Cmd, valueMarkerStart) || strings.Contains(s.Cmd, valueMarkerEnd) {
+			return nil, fmt.Errorf("%w: step %s: the command holds U+E000 or U+E001", ErrInvalidBatch, s.Name)
+		}
+		tmpl, err := template.New(s.Name).Funcs(batchFuncs).Option("missingkey=error").Parse(s.Cmd)
+		if err != nil {
+			return nil, fmt.Errorf("%w: step %s: %v", ErrInvalidBatch, s.Name, err)
+		}
+		actions, err := markValues(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("%w: step %s: %v", ErrInvalidBatch, s.Name, err)
+		}
+		if actions > 0 && s.Options.Mode == ExecModeShell {
+			return nil, fmt.Errorf("%w: step %s: a command in shell mode cannot have template actions", ErrInvalidBatch, s.Name)
+		}
+		bs.tmpl = tmpl
+		return bs, nil
+	}
+
+	var batch []*batchStep
+	for i, s := range steps {
+		bs, err := compile(s, fmt.Sprintf("step%d", i+1), false)
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, bs)
+	}
+	if max > 0 && commands > max {
+		return nil, fmt.Errorf("%w: %d commands, at most %d", ErrInvalidBatch, commands, max)
+	}
+	return batch, nil
+}
+
+// ExecBatch runs the steps in order, each command through the whole
+// middleware chain like a synchronous execution, and stops at the first step
+// that fails without ContinueOnError. The steps that did not run are skipped.
+// The error is only set when the batch is invalid.
+func (b *basicBashExecService) ExecBatch(ctx context.Context, steps []BatchStep) (res BatchResult, err error) {
+	batch, err := compileBatch(steps, b.cfg.MaxBatchSteps)
+	if err != nil {
+		return
+	}
+	if res.ID, err = newID(); err != nil {
+		return
+	}
+
+	data := BatchData{ID: res.ID, Steps: map[string]StepOutput{}}
+	res.Success = true
+	for _, s := range batch {
+		if !res.Success || ctx.Err() != nil {
+			res.Success = false
+			res.Steps = append(res.Steps, s.skipped())
+			continue
+		}
+		var r StepResult
+		if s.group != nil {
+			r = b.runGroup(ctx, res.ID, s, data)
+		} else {
+			r = b.runStep(ctx, res.ID, s, data)
+		}
+		data.add(r)
+		if r.Status == StepFailed && !s.ContinueOnError {
+			res.Success = false
+		}
+		res.Steps = append(res.Steps, r)
+	}
+	return res, nil
+}
+
+// markValues adds batchValueFunc at the end of the pipeline of the actions of
+// t that print a value, like html/template adds its escapers, and returns how
+// many there are.
+func markValues(t *template.Template) (int, error) {
+	if len(t.Templates()) > 1 {
+		return 0, fmt.Errorf("define and block are not supported")
+	}
+	actions := 0
+	var walk func(n parse.Node) error
+	walk = func(n parse.Node) error {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return nil
+			}
+			for _, c := range n.Nodes {
+				if err := walk(c); err != nil {
+					return err
+				}
+			}
+		case *parse.ActionNode:
+			// An action that only declares variables prints nothing.
+			if len(n.Pipe.Decl) == 0 {
+				fn := parse.NewIdentifier(batchValueFunc).SetTree(t.Tree).SetPos(n.Pos)
+				n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{NodeType: parse.NodeCommand, Pos: n.Pos, Args: []parse.Node{fn}})
+				actions++
+			}
+		case *parse.IfNode:
+			return walkBranch(walk, &n.BranchNode)
+		case *parse.RangeNode:
+			return walkBranch(walk, &n.BranchNode)
+		case *parse.WithNode:
+			return walkBranch(walk, &n.BranchNode)
+		case *parse.TemplateNode:
+			return fmt.Errorf("template %q: nested templates are not supported", n.Name)
+		}
+		return nil
+	}
+	return actions, walk(t.Tree.Root)
+}
+
+func walkBranch(walk func(parse.Node) error, n *parse.BranchNode) error {
+	if err := walk(n.List); err != nil {
+		return err
+	}
+	return walk(n.ElseList)
+}
+
+// render renders the command of s with data, quoted so that ExecModeArgv
+// splits it into the words of the template. The actions print markers, the
+// text is split into words, and the values replace the markers in the words,
+// so that a value can neither add words nor be read as quotes. A value that
+// starts a word cannot start with "-", so that it cannot be read as an option.
+func (s *batchStep) render(data BatchData) (string, error) {
+	cmd, err := s.renderWords(data)
+	if err != nil {
+		return "", fmt.Errorf("%w: step %s: %v", ErrInvalidBatch, s.Name, err)
+	}
+	return cmd, nil
+}
+
+func (s *batchStep) renderWords(data BatchData) (string, error) {
+	tmpl, err := s.tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+	var values []string
+	tmpl.Funcs(template.FuncMap{batchValueFunc: func(v interface{}) string {
+		values = append(values, fmt.Sprint(v))
+		return valueMarkerStart + strconv.Itoa(len(values)-1) + valueMarkerEnd
+	}})
+	var text strings.Builder
+	if err = tmpl.Execute(&text, data); err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return text.String(), nil
+	}
+
+	// Every marker is in a word, since it is not blank.
+	words, err := SplitArgs(text.String())
+	if err != nil {
+		return "", err
+	}
+	for i, w := range words {
+		word := valueMarkerRe.ReplaceAllStringFunc(w, func(m string) string {
+			n, _ := strconv.Atoi(valueMarkerRe.FindStringSubmatch(m)[1])
+			return values[n]
+		})
+		// A word that only starts with "-" once its markers are replaced
+		// starts with a value, after the empty ones.
+		if strings.HasPrefix(word, "-") && !strings.HasPrefix(w, "-") {
+			return "", fmt.Errorf("word %q starts with - from a value", word)
+		}
+		words[i] = quoteArg(word)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// runStep renders the command of s with data and executes it.
+func (b *basicBashExecService) runStep(ctx context.Context, batchID string, s *batchStep, data BatchData) StepResult {
+	r := StepResult{Name: s.Name}
+	cmd, err := s.render(data)
+	if err != nil {
+		r.Status, r.Err = StepFailed, err.Error()
+		return r
+	}
+	r.Cmd = cmd
+
+	opts := s.Options
+	opts.BatchID, opts.BatchStep = batchID, s.Name
+	res, err := b.svc.ExecCmd(ctx, r.Cmd, opts)
+	if !res.StartedAt.IsZero() {
+		r.Result = &res
+	}
+	switch {
+	case err != nil:
+		r.Status, r.Err = StepFailed, err.Error()
+	case res.ExitCode != 0:
+		r.Status = StepFailed
+	default:
+		r.Status = StepSucceeded
+	}
+	return r
+}
+
+// runGroup runs the steps of the group s at the same time.
+func (b *basicBashExecService) runGroup(ctx context.Context, batchID string, s *batchStep, data BatchData) StepResult {
+	r := StepResult{Name: s.Name, Status: StepSucceeded, Parallel: make([]StepResult, len(s.group))}
+	var wg sync.WaitGroup
+	for i, p := range s.group {
+		wg.Add(1)
+		go func(i int, p *batchStep) {
+			defer wg.Done()
+			r.Parallel[i] = b.runStep(ctx, batchID, p, data)
+		}(i, p)
+	}
+	wg.Wait()
+	for i, p := range s.group {
+		if r.Parallel[i].Status == StepFailed && !p.ContinueOnError {
+			r.Status = StepFailed
+		}
+	}
+	return r
+}
+
+// skipped returns the result of s when it does not run.
+func (s *batchStep) skipped() StepResult {
+	r := StepResult{Name: s.Name, Status: StepSkipped}
+	for _, p := range s.group {
+		r.Parallel = append(r.Parallel, p.skipped())
+	}
+	return r
+}
+
+// add makes the result of a step, and of the steps of its group, available
+// to the templates of the next steps.
+func (d *BatchData) add(r StepResult) {
+	d.Prev = r.output()
+	d.Steps[r.Name] = d.Prev
+	for _, p := range r.Parallel {
+		d.Steps[p.Name] = p.output()
+	}
+}
+
+func (r StepResult) output() StepOutput {
+	out := StepOutput{Status: r.Status}
+	if r.Result != nil {
+		out.ExitCode, out.Stdout, out.Stderr = r.Result.ExitCode, r.Result.StdOut, r.Result.StdErr
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBatchStepRender(t *testing.T) {
+	data := BatchData{
+		ID: "b1",
+		Steps: map[string]StepOutput{
+			"mktemp": {Status: StepSucceeded, Stdout: "/tmp/x y\n"},
+			"flag":   {Status: StepSucceeded, Stdout: "--help"},
+		},
+		Prev: StepOutput{Status: StepFailed, ExitCode: 2, Stdout: `a'b "c" $(id) ; rm -rf /`},
+	}
+	tests := []struct {
+		name string
+		cmd  string
+		mode ExecMode
+		want []string
+		err  error
+	}{
+		{name: "no action", cmd: "ls -l '/a b'", want: []string{"ls", "-l", "/a b"}},
+		{name: "value is one word", cmd: "echo {{.Prev.Stdout}}", want: []string{"echo", `a'b "c" $(id) ; rm -rf /`}},
+		{name: "value inside a word", cmd: "rm -r {{trim .Steps.mktemp.Stdout}}/sub", want: []string{"rm", "-r", "/tmp/x y/sub"}},
+		{name: "value inside double quotes", cmd: `echo "out: {{.Prev.Stdout}}"`, want: []string{"echo", `out: a'b "c" $(id) ; rm -rf /`}},
+		{name: "value inside single quotes", cmd: `echo '{{.Prev.ExitCode}}'`, want: []string{"echo", "2"}},
+		{name: "leading - starting a word", cmd: "ls {{.Steps.flag.Stdout}}", err: ErrInvalidBatch},
+		{name: "leading - after an empty value", cmd: "ls {{.Prev.Stderr}}{{.Steps.flag.Stdout}}", err: ErrInvalidBatch},
+		{name: "leading - inside double quotes", cmd: `ls "{{.Steps.flag.Stdout}}"`, err: ErrInvalidBatch},
+		{name: "leading - inside a word", cmd: "ls --x={{.Steps.flag.Stdout}}", want: []string{"ls", "--x=--help"}},
+		{name: "option of the template", cmd: "ls -{{.Prev.ExitCode}}", want: []string{"ls", "-2"}},
+		{name: "no more quote", cmd: "echo {{quote .Prev.Stdout}}", err: ErrInvalidBatch},
+		{name: "empty value", cmd: `test -n {{.Prev.Stderr}}`, want: []string{"test", "-n", ""}},
+		{name: "actions in if", cmd: `echo {{if eq .Prev.Status "failed"}}{{.ID}} {{.Prev.ExitCode}}{{end}}`, want: []string{"echo", "b1", "2"}},
+		{name: "variables print nothing", cmd: `{{$out := .Prev.Stdout}}printf %s {{$out}}`, want: []string{"printf", "%s", `a'b "c" $(id) ; rm -rf /`}},
+		{name: "shell without actions", cmd: "ls | wc -l", mode: ExecModeShell, want: []string{"ls", "|", "wc", "-l"}},
+		{name: "shell with actions", cmd: "echo {{.ID}} | wc -c", mode: ExecModeShell, err: ErrInvalidBatch},
+		{name: "missing step", cmd: "echo {{.Steps.nope.Stdout}}", err: ErrInvalidBatch},
+		{name: "nested template", cmd: `{{define "x"}}id{{end}}{{template "x"}}`, err: ErrInvalidBatch},
+		{name: "marker in the command", cmd: "echo \uE0000\uE001", err: ErrInvalidBatch},
+		{name: "unterminated quote", cmd: "echo '{{.ID}}", err: ErrInvalidBatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch, err := compileBatch([]BatchStep{{Cmd: tt.cmd, Options: ExecOptions{Mode: tt.mode}}}, 0)
+			if err == nil {
+				var cmd string
+				if cmd, err = batch[0].render(data); err == nil {
+					got, err := SplitArgs(cmd)
+					if err != nil {
+						t.Fatalf("SplitArgs(%q) error = %v", cmd, err)
+					}
+					if !reflect.DeepEqual(got, tt.want) {
+						t.Errorf("render(%q) = %q, split into %q, want %q", tt.cmd, cmd, got, tt.want)
+					}
+				}
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("render(%q) error = %v, want %v", tt.cmd, err, tt.err)
+			}
+		})
+	}
+}
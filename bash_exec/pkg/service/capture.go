@@ -0,0 +1,104 @@
+package service
+
+import (
+	"unicode/utf8"
+)
+
+// OutputStats tells how much a command wrote on each stream, and whether
+// StdOut and StdErr only hold the head and the tail of it, see Config.MaxOutput.
+type OutputStats struct {
+	StdoutBytes     int64 `json:"stdout_bytes"`
+	StdoutTruncated bool  `json:"stdout_truncated"`
+	StderrBytes     int64 `json:"stderr_bytes"`
+	StderrTruncated bool  `json:"stderr_truncated"`
+}
+
+func newOutputStats(stdout, stderr *capture) OutputStats {
+	return OutputStats{
+		StdoutBytes:     stdout.n,
+		StdoutTruncated: stdout.truncated(),
+		StderrBytes:     stderr.n,
+		StderrTruncated: stderr.truncated(),
+	}
+}
+
+// capture keeps the output written on a stream when it is at most max bytes,
+// and else its first and its last max/2 bytes, so that a command cannot make
+// the service hold more. It keeps everything when max is 0.
+type capture struct {
+	max  int
+	n    int64
+	head []byte
+	// tail is a ring buffer, written at pos, that is full once wrapped.
+	tail    []byte
+	pos     int
+	wrapped bool
+}
+
+func newCapture(max int) *capture {
+	return &capture{max: max}
+}
+
+func (c *capture) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	data := p
+	if headMax := c.max / 2; c.max <= 0 || len(c.head) < headMax {
+		k := len(data)
+		if c.max > 0 && k > headMax-len(c.head) {
+			k = headMax - len(c.head)
+		}
+		c.head = append(c.head, data[:k]...)
+		data = data[k:]
+	}
+	if len(data) == 0 {
+		return len(p), nil
+	}
+	tailMax := c.max - c.max/2
+	if c.tail == nil {
+		c.tail = make([]byte, tailMax)
+	}
+	if len(data) >= tailMax {
+		copy(c.tail, data[len(data)-tailMax:])
+		c.pos, c.wrapped = 0, true
+		return len(p), nil
+	}
+	k := copy(c.tail[c.pos:], data)
+	if k < len(data) {
+		copy(c.tail, data[k:])
+		c.wrapped = true
+	}
+	c.pos = (c.pos + len(data)) % tailMax
+	if c.pos == 0 && k == len(data) {
+		c.wrapped = true
+	}
+	return len(p), nil
+}
+
+// truncated reports whether some of the output was dropped.
+func (c *capture) truncated() bool {
+	return c.max > 0 && c.n > int64(c.max)
+}
+
+// String returns the output kept, the head followed by the tail when it was
+// truncated. The runes split by the cut are dropped, so that a text output
+// stays valid UTF-8.
+func (c *capture) String() string {
+	tail := c.tail[:c.pos]
+	if c.wrapped {
+		tail = append(append(make([]byte, 0, len(c.tail)), c.tail[c.pos:]...), c.tail[:c.pos]...)
+	}
+	if !c.truncated() {
+		return string(c.head) + string(tail)
+	}
+	head := c.head
+	for i := 0; i < utf8.UTFMax-1 && len(head) > 0; i++ {
+		if r, size := utf8.DecodeLastRune(head); r != utf8.RuneError || size > 1 {
+			break
+		}
+		head = head[:len(head)-1]
+	}
+	for i := 0; i < utf8.UTFMax-1 && len(tail) > 0 && !utf8.RuneStart(tail[0]); i++ {
+		tail = tail[1:]
+	}
+	return string(head) + string(tail)
+}
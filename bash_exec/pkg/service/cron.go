@@ -0,0 +1,179 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidCron = errors.New("invalid cron expression")
+
+// cronSchedule is a parsed cron expression, the minutes, hours, days of the
+// month, months and days of the week it matches as bit sets.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny tell whether the day fields start with "*". Like in
+	// cron, a day matches when both fields match it if one of them does, and
+	// when either does otherwise.
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = [5]cronField{
+	{min: 0, max: 59},
+	{min: 0, max: 23},
+	{min: 1, max: 31},
+	{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	// Sunday is both 0 and 7.
+	{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCron parses a cron expression of five fields, minute, hour, day of the
+// month, month and day of the week, or one of the macros @yearly, @monthly,
+// @weekly, @daily and @hourly. A field is a list of values, ranges and "*",
+// each optionally followed by a step, e.g. "*/15" or "1-5,sat"; months and
+// days of the week can be given by the first three letters of their names.
+func parseCron(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = m
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w: %q has %d fields, want %d", errInvalidCron, expr, len(fields), len(cronFields))
+	}
+	var sets [5]uint64
+	for i, f := range fields {
+		set, err := cronFields[i].parse(f)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", errInvalidCron, f, err)
+		}
+		sets[i] = set
+	}
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return &cronSchedule{
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		domAny: strings.HasPrefix(fields[2], "*"), dowAny: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parse returns the set of the values f matches.
+func (c cronField) parse(f string) (set uint64, err error) {
+	for _, part := range strings.Split(f, ",") {
+		rng, step := part, 1
+		if r, s, ok := strings.Cut(part, "/"); ok {
+			if step, err = strconv.Atoi(s); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", s)
+			}
+			rng = r
+		}
+		lo, hi := c.min, c.max
+		first, last, isRange := strings.Cut(rng, "-")
+		switch {
+		case rng == "*":
+		case isRange:
+			if lo, err = c.value(first); err != nil {
+				return 0, err
+			}
+			if hi, err = c.value(last); err != nil {
+				return 0, err
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		default:
+			if lo, err = c.value(rng); err != nil {
+				return 0, err
+			}
+			// A single value with a step starts a range, like in cron.
+			if step == 1 {
+				hi = lo
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func (c cronField) value(s string) (int, error) {
+	if v, ok := c.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < c.min || v > c.max {
+		return 0, fmt.Errorf("invalid value %q, want %d to %d", s, c.min, c.max)
+	}
+	return v, nil
+}
+
+// next returns the first minute after t that s matches, in the location of t,
+// or the zero time when there is none within 5 years, e.g. for February 30.
+// A time skipped by a daylight saving change does not match, and a time
+// repeated by one matches once.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	loc := t.Location()
+	after := wallClock(t)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		var next time.Time
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0 || !wallClock(t).After(after):
+			next = t.Add(time.Minute)
+		default:
+			return t
+		}
+		// time.Date normalizes a time skipped by a daylight saving change to
+		// the hour before it, the next hour starts the search again.
+		if !next.After(t) {
+			next = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		}
+		t = next
+	}
+	return time.Time{}
+}
+
+// wallClock returns the minute t shows on a clock of its location, as a UTC time.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
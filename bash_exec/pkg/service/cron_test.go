@@ -0,0 +1,119 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr string
+		want *cronSchedule
+		err  bool
+	}{
+		{expr: "* * * * *", want: &cronSchedule{minute: 1<<60 - 1, hour: 1<<24 - 1, dom: 1<<32 - 2, month: 1<<13 - 2, dow: 1<<8 - 1, domAny: true, dowAny: true}},
+		{expr: "0,30 9-17/4 1 jan-mar/2 mon-fri", want: &cronSchedule{minute: 1 | 1<<30, hour: 1<<9 | 1<<13 | 1<<17, dom: 1 << 1, month: 1<<1 | 1<<3, dow: 0b111110}},
+		{expr: "5/20 * * * *", want: &cronSchedule{minute: 1<<5 | 1<<25 | 1<<45, hour: 1<<24 - 1, dom: 1<<32 - 2, month: 1<<13 - 2, dow: 1<<8 - 1, domAny: true, dowAny: true}},
+		{expr: "0 0 * * 7", want: &cronSchedule{minute: 1, hour: 1, dom: 1<<32 - 2, month: 1<<13 - 2, dow: 1<<7 | 1, domAny: true}},
+		{expr: "0 0 */2 * SUN", want: &cronSchedule{minute: 1, hour: 1, dom: 0xaaaaaaaa, month: 1<<13 - 2, dow: 1, domAny: true}},
+		{expr: " @Weekly ", want: &cronSchedule{minute: 1, hour: 1, dom: 1<<32 - 2, month: 1<<13 - 2, dow: 1, domAny: true}},
+		{expr: "* * * *", err: true},
+		{expr: "* * * * * *", err: true},
+		{expr: "60 * * * *", err: true},
+		{expr: "* 24 * * *", err: true},
+		{expr: "* * 0 * *", err: true},
+		{expr: "* * * 13 *", err: true},
+		{expr: "* * * * 8", err: true},
+		{expr: "*/0 * * * *", err: true},
+		{expr: "5-1 * * * *", err: true},
+		{expr: "1,,2 * * * *", err: true},
+		{expr: "* * * foo *", err: true},
+		{expr: "@every", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := parseCron(tt.expr)
+			if tt.err {
+				if !errors.Is(err, errInvalidCron) {
+					t.Fatalf("parseCron(%q) error = %v, want %v", tt.expr, err, errInvalidCron)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != *tt.want {
+				t.Errorf("parseCron(%q) = %+v, want %+v", tt.expr, *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	tests := []struct {
+		name string
+		expr string
+		loc  *time.Location
+		from string
+		want string
+	}{
+		{name: "every minute", expr: "* * * * *", from: "2026-10-16 10:07:30", want: "2026-10-16 10:08:00"},
+		{name: "next quarter", expr: "*/15 * * * *", from: "2026-10-16 10:15:00", want: "2026-10-16 10:30:00"},
+		{name: "next day", expr: "30 9 * * *", from: "2026-10-16 10:00:00", want: "2026-10-17 09:30:00"},
+		{name: "next year", expr: "@yearly", from: "2026-10-16 10:00:00", want: "2027-01-01 00:00:00"},
+		{name: "weekdays", expr: "0 9 * * mon-fri", from: "2026-10-16 10:00:00", want: "2026-10-19 09:00:00"},
+		{name: "sunday as 7", expr: "0 9 * * 7", from: "2026-10-16 10:00:00", want: "2026-10-18 09:00:00"},
+		// 2026-10-16 is a Friday, both day fields are set so either matches.
+		{name: "day of month or of week", expr: "0 0 13 * fri", from: "2026-10-10 00:00:00", want: "2026-10-13 00:00:00"},
+		{name: "day of week or of month", expr: "0 0 13 * fri", from: "2026-10-13 00:00:00", want: "2026-10-16 00:00:00"},
+		{name: "day of month only", expr: "0 0 13 * *", from: "2026-10-13 00:00:00", want: "2026-11-13 00:00:00"},
+		{name: "day of week only", expr: "0 0 * * fri", from: "2026-10-13 00:00:00", want: "2026-10-16 00:00:00"},
+		// A day field that starts with * restricts the other one.
+		{name: "starred day of month and day of week", expr: "0 0 */2 * fri", from: "2026-10-16 00:00:00", want: "2026-10-23 00:00:00"},
+		{name: "31st", expr: "0 0 31 * *", from: "2026-10-31 00:00:00", want: "2026-12-31 00:00:00"},
+		{name: "leap day", expr: "0 0 29 2 *", from: "2026-10-16 00:00:00", want: "2028-02-29 00:00:00"},
+		{name: "february 30", expr: "0 0 30 2 *", from: "2026-10-16 00:00:00", want: ""},
+		{name: "skipped by daylight saving", expr: "30 2 * * *", loc: newYork, from: "2026-03-07 03:00:00", want: "2026-03-09 02:30:00"},
+		{name: "after daylight saving", expr: "30 3 * * *", loc: newYork, from: "2026-03-08 00:00:00", want: "2026-03-08 03:30:00"},
+		{name: "repeated by daylight saving", expr: "30 1 * * *", loc: newYork, from: "2026-11-01 00:00:00", want: "2026-11-01 01:30:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+			s, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			from, err := time.ParseInLocation("2006-01-02 15:04:05", tt.from, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := s.next(from)
+			if tt.want == "" {
+				if !got.IsZero() {
+					t.Errorf("next(%s) = %s, want none", from, got)
+				}
+				return
+			}
+			want, _ := time.ParseInLocation("2006-01-02 15:04:05", tt.want, loc)
+			if !got.Equal(want) {
+				t.Errorf("next(%s) = %s, want %s", from, got, want)
+			}
+		})
+	}
+
+	// A time repeated by the end of daylight saving matches once.
+	s, _ := parseCron("30 1 * * *")
+	first := s.next(time.Date(2026, 11, 1, 0, 0, 0, 0, newYork))
+	if got, want := s.next(first), time.Date(2026, 11, 2, 1, 30, 0, 0, newYork); !got.Equal(want) {
+		t.Errorf("next(%s) = %s, want %s", first, got, want)
+	}
+}
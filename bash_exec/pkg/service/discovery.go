@@ -0,0 +1,494 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/circuitbreaker"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/ratelimit"
+	"github.com/go-kit/kit/sd"
+	log "github.com/go-kit/log"
+	"github.com/sony/gobreaker"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultDNSTTL        = 30 * time.Second
+	defaultFileInterval  = 2 * time.Second
+	defaultConsulDelay   = time.Second
+	maxDiscoveryBackoff  = time.Minute
+	consulWait           = time.Minute
+	consulRequestTimeout = consulWait + 15*time.Second
+)
+
+// NewStoreInstancer returns the sd.Instancer that finds the instances of the
+// store service addr describes, one of:
+//
+//	host:port,grpc://host:port                    a fixed list
+//	dnssrv://_store._tcp.example.com?ttl=30s      the targets of the SRV records of the name, resolved every ttl
+//	file:///etc/store-instances?interval=2s       the lines of the file, read again every interval
+//	consul://localhost:8500/store-cmds?tag=&dc=   the instances of the service that pass their health checks in a Consul catalog
+//
+// The instances a discovery finds are called over HTTP, or over gRPC with the
+// transport=grpc query parameter, unless they start with their own scheme.
+// Lines of the file that are empty or start with # are ignored.
+func NewStoreInstancer(addr string, logger log.Logger) (sd.Instancer, error) {
+	scheme, _, _ := strings.Cut(addr, "://")
+	switch scheme {
+	case "dnssrv", "file", "consul":
+	default:
+		return sd.FixedInstancer(splitInstances(addr)), nil
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("store service discovery %q: %w", addr, err)
+	}
+	q := u.Query()
+	var prefix string
+	switch q.Get("transport") {
+	case "", "http":
+	case "grpc":
+		prefix = "grpc://"
+	default:
+		return nil, fmt.Errorf("store service discovery %q: unknown transport %q", addr, q.Get("transport"))
+	}
+	logger = log.With(logger, "discovery", u.Redacted())
+
+	switch u.Scheme {
+	case "dnssrv":
+		ttl, err := durationParam(q, "ttl", defaultDNSTTL)
+		if err != nil {
+			return nil, fmt.Errorf("store service discovery %q: %w", addr, err)
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("store service discovery %q: missing SRV name", addr)
+		}
+		return newPollInstancer(lookupSRV(u.Host, prefix), ttl, logger), nil
+	case "file":
+		interval, err := durationParam(q, "interval", defaultFileInterval)
+		if err != nil {
+			return nil, fmt.Errorf("store service discovery %q: %w", addr, err)
+		}
+		// file://instances.txt names a file relative to the working directory.
+		path := u.Host + u.Path
+		if path == "" {
+			return nil, fmt.Errorf("store service discovery %q: missing file path", addr)
+		}
+		return newPollInstancer(readInstances(path, prefix), interval, logger), nil
+	default:
+		service := strings.Trim(u.Path, "/")
+		if u.Host == "" || service == "" {
+			return nil, fmt.Errorf("store service discovery %q: want consul://host:port/service", addr)
+		}
+		params := url.Values{"passing": {"1"}, "wait": {consulWait.String()}}
+		for _, k := range []string{"tag", "dc"} {
+			if v := q.Get(k); v != "" {
+				params.Set(k, v)
+			}
+		}
+		c := &consulCatalog{
+			url:    (&url.URL{Scheme: "http", Host: u.Host, Path: "/v1/health/service/" + service, RawQuery: params.Encode()}).String(),
+			prefix: prefix,
+			client: &http.Client{Timeout: consulRequestTimeout},
+		}
+		return newPollInstancer(c.lookup, defaultConsulDelay, logger), nil
+	}
+}
+
+func durationParam(q url.Values, key string, def time.Duration) (time.Duration, error) {
+	s := q.Get(key)
+	if s == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", key, s)
+	}
+	return d, nil
+}
+
+// withScheme prefixes the instance with the scheme of a discovery, unless it
+// has its own.
+func withScheme(prefix, instance string) string {
+	if strings.Contains(instance, "://") {
+		return instance
+	}
+	return prefix + instance
+}
+
+// lookupSRV resolves the SRV records of name. Priorities and weights are
+// ignored, the balancer spreads the requests on every target.
+func lookupSRV(name, prefix string) func(context.Context) ([]string, error) {
+	return func(ctx context.Context) ([]string, error) {
+		_, records, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			return nil, err
+		}
+		instances := make([]string, 0, len(records))
+		for _, r := range records {
+			if r.Port == 0 {
+				continue
+			}
+			instances = append(instances, withScheme(prefix, net.JoinHostPort(strings.TrimSuffix(r.Target, "."), strconv.Itoa(int(r.Port)))))
+		}
+		return instances, nil
+	}
+}
+
+// readInstances reads the instances of the file at path, one per line.
+func readInstances(path, prefix string) func(context.Context) ([]string, error) {
+	return func(context.Context) ([]string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var instances []string
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				instances = append(instances, withScheme(prefix, line))
+			}
+		}
+		return instances, nil
+	}
+}
+
+// consulCatalog queries the health endpoint of the Consul HTTP API, with
+// blocking queries that return when the instances of the service change.
+type consulCatalog struct {
+	url    string
+	prefix string
+	client *http.Client
+	index  uint64
+}
+
+type consulServiceEntry struct {
+	Node struct {
+		Address string
+	}
+	Service struct {
+		Address string
+		Port    int
+	}
+}
+
+func (c *consulCatalog) lookup(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"&index="+strconv.FormatUint(c.index, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("consul: %s", resp.Status)
+	}
+	var entries []consulServiceEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("consul: %w", err)
+	}
+	// An index that goes backwards, e.g. when the catalog is restored, starts
+	// the blocking queries again.
+	index, err := strconv.ParseUint(resp.Header.Get("X-Consul-Index"), 10, 64)
+	if err != nil || index < c.index {
+		index = 0
+	}
+	c.index = index
+
+	instances := make([]string, 0, len(entries))
+	for _, e := range entries {
+		host := e.Service.Address
+		if host == "" {
+			host = e.Node.Address
+		}
+		instances = append(instances, withScheme(c.prefix, net.JoinHostPort(host, strconv.Itoa(e.Service.Port))))
+	}
+	return instances, nil
+}
+
+// pollInstancer is an sd.Instancer that publishes the instances a lookup
+// returns. The lookup is called again interval after it succeeds, and after
+// a delay that doubles up to a minute while it fails.
+type pollInstancer struct {
+	cache  instanceCache
+	cancel context.CancelFunc
+}
+
+func newPollInstancer(lookup func(context.Context) ([]string, error), interval time.Duration, logger log.Logger) *pollInstancer {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &pollInstancer{cancel: cancel}
+	// The first lookup is done right away, so that the instances are known
+	// before the first request.
+	delay := p.poll(ctx, lookup, interval, 0, logger)
+	go func() {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+			delay = p.poll(ctx, lookup, interval, delay, logger)
+			timer.Reset(delay)
+		}
+	}()
+	return p
+}
+
+// poll calls lookup, publishes what it returns and returns the delay before
+// the next call.
+func (p *pollInstancer) poll(ctx context.Context, lookup func(context.Context) ([]string, error), interval, delay time.Duration, logger log.Logger) time.Duration {
+	instances, err := lookup(ctx)
+	if ctx.Err() != nil {
+		return interval
+	}
+	p.cache.update(sd.Event{Instances: instances, Err: err})
+	if err == nil {
+		return interval
+	}
+	if delay < interval {
+		delay = interval
+	} else if delay *= 2; delay > maxDiscoveryBackoff {
+		delay = maxDiscoveryBackoff
+	}
+	logger.Log("during", "Discovery", "retry_in", delay, "err", err)
+	return delay
+}
+
+// Register implements sd.Instancer.
+func (p *pollInstancer) Register(ch chan<- sd.Event) { p.cache.register(ch) }
+
+// Deregister implements sd.Instancer.
+func (p *pollInstancer) Deregister(ch chan<- sd.Event) { p.cache.deregister(ch) }
+
+// Stop implements sd.Instancer.
+func (p *pollInstancer) Stop() { p.cancel() }
+
+// instanceCache keeps the last instances found and sends them to the
+// registered channels when they change. A failed lookup keeps the instances
+// found before it, with its error.
+type instanceCache struct {
+	mtx   sync.Mutex
+	state sd.Event
+	reg   map[chan<- sd.Event]struct{}
+}
+
+func (c *instanceCache) update(event sd.Event) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if event.Err != nil {
+		event.Instances = c.state.Instances
+	}
+	sort.Strings(event.Instances)
+	if reflect.DeepEqual(c.state, event) {
+		return
+	}
+	c.state = event
+	for ch := range c.reg {
+		ch <- event
+	}
+}
+
+func (c *instanceCache) register(ch chan<- sd.Event) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.reg == nil {
+		c.reg = map[chan<- sd.Event]struct{}{}
+	}
+	c.reg[ch] = struct{}{}
+	ch <- c.state
+}
+
+func (c *instanceCache) deregister(ch chan<- sd.Event) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.reg, ch)
+}
+
+// storeEndpointer is the sd.Endpointer of the store service instances. It
+// follows the instances an Instancer finds, so that the balancer built on it
+// uses them as soon as they are found, and tracks their health with a circuit
+// breaker each. The instances whose breaker is open, after they failed several
+// times in a row, are left out of the endpoints until the breaker lets a
+// request through again, unless every instance is.
+type storeEndpointer struct {
+	instancer sd.Instancer
+	factory   sd.Factory
+	cfg       StoreClientConfig
+	logger    log.Logger
+	events    chan sd.Event
+
+	mtx       sync.RWMutex
+	instances []*storeInstance // sorted by address
+	err       error
+}
+
+type storeInstance struct {
+	address  string
+	endpoint endpoint.Endpoint
+	closer   io.Closer
+	breaker  *gobreaker.CircuitBreaker
+}
+
+// newStoreEndpointer returns a storeEndpointer of the instances of instancer.
+// The endpoint of each instance is made by factory, with the timeout, breaker
+// and rate limit of cfg.
+func newStoreEndpointer(instancer sd.Instancer, factory sd.Factory, cfg StoreClientConfig, logger log.Logger) *storeEndpointer {
+	e := &storeEndpointer{
+		instancer: instancer,
+		factory:   factory,
+		cfg:       cfg,
+		logger:    logger,
+		events:    make(chan sd.Event),
+	}
+	go e.receive()
+	instancer.Register(e.events)
+	return e
+}
+
+func (e *storeEndpointer) receive() {
+	for event := range e.events {
+		e.update(event)
+	}
+}
+
+func (e *storeEndpointer) update(event sd.Event) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	// The instances found before a failed lookup are kept, the instancer
+	// logs the failure.
+	if event.Err != nil {
+		e.err = event.Err
+		return
+	}
+	e.err = nil
+
+	current := make(map[string]*storeInstance, len(e.instances))
+	for _, i := range e.instances {
+		current[i.address] = i
+	}
+	instances := make([]*storeInstance, 0, len(event.Instances))
+	for _, address := range event.Instances {
+		if i, ok := current[address]; ok {
+			instances = append(instances, i)
+			delete(current, address)
+			continue
+		}
+		i, err := e.newInstance(address)
+		if err != nil {
+			e.logger.Log("store_instance", address, "err", err)
+			continue
+		}
+		instances = append(instances, i)
+	}
+	for _, i := range current {
+		if i.closer != nil {
+			i.closer.Close()
+		}
+		// A gauge cannot drop the series of the instance, it is reset so
+		// that a breaker left open does not report the instance down forever.
+		e.cfg.BreakerState.With("instance", i.address).Set(float64(gobreaker.StateClosed))
+	}
+	sort.Slice(instances, func(a, b int) bool { return instances[a].address < instances[b].address })
+	e.instances = instances
+
+	addresses := make([]string, len(instances))
+	for n, i := range instances {
+		addresses[n] = i.address
+	}
+	e.logger.Log("call_to", fmt.Sprint(addresses))
+}
+
+func (e *storeEndpointer) newInstance(address string) (*storeInstance, error) {
+	ep, closer, err := e.factory(address)
+	if err != nil {
+		return nil, err
+	}
+	if timeout := e.cfg.AttemptTimeout; timeout > 0 {
+		next := ep
+		ep = func(ctx context.Context, request interface{}) (interface{}, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next(ctx, request)
+		}
+	}
+
+	settings := gobreaker.Settings{
+		Name:        address,
+		MaxRequests: e.cfg.BreakerProbes,
+		Interval:    e.cfg.BreakerInterval,
+		Timeout:     e.cfg.BreakerOpenTimeout,
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			e.logger.Log("store_instance", name, "breaker", to.String())
+			e.cfg.BreakerState.With("instance", name).Set(float64(to))
+			e.cfg.BreakerTransitions.With("instance", name, "state", to.String()).Add(1)
+		},
+	}
+	if failures := e.cfg.BreakerFailures; failures > 0 {
+		settings.ReadyToTrip = func(counts gobreaker.Counts) bool { return counts.ConsecutiveFailures >= failures }
+	}
+	breaker := gobreaker.NewCircuitBreaker(settings)
+	e.cfg.BreakerState.With("instance", address).Set(float64(gobreaker.StateClosed))
+	ep = circuitbreaker.Gobreaker(breaker)(ep)
+
+	if e.cfg.QPS > 0 {
+		burst := e.cfg.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(e.cfg.QPS))
+		}
+		ep = ratelimit.NewErroringLimiter(rate.NewLimiter(rate.Limit(e.cfg.QPS), burst))(ep)
+	}
+	return &storeInstance{address: address, endpoint: ep, closer: closer, breaker: breaker}, nil
+}
+
+// Endpoints implements sd.Endpointer. It returns the endpoints of the healthy
+// instances, or of every instance when none is.
+func (e *storeEndpointer) Endpoints() ([]endpoint.Endpoint, error) {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+	if len(e.instances) == 0 {
+		return nil, e.err
+	}
+	endpoints := make([]endpoint.Endpoint, 0, len(e.instances))
+	for _, i := range e.instances {
+		if i.breaker.State() != gobreaker.StateOpen {
+			endpoints = append(endpoints, i.endpoint)
+		}
+	}
+	if len(endpoints) == 0 {
+		for _, i := range e.instances {
+			endpoints = append(endpoints, i.endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+// Close stops following the instances and closes their connections.
+func (e *storeEndpointer) Close() {
+	e.instancer.Deregister(e.events)
+	close(e.events)
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	for _, i := range e.instances {
+		if i.closer != nil {
+			i.closer.Close()
+		}
+	}
+	e.instances = nil
+}
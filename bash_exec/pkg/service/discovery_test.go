@@ -0,0 +1,394 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/sd"
+	log "github.com/go-kit/log"
+	"github.com/sony/gobreaker"
+)
+
+func writeInstances(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "instances")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// firstEvent returns the event an Instancer sends when a channel registers.
+func firstEvent(t *testing.T, instancer sd.Instancer) sd.Event {
+	t.Helper()
+	ch := make(chan sd.Event, 1)
+	instancer.Register(ch)
+	defer instancer.Deregister(ch)
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+	}
+	return sd.Event{}
+}
+
+func TestNewStoreInstancer(t *testing.T) {
+	file := writeInstances(t, "# store instances\nstore-1:8081\n\n  store-2:8081  \nhttp://store-3:8081\n")
+	consul := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v1/health/service/store-cmds" || q.Get("passing") != "1" || q.Get("tag") != "v1" || q.Get("dc") != "" {
+			http.Error(w, r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("X-Consul-Index", "1")
+		fmt.Fprint(w, `[{"Node":{"Address":"10.0.0.1"},"Service":{"Port":8081}}]`)
+	}))
+	defer consul.Close()
+	consulHost := strings.TrimPrefix(consul.URL, "http://")
+
+	tests := []struct {
+		name string
+		addr string
+		want []string
+		err  bool
+	}{
+		{name: "fixed", addr: "store-1:8081, grpc://store-2:8082", want: []string{"store-1:8081", "grpc://store-2:8082"}},
+		{name: "file", addr: "file://" + file, want: []string{"http://store-3:8081", "store-1:8081", "store-2:8081"}},
+		{name: "file over grpc", addr: "file://" + file + "?transport=grpc&interval=1m", want: []string{"grpc://store-1:8081", "grpc://store-2:8081", "http://store-3:8081"}},
+		{name: "consul", addr: "consul://" + consulHost + "/store-cmds?tag=v1", want: []string{"10.0.0.1:8081"}},
+		{name: "unknown transport", addr: "file://" + file + "?transport=thrift", err: true},
+		{name: "invalid interval", addr: "file://" + file + "?interval=-1s", err: true},
+		{name: "missing file path", addr: "file://", err: true},
+		{name: "invalid ttl", addr: "dnssrv://_store._tcp.example.com?ttl=often", err: true},
+		{name: "missing SRV name", addr: "dnssrv://?ttl=1s", err: true},
+		{name: "missing consul service", addr: "consul://" + consulHost, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instancer, err := NewStoreInstancer(tt.addr, log.NewNopLogger())
+			if tt.err {
+				if err == nil {
+					instancer.Stop()
+					t.Fatalf("NewStoreInstancer(%q) succeeded, want an error", tt.addr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer instancer.Stop()
+			event := firstEvent(t, instancer)
+			if event.Err != nil || !reflect.DeepEqual(event.Instances, tt.want) {
+				t.Errorf("instances = %q, %v, want %q", event.Instances, event.Err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadInstances(t *testing.T) {
+	path := writeInstances(t, "store-1:8081\r\n#store-2:8081\ngrpc://store-3:8082\n")
+	got, err := readInstances(path, "grpc://")(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"grpc://store-1:8081", "grpc://store-3:8082"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readInstances = %q, want %q", got, want)
+	}
+	if _, err = readInstances(filepath.Join(t.TempDir(), "missing"), "")(context.Background()); err == nil {
+		t.Error("readInstances of a missing file succeeded")
+	}
+}
+
+func TestConsulCatalog(t *testing.T) {
+	// Each response is the status, the index and the body the fake returns
+	// when queried with index.
+	type response struct {
+		index  string
+		status int
+		header string
+		body   string
+	}
+	responses := []response{
+		{index: "0", header: "5", body: `[{"Node":{"Address":"10.0.0.1"},"Service":{"Port":8081}},{"Node":{"Address":"10.0.0.1"},"Service":{"Address":"10.0.1.2","Port":8082}}]`},
+		{index: "5", header: "7", body: `[{"Node":{"Address":"10.0.0.1"},"Service":{"Port":8081}}]`},
+		// The index goes backwards when the catalog is restored.
+		{index: "7", header: "3", body: `[]`},
+		{index: "0", status: http.StatusInternalServerError},
+		{index: "0", header: "not a number", body: `[{"Node":{"Address":"::1"},"Service":{"Port":8081}}]`},
+		{index: "0", header: "2", body: `{`},
+	}
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := responses[n]
+		n++
+		if got := r.URL.Query().Get("index"); got != resp.index {
+			t.Errorf("request %d index = %s, want %s", n, got, resp.index)
+		}
+		if resp.status != 0 {
+			w.WriteHeader(resp.status)
+			return
+		}
+		w.Header().Set("X-Consul-Index", resp.header)
+		fmt.Fprint(w, resp.body)
+	}))
+	defer srv.Close()
+
+	c := &consulCatalog{url: srv.URL + "/v1/health/service/store-cmds?passing=1", prefix: "grpc://", client: srv.Client()}
+	wants := [][]string{
+		{"grpc://10.0.0.1:8081", "grpc://10.0.1.2:8082"},
+		{"grpc://10.0.0.1:8081"},
+		{},
+		nil,
+		{"grpc://[::1]:8081"},
+		nil,
+	}
+	for i, want := range wants {
+		got, err := c.lookup(context.Background())
+		if want == nil {
+			if err == nil {
+				t.Errorf("lookup %d = %q, want an error", i+1, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("lookup %d = %q, %v, want %q", i+1, got, err, want)
+		}
+	}
+}
+
+func TestPollInstancer(t *testing.T) {
+	var (
+		mtx     sync.Mutex
+		results = []error{nil, nil, errors.New("unreachable"), nil}
+		calls   int
+	)
+	lookup := func(context.Context) ([]string, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		calls++
+		switch {
+		case calls > len(results):
+			return []string{"b", "a"}, nil
+		case results[calls-1] != nil:
+			return nil, results[calls-1]
+		}
+		return []string{"a"}, nil
+	}
+	p := newPollInstancer(lookup, time.Millisecond, log.NewNopLogger())
+	ch := make(chan sd.Event, 10)
+	p.Register(ch)
+
+	// The same instances are sent once, the ones found before a failure are
+	// kept with its error.
+	wants := []sd.Event{
+		{Instances: []string{"a"}},
+		{Instances: []string{"a"}, Err: results[2]},
+		{Instances: []string{"a"}},
+		{Instances: []string{"a", "b"}},
+	}
+	for _, want := range wants {
+		select {
+		case got := <-ch:
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("event = %+v, want %+v", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no event, want %+v", want)
+		}
+	}
+	p.Deregister(ch)
+	p.Stop()
+	mtx.Lock()
+	stopped := calls
+	mtx.Unlock()
+	time.Sleep(20 * time.Millisecond)
+	mtx.Lock()
+	defer mtx.Unlock()
+	// A lookup in progress when Stop is called may still finish.
+	if calls > stopped+1 {
+		t.Errorf("%d lookups after Stop", calls-stopped)
+	}
+}
+
+func TestPollInstancerBackoff(t *testing.T) {
+	failing := func(context.Context) ([]string, error) { return nil, errors.New("unreachable") }
+	tests := []struct {
+		delay time.Duration
+		want  time.Duration
+	}{
+		{delay: 0, want: time.Second},
+		{delay: time.Second, want: 2 * time.Second},
+		{delay: 20 * time.Second, want: 40 * time.Second},
+		{delay: 40 * time.Second, want: maxDiscoveryBackoff},
+		{delay: maxDiscoveryBackoff, want: maxDiscoveryBackoff},
+	}
+	p := &pollInstancer{}
+	for _, tt := range tests {
+		if got := p.poll(context.Background(), failing, time.Second, tt.delay, log.NewNopLogger()); got != tt.want {
+			t.Errorf("poll after %s = %s, want %s", tt.delay, got, tt.want)
+		}
+	}
+	succeeding := func(context.Context) ([]string, error) { return nil, nil }
+	if got := p.poll(context.Background(), succeeding, time.Second, maxDiscoveryBackoff, log.NewNopLogger()); got != time.Second {
+		t.Errorf("poll after a success = %s, want %s", got, time.Second)
+	}
+}
+
+// testGauge is a metrics.Gauge that keeps the value of each label value.
+type testGauge struct {
+	mtx    *sync.Mutex
+	values map[string]float64
+	label  string
+}
+
+func newTestGauge() *testGauge {
+	return &testGauge{mtx: &sync.Mutex{}, values: map[string]float64{}}
+}
+
+func (g *testGauge) With(labelValues ...string) metrics.Gauge {
+	return &testGauge{mtx: g.mtx, values: g.values, label: strings.Join(labelValues, ",")}
+}
+
+func (g *testGauge) Set(value float64) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.values[g.label] = value
+}
+
+func (g *testGauge) Add(delta float64) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.values[g.label] += delta
+}
+
+func (g *testGauge) value(labelValues ...string) float64 {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return g.values[strings.Join(labelValues, ",")]
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
+func TestStoreEndpointer(t *testing.T) {
+	var (
+		mtx     sync.Mutex
+		failing = map[string]bool{}
+		closed  = map[string]bool{}
+	)
+	factory := func(instance string) (endpoint.Endpoint, io.Closer, error) {
+		ep := func(context.Context, interface{}) (interface{}, error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			if failing[instance] {
+				return nil, errors.New("unavailable")
+			}
+			return instance, nil
+		}
+		return ep, closerFunc(func() error {
+			mtx.Lock()
+			defer mtx.Unlock()
+			closed[instance] = true
+			return nil
+		}), nil
+	}
+	state := newTestGauge()
+	cfg := StoreClientConfig{
+		BreakerFailures:    1,
+		BreakerOpenTimeout: time.Hour,
+		BreakerState:       state,
+		BreakerTransitions: discard.NewCounter(),
+	}
+	instancer := &pollInstancer{cancel: func() {}}
+	e := newStoreEndpointer(instancer, factory, cfg, log.NewNopLogger())
+	defer e.Close()
+
+	// instances returns the instances of the endpoints, once the endpointer
+	// has received the last event.
+	instances := func(want int) []string {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			eps, err := e.Endpoints()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(eps) == want || time.Now().After(deadline) {
+				var res []string
+				for _, ep := range eps {
+					instance, _ := ep(context.Background(), nil)
+					res = append(res, fmt.Sprint(instance))
+				}
+				return res
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	instancer.cache.update(sd.Event{Instances: []string{"c", "a", "b"}})
+	if got, want := instances(3), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("endpoints = %q, want %q", got, want)
+	}
+
+	// An instance that fails opens its breaker and is left out.
+	mtx.Lock()
+	failing["b"] = true
+	mtx.Unlock()
+	instances(3)
+	if got, want := instances(2), []string{"a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("endpoints without b = %q, want %q", got, want)
+	}
+	if got := state.value("instance", "b"); got != float64(gobreaker.StateOpen) {
+		t.Errorf("breaker state of b = %v, want open", got)
+	}
+
+	// When every breaker is open, every instance is used.
+	mtx.Lock()
+	failing["a"], failing["c"] = true, true
+	mtx.Unlock()
+	instances(2)
+	e.mtx.RLock()
+	for _, i := range e.instances {
+		if i.breaker.State() != gobreaker.StateOpen {
+			t.Errorf("breaker of %s is %s, want open", i.address, i.breaker.State())
+		}
+	}
+	e.mtx.RUnlock()
+	if eps, _ := e.Endpoints(); len(eps) != 3 {
+		t.Errorf("%d endpoints with every breaker open, want 3", len(eps))
+	}
+
+	// A failed lookup keeps the instances.
+	instancer.cache.update(sd.Event{Err: errors.New("unreachable")})
+	// The instances dropped are closed, and their breaker state reset.
+	instancer.cache.update(sd.Event{Instances: []string{"a", "d"}})
+	if got, want := instances(1), []string{"d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("endpoints = %q, want %q", got, want)
+	}
+	mtx.Lock()
+	if !closed["b"] || !closed["c"] || closed["a"] {
+		t.Errorf("closed = %v, want b and c", closed)
+	}
+	mtx.Unlock()
+	if got := state.value("instance", "b"); got != float64(gobreaker.StateClosed) {
+		t.Errorf("breaker state of the dropped b = %v, want closed", got)
+	}
+	if got := state.value("instance", "a"); got != float64(gobreaker.StateOpen) {
+		t.Errorf("breaker state of a = %v, want open", got)
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+)
+
+var (
+	ErrInvalidCwd   = errs.New(http.StatusBadRequest, "invalid_cwd", "invalid working directory")
+	ErrCwdForbidden = errs.New(http.StatusForbidden, "cwd_forbidden", "working directory not allowed")
+	ErrInvalidEnv   = errs.New(http.StatusBadRequest, "invalid_env", "invalid environment variable")
+	ErrEnvForbidden = errs.New(http.StatusForbidden, "env_forbidden", "environment variable not allowed")
+	ErrInvalidStdin = errs.New(http.StatusBadRequest, "invalid_stdin", "invalid stdin")
+)
+
+// setEnvironment sets the working directory, the environment and the standard
+// input of c from opts, once they are checked against the directories and the
+// variables allowed by the configuration.
+func (b *basicBashExecService) setEnvironment(c *exec.Cmd, opts ExecOptions) error {
+	if opts.Cwd != "" {
+		dir, err := b.allowedDir(opts.Cwd)
+		if err != nil {
+			return err
+		}
+		c.Dir = dir
+	}
+
+	names := make([]string, 0, len(opts.Env))
+	for name := range opts.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	vars := make([]string, 0, len(names))
+	for _, name := range names {
+		value := opts.Env[name]
+		if name == "" || strings.ContainsAny(name, "=\x00") || strings.ContainsRune(value, 0) {
+			return fmt.Errorf("%w: %q", ErrInvalidEnv, name)
+		}
+		if !b.allowedEnv(name) {
+			return errs.WithDetails(fmt.Errorf("%w: %s", ErrEnvForbidden, name), map[string]string{"name": name})
+		}
+		vars = append(vars, name+"="+value)
+	}
+	// A nil Env gives the command the environment of the service, the
+	// variables of the request override it.
+	switch {
+	case opts.CleanEnv:
+		c.Env = vars
+	case len(vars) > 0:
+		c.Env = append(os.Environ(), vars...)
+	}
+
+	if len(opts.Stdin) > 0 {
+		c.Stdin = bytes.NewReader(opts.Stdin)
+	}
+	return nil
+}
+
+// allowedDir returns the directory a command can run in for dir, with its
+// symbolic links resolved so that they cannot lead out of the allowed
+// directories.
+func (b *basicBashExecService) allowedDir(dir string) (string, error) {
+	if !filepath.IsAbs(dir) {
+		return "", fmt.Errorf("%w: %q is not absolute", ErrInvalidCwd, dir)
+	}
+	forbidden := errs.WithDetails(fmt.Errorf("%w: %s", ErrCwdForbidden, dir), map[string]string{"cwd": dir})
+	// The directory is checked before it is resolved, not to reveal what
+	// exists outside of the allowed directories.
+	if !b.withinAllowedDirs(filepath.Clean(dir)) {
+		return "", forbidden
+	}
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidCwd, err)
+	}
+	if !b.withinAllowedDirs(resolved) {
+		return "", forbidden
+	}
+	if fi, err := os.Stat(resolved); err != nil || !fi.IsDir() {
+		return "", fmt.Errorf("%w: %s is not a directory", ErrInvalidCwd, dir)
+	}
+	return resolved, nil
+}
+
+func (b *basicBashExecService) withinAllowedDirs(dir string) bool {
+	for _, allowed := range b.allowedDirs {
+		rel, err := filepath.Rel(allowed, dir)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedEnv reports whether a request can set the variable name, listed by
+// Config.AllowedEnv or matching one of its prefixes.
+func (b *basicBashExecService) allowedEnv(name string) bool {
+	for _, allowed := range b.cfg.AllowedEnv {
+		if prefix := strings.TrimSuffix(allowed, "*"); prefix != allowed {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDirs returns the absolute paths of dirs, with their symbolic links
+// resolved when they exist.
+func resolveDirs(dirs []string) []string {
+	res := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = resolved
+		}
+		res = append(res, dir)
+	}
+	return res
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/exec"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+)
+
+// ErrSandbox is returned when the sandbox of an execution cannot be set up.
+var ErrSandbox = errs.New(http.StatusInternalServerError, "sandbox_failed", "cannot set up the sandbox")
+
+// Executor starts the processes of the commands that ExecCmd prepares and
+// waits for, and so decides what they can reach of the system.
+type Executor interface {
+	// Start starts c with the limits of l that are enforced by the system,
+	// and returns the function that reports the limit the command exceeded
+	// once it exited. Start returns when ctx is done even if c is not yet
+	// running the command, the caller then stops it, see waitOrKill.
+	Start(ctx context.Context, c *exec.Cmd, l Limits) (func(*os.ProcessState) string, error)
+}
+
+// The executors, by the name the service is configured with.
+const (
+	// ExecutorHost runs the commands as processes of the service, that see
+	// what the service sees.
+	ExecutorHost = "host"
+	// ExecutorSandbox runs every command in its own namespaces, see NewSandboxExecutor.
+	ExecutorSandbox = "sandbox"
+)
+
+type hostExecutor struct {
+	cgroupRoot string
+}
+
+// NewHostExecutor returns the executor that starts the commands directly, as
+// processes of the service. The commands get a cgroup under cgroupRoot,
+// prepared by EnableCgroup, that enforces their memory and process limits;
+// they are enforced by rlimits when it is empty.
+func NewHostExecutor(cgroupRoot string) Executor {
+	return hostExecutor{cgroupRoot}
+}
+
+func (e hostExecutor) Start(ctx context.Context, c *exec.Cmd, l Limits) (func(*os.ProcessState) string, error) {
+	if l.system() {
+		return startHelper(ctx, c, l, e.cgroupRoot, nil)
+	}
+	if err := c.Start(); err != nil {
+		return nil, spawnError(err)
+	}
+	return func(*os.ProcessState) string { return "" }, nil
+}
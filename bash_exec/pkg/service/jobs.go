@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+)
+
+var (
+	ErrJobNotFound  = errs.New(http.StatusNotFound, "job_not_found", "job not found")
+	ErrJobQueueFull = errs.New(http.StatusTooManyRequests, "job_queue_full", "job queue is full")
+)
+
+// JobStatus is the state of an asynchronous execution.
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// Finished reports whether s is a final state.
+func (s JobStatus) Finished() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCancelled
+}
+
+// Job is a snapshot of an asynchronous execution. StdOut and StdErr hold the
+// output produced so far, ExitCode and Err are set once the job is finished.
+// A job that exited with a non-zero status is failed without Err, that is
+// set when the command could not run.
+type Job struct {
+	ID         string    `json:"id"`
+	Cmd        string    `json:"cmd"`
+	Status     JobStatus `json:"status"`
+	StdOut     string    `json:"std_out"`
+	StdErr     string    `json:"std_err"`
+	ExitCode   int       `json:"exit_code"`
+	HistoryID  string    `json:"history_id,omitempty"`
+	Err        string    `json:"err,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// LimitExceeded is the limit that stopped the command, see ExecResult.
+	LimitExceeded string `json:"limit_exceeded,omitempty"`
+
+	OutputStats
+}
+
+// job is the mutable state behind a Job, guarded by the jobManager mutex.
+type job struct {
+	Job
+	opts   ExecOptions
+	caller string
+	cancel context.CancelFunc
+	// stdout and stderr capture the output of the job while it runs.
+	stdout *capture
+	stderr *capture
+}
+
+// jobManager runs the submitted jobs on a bounded pool of workers. Each job
+// goes through svc, the whole middleware chain, like a synchronous execution.
+type jobManager struct {
+	svc       BashExecService
+	retention time.Duration
+	maxOutput int
+
+	mtx   sync.Mutex
+	jobs  map[string]*job
+	queue chan *job
+}
+
+func newJobManager(workers, queueSize int, retention time.Duration, maxOutput int) *jobManager {
+	if workers <= 0 {
+		workers = 1
+	}
+	m := &jobManager{
+		retention: retention,
+		maxOutput: maxOutput,
+		jobs:      map[string]*job{},
+		queue:     make(chan *job, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		go m.work()
+	}
+	return m
+}
+
+func (m *jobManager) submit(ctx context.Context, cmd string, opts ExecOptions) (id string, err error) {
+	if id, err = newID(); err != nil {
+		return
+	}
+	j := &job{
+		Job:    Job{ID: id, Cmd: cmd, Status: JobQueued, ExitCode: -999, CreatedAt: time.Now()},
+		opts:   opts,
+		caller: CallerFromContext(ctx),
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.prune()
+	select {
+	case m.queue <- j:
+		m.jobs[id] = j
+		return id, nil
+	default:
+		return "", ErrJobQueueFull
+	}
+}
+
+func (m *jobManager) get(id string) (Job, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	snapshot := j.Job
+	if j.stdout != nil {
+		snapshot.StdOut, snapshot.StdErr = j.stdout.String(), j.stderr.String()
+		snapshot.OutputStats = newOutputStats(j.stdout, j.stderr)
+	}
+	return snapshot, nil
+}
+
+// cancel stops a queued or running job, cancelling a finished job does nothing.
+func (m *jobManager) cancel(id string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return ErrJobNotFound
+	}
+	switch j.Status {
+	case JobQueued:
+		j.Status, j.FinishedAt = JobCancelled, time.Now()
+	case JobRunning:
+		j.cancel()
+	}
+	return nil
+}
+
+func (m *jobManager) work() {
+	for j := range m.queue {
+		m.run(j)
+	}
+}
+
+func (m *jobManager) run(j *job) {
+	// The job outlives the request that submitted it, only the caller is kept.
+	ctx, cancel := context.WithCancel(ContextWithCaller(context.Background(), j.caller))
+	defer cancel()
+
+	m.mtx.Lock()
+	if j.Status != JobQueued {
+		m.mtx.Unlock()
+		return
+	}
+	j.Status, j.StartedAt, j.cancel = JobRunning, time.Now(), cancel
+	j.stdout, j.stderr = newCapture(m.maxOutput), newCapture(m.maxOutput)
+	m.mtx.Unlock()
+
+	opts := j.opts
+	opts.Output = func(stream string, data []byte) {
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+		if stream == StreamStderr {
+			j.stderr.Write(data)
+		} else {
+			j.stdout.Write(data)
+		}
+	}
+	res, err := m.svc.ExecCmd(ctx, j.Cmd, opts)
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	j.StdOut, j.StdErr, j.OutputStats = res.StdOut, res.StdErr, res.OutputStats
+	j.stdout, j.stderr = nil, nil
+	j.ExitCode, j.HistoryID, j.FinishedAt = res.ExitCode, res.HistoryID, time.Now()
+	j.LimitExceeded = res.LimitExceeded
+	switch {
+	case err == nil && res.ExitCode == 0:
+		j.Status = JobSucceeded
+	case err == nil:
+		// The exit status is given by ExitCode.
+		j.Status = JobFailed
+	case errors.Is(err, context.Canceled):
+		j.Status, j.Err = JobCancelled, err.Error()
+	default:
+		j.Status, j.Err = JobFailed, err.Error()
+	}
+}
+
+// prune forgets the jobs finished more than retention ago. The caller must hold the mutex.
+func (m *jobManager) prune() {
+	if m.retention <= 0 {
+		return
+	}
+	for id, j := range m.jobs {
+		if j.Status.Finished() && time.Since(j.FinishedAt) > m.retention {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+// newID returns a random identifier of 128 bits, hex encoded.
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
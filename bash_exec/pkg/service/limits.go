@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+)
+
+var (
+	ErrInvalidLimits = errs.New(http.StatusBadRequest, "invalid_limits", "invalid limits")
+	// ErrLimits is returned when the limits of an execution cannot be applied.
+	ErrLimits = errs.New(http.StatusInternalServerError, "limits_failed", "cannot apply limits")
+)
+
+// Limits bound the resources of an execution, a zero field is no limit.
+// CPUTime, Memory, OpenFiles and Processes are enforced by the system, on Linux
+// only: Memory and Processes by a cgroup v2 when the Executor has a cgroup
+// root, see NewHostExecutor, by rlimits otherwise. Output is enforced by the
+// service.
+type Limits struct {
+	// CPUTime bounds the CPU time of every process of the command.
+	CPUTime time.Duration `json:"cpu_time,omitempty"`
+	// Memory bounds the memory of the command in bytes: the memory of its
+	// cgroup, or else the address space of every process.
+	Memory int64 `json:"memory_bytes,omitempty"`
+	// OpenFiles bounds the number of files every process can open.
+	OpenFiles int64 `json:"open_files,omitempty"`
+	// Processes bounds the number of processes of the command, in its cgroup.
+	// Without cgroup, RLIMIT_NPROC counts all the processes of the user the
+	// service runs as, and is ignored when it is root.
+	Processes int64 `json:"processes,omitempty"`
+	// Output bounds the size of the output of the command, on stdout and
+	// stderr together, in bytes. The command is stopped when it writes more.
+	Output int64 `json:"output_bytes,omitempty"`
+}
+
+// The limits ExecResult.LimitExceeded reports. A command that exceeds
+// OpenFiles only sees its calls fail, so it is never reported, nor are Memory
+// and Processes when they are not enforced by a cgroup.
+const (
+	LimitCPUTime   = "cpu_time"
+	LimitMemory    = "memory"
+	LimitProcesses = "processes"
+	LimitOutput    = "output"
+)
+
+func (l Limits) validate() error {
+	if l.CPUTime < 0 || l.Memory < 0 || l.OpenFiles < 0 || l.Processes < 0 || l.Output < 0 {
+		return fmt.Errorf("%w: negative limit", ErrInvalidLimits)
+	}
+	return nil
+}
+
+// lower returns l lowered by the limits of r, that cannot raise them.
+func (l Limits) lower(r Limits) Limits {
+	min := func(a, b int64) int64 {
+		if a == 0 || (b > 0 && b < a) {
+			return b
+		}
+		return a
+	}
+	return Limits{
+		CPUTime:   time.Duration(min(int64(l.CPUTime), int64(r.CPUTime))),
+		Memory:    min(l.Memory, r.Memory),
+		OpenFiles: min(l.OpenFiles, r.OpenFiles),
+		Processes: min(l.Processes, r.Processes),
+		Output:    min(l.Output, r.Output),
+	}
+}
+
+// system reports whether l has limits that are enforced by the system.
+func (l Limits) system() bool {
+	return l.CPUTime > 0 || l.Memory > 0 || l.OpenFiles > 0 || l.Processes > 0
+}
+
+// outputLimit counts the output of a command on both of its streams, and
+// calls exceeded once it is larger than max. The output beyond max is dropped.
+type outputLimit struct {
+	mtx      sync.Mutex
+	max, n   int64
+	exceeded func()
+	hit      bool
+}
+
+func (l *outputLimit) writer(w io.Writer) io.Writer {
+	return &limitedWriter{l, w}
+}
+
+// Exceeded reports whether the command wrote more than the limit.
+func (l *outputLimit) Exceeded() bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.hit
+}
+
+type limitedWriter struct {
+	l *outputLimit
+	w io.Writer
+}
+
+// Write never fails, so that the output keeps being drained until the command
+// is stopped.
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	w.l.mtx.Lock()
+	defer w.l.mtx.Unlock()
+	n := int64(len(p))
+	if left := w.l.max - w.l.n; n > left {
+		n = left
+		if !w.l.hit {
+			w.l.hit = true
+			w.l.exceeded()
+		}
+	}
+	w.l.n += n
+	if n > 0 {
+		w.w.Write(p[:n])
+	}
+	return len(p), nil
+}
@@ -0,0 +1,379 @@
+//go:build linux
+
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// limitsHelperArg is the first argument of the service when it is started as
+// the helper that applies the limits of a command, see RunLimitsHelper.
+const limitsHelperArg = "--exec-limited"
+
+// helperSpec tells the helper what to apply before it executes Path.
+type helperSpec struct {
+	Path    string   `json:"path"`
+	Cgroup  string   `json:"cgroup,omitempty"`
+	Rlimits []rlimit `json:"rlimits,omitempty"`
+	// Sandbox is set when the helper runs in the namespaces of a sandbox,
+	// that it sets up.
+	Sandbox *sandboxSpec `json:"sandbox,omitempty"`
+}
+
+type rlimit struct {
+	Resource int    `json:"resource"`
+	Cur      uint64 `json:"cur"`
+	Max      uint64 `json:"max"`
+}
+
+// helperFailure is what the helper reports on its error pipe, fd 3, when it
+// cannot execute the command. A failed exec is reported with its Errno only,
+// see failExec.
+type helperFailure struct {
+	Op    string `json:"op"`
+	Errno int    `json:"errno,omitempty"`
+	Msg   string `json:"msg"`
+}
+
+// RunLimitsHelper applies the limits of a command, sets up its sandbox and
+// executes it, when the process is the helper the service starts to do so,
+// and returns otherwise. The limits of a process can only be set from within,
+// and the Go runtime cannot set them, nor mount filesystems, between fork and
+// exec, hence the helper. It must be called first by the main function of the
+// service.
+func RunLimitsHelper() {
+	if len(os.Args) < 4 || os.Args[1] != limitsHelperArg {
+		return
+	}
+	errPipe := os.NewFile(3, "errpipe")
+	fail := func(op string, err error) {
+		f := helperFailure{Op: op, Msg: err.Error()}
+		var errno syscall.Errno
+		if errors.As(err, &errno) {
+			f.Errno = int(errno)
+		}
+		json.NewEncoder(errPipe).Encode(f)
+		os.Exit(127)
+	}
+
+	var spec helperSpec
+	if err := json.Unmarshal([]byte(os.Args[2]), &spec); err != nil {
+		fail("spec", err)
+	}
+	if spec.Cgroup != "" {
+		if err := os.WriteFile(filepath.Join(spec.Cgroup, "cgroup.procs"), []byte("0"), 0); err != nil {
+			fail("cgroup", err)
+		}
+	}
+	var filter []unix.SockFilter
+	if spec.Sandbox != nil {
+		if err := spec.Sandbox.enter(); err != nil {
+			fail("sandbox", err)
+		}
+		var err error
+		if filter, err = seccompFilter(); err != nil {
+			fail("seccomp", err)
+		}
+	}
+	// The filter denies mount, so it is installed once the sandbox is set up.
+	if filter != nil {
+		if err := installSeccomp(filter); err != nil {
+			fail("seccomp", err)
+		}
+	}
+	// The arguments of execve are built before the limits are set: once
+	// RLIMIT_AS is, the heap may not grow anymore, the address space of the
+	// helper being possibly larger than the limit already.
+	argv0p, err := syscall.BytePtrFromString(spec.Path)
+	if err != nil {
+		fail("exec", err)
+	}
+	argvp, err := syscall.SlicePtrFromStrings(os.Args[3:])
+	if err != nil {
+		fail("exec", err)
+	}
+	envvp, err := syscall.SlicePtrFromStrings(os.Environ())
+	if err != nil {
+		fail("exec", err)
+	}
+	syscall.CloseOnExec(3)
+	// RLIMIT_AS comes last, so a limit that cannot be set is reported while
+	// the helper can still allocate.
+	for _, r := range spec.Rlimits {
+		if err := unix.Setrlimit(r.Resource, &unix.Rlimit{Cur: r.Cur, Max: r.Max}); err != nil {
+			fail("setrlimit", err)
+		}
+	}
+	_, _, errno := syscall.RawSyscall(syscall.SYS_EXECVE,
+		uintptr(unsafe.Pointer(argv0p)),
+		uintptr(unsafe.Pointer(&argvp[0])),
+		uintptr(unsafe.Pointer(&envvp[0])))
+	failExec(errno)
+}
+
+// execFailure is the start of the helperFailure of a failed exec, that
+// failExec completes with the errno.
+const execFailure = `{"op":"exec","errno":`
+
+// failExec reports on the error pipe that exec failed with errno, and exits.
+// The rlimits are set, so it only uses the stack and raw system calls.
+func failExec(errno syscall.Errno) {
+	var buf [64]byte
+	msg := append(buf[:0], execFailure...)
+	msg = strconv.AppendUint(msg, uint64(errno), 10)
+	msg = append(msg, "}\n"...)
+	syscall.RawSyscall(syscall.SYS_WRITE, 3, uintptr(unsafe.Pointer(&msg[0])), uintptr(len(msg)))
+	syscall.RawSyscall(syscall.SYS_EXIT_GROUP, 127, 0, 0)
+}
+
+// startHelper starts c with the limits l enforced by the system, in the
+// sandbox when it is set: it runs the helper instead, that joins a new cgroup
+// under cgroupRoot when it is set, enters the sandbox, sets the rlimits and
+// executes the command. The namespaces of the sandbox must be set in
+// c.SysProcAttr. The returned function reports the
+// limit the command exceeded, if any, and releases its cgroup once c exited.
+// When ctx is done before the helper reports, c is returned as started, and
+// stopped by the caller like a command that timed out.
+// The helper is the same process as the command, so the PeakRSS of the
+// command is at least the one of the helper.
+func startHelper(ctx context.Context, c *exec.Cmd, l Limits, cgroupRoot string, sandbox *sandboxSpec) (func(*os.ProcessState) string, error) {
+	path := c.Path
+	if filepath.Base(path) == path {
+		// exec.Command could not find the command in PATH.
+		if _, err := exec.LookPath(path); err != nil {
+			return nil, spawnError(err)
+		}
+	}
+
+	spec := helperSpec{Path: path, Sandbox: sandbox}
+	var cgroup string
+	if cgroupRoot != "" && (l.Memory > 0 || l.Processes > 0) {
+		var err error
+		if cgroup, err = newCgroup(cgroupRoot, l); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrLimits, err)
+		}
+		spec.Cgroup = cgroup
+	}
+	rlimits, err := systemRlimits(l, cgroup != "")
+	if err != nil {
+		removeCgroup(cgroup)
+		return nil, fmt.Errorf("%w: %v", ErrLimits, err)
+	}
+	spec.Rlimits = rlimits
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		removeCgroup(cgroup)
+		return nil, fmt.Errorf("%w: %v", ErrLimits, err)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		removeCgroup(cgroup)
+		return nil, fmt.Errorf("%w: %v", ErrLimits, err)
+	}
+	c.Path = "/proc/self/exe"
+	c.Args = append([]string{c.Args[0], limitsHelperArg, string(specJSON)}, c.Args...)
+	c.ExtraFiles = []*os.File{w}
+	err = c.Start()
+	w.Close()
+	if err != nil {
+		r.Close()
+		removeCgroup(cgroup)
+		if sandbox != nil {
+			// The namespaces are created with the process.
+			return nil, fmt.Errorf("%w: %v", ErrSandbox, err)
+		}
+		return nil, spawnError(err)
+	}
+	// The pipe is closed on exec, its content tells whether the helper failed.
+	// The helper can hang before, e.g. on a cgroup or a mount, so the pipe
+	// is read aside, until the helper is killed if ctx is done first.
+	report := make(chan []byte, 1)
+	go func() {
+		msg, _ := io.ReadAll(r)
+		r.Close()
+		report <- msg
+	}()
+	var msg []byte
+	select {
+	case msg = <-report:
+	case <-ctx.Done():
+	}
+	if len(msg) > 0 {
+		c.Wait()
+		removeCgroup(cgroup)
+		var f helperFailure
+		if err := json.Unmarshal(msg, &f); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrLimits, bytes.TrimSpace(msg))
+		}
+		if f.Op == "exec" && f.Errno != 0 {
+			return nil, spawnError(&os.PathError{Op: "exec", Path: path, Err: syscall.Errno(f.Errno)})
+		}
+		if f.Op == "sandbox" || f.Op == "seccomp" {
+			return nil, fmt.Errorf("%w: %s: %s", ErrSandbox, f.Op, f.Msg)
+		}
+		return nil, fmt.Errorf("%w: %s: %s", ErrLimits, f.Op, f.Msg)
+	}
+
+	return func(ps *os.ProcessState) string {
+		defer removeCgroup(cgroup)
+		return limitExceeded(ps, l, cgroup)
+	}, nil
+}
+
+// systemRlimits returns the rlimits that enforce l, Memory and Processes only
+// when they are not enforced by a cgroup. The rlimits are never raised above
+// the ones of the service.
+func systemRlimits(l Limits, cgroup bool) ([]rlimit, error) {
+	var res []rlimit
+	add := func(resource int, cur, max uint64) error {
+		var current unix.Rlimit
+		if err := unix.Getrlimit(resource, &current); err != nil {
+			return err
+		}
+		if max > current.Max {
+			max = current.Max
+		}
+		if cur > max {
+			cur = max
+		}
+		res = append(res, rlimit{resource, cur, max})
+		return nil
+	}
+	if l.CPUTime > 0 {
+		// The command gets SIGXCPU at the soft limit, and SIGKILL one second
+		// later if it handles it.
+		sec := uint64((l.CPUTime + time.Second - 1) / time.Second)
+		if err := add(unix.RLIMIT_CPU, sec, sec+1); err != nil {
+			return nil, err
+		}
+	}
+	if l.OpenFiles > 0 {
+		if err := add(unix.RLIMIT_NOFILE, uint64(l.OpenFiles), uint64(l.OpenFiles)); err != nil {
+			return nil, err
+		}
+	}
+	if l.Processes > 0 && !cgroup {
+		if err := add(unix.RLIMIT_NPROC, uint64(l.Processes), uint64(l.Processes)); err != nil {
+			return nil, err
+		}
+	}
+	if l.Memory > 0 && !cgroup {
+		if err := add(unix.RLIMIT_AS, uint64(l.Memory), uint64(l.Memory)); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// limitExceeded returns the limit that ended the process ps, if any.
+func limitExceeded(ps *os.ProcessState, l Limits, cgroup string) string {
+	if cgroup != "" {
+		if l.Memory > 0 && cgroupEvent(cgroup, "memory.events", "oom_kill") > 0 {
+			return LimitMemory
+		}
+		if l.Processes > 0 && cgroupEvent(cgroup, "pids.events", "max") > 0 {
+			return LimitProcesses
+		}
+	}
+	ws, ok := ps.Sys().(syscall.WaitStatus)
+	if !ok || !ws.Signaled() || l.CPUTime <= 0 {
+		return ""
+	}
+	switch ws.Signal() {
+	case syscall.SIGXCPU:
+		return LimitCPUTime
+	case syscall.SIGKILL:
+		if ps.UserTime()+ps.SystemTime() >= l.CPUTime {
+			return LimitCPUTime
+		}
+	}
+	return ""
+}
+
+// EnableCgroup prepares root, a cgroup v2 directory the service can write to,
+// to hold a cgroup per command with the memory and pids controllers.
+func EnableCgroup(root string) error {
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err != nil {
+		return fmt.Errorf("%s is not a cgroup v2: %w", root, err)
+	}
+	return os.WriteFile(filepath.Join(root, "cgroup.subtree_control"), []byte("+memory +pids"), 0)
+}
+
+// newCgroup creates a cgroup under root with the memory and process limits of l.
+func newCgroup(root string, l Limits) (string, error) {
+	id, err := newID()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(root, "exec-"+id)
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return "", err
+	}
+	write := func(file string, v int64) error {
+		return os.WriteFile(filepath.Join(dir, file), []byte(strconv.FormatInt(v, 10)), 0)
+	}
+	if l.Memory > 0 {
+		if err := write("memory.max", l.Memory); err != nil {
+			removeCgroup(dir)
+			return "", err
+		}
+		// Without swap accounting, the file does not exist.
+		write("memory.swap.max", 0)
+	}
+	if l.Processes > 0 {
+		if err := write("pids.max", l.Processes); err != nil {
+			removeCgroup(dir)
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// cgroupEvent returns the counter of event in the events file of cgroup.
+func cgroupEvent(cgroup, file, event string) int64 {
+	f, err := os.Open(filepath.Join(cgroup, file))
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if name, v, ok := strings.Cut(s.Text(), " "); ok && name == event {
+			n, _ := strconv.ParseInt(v, 10, 64)
+			return n
+		}
+	}
+	return 0
+}
+
+// removeCgroup kills the processes left in cgroup and removes it.
+func removeCgroup(cgroup string) {
+	if cgroup == "" {
+		return
+	}
+	os.WriteFile(filepath.Join(cgroup, "cgroup.kill"), []byte("1"), 0)
+	// The killed processes leave the cgroup asynchronously.
+	for i := 0; i < 50; i++ {
+		if err := os.Remove(cgroup); err == nil || errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
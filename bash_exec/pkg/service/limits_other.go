@@ -0,0 +1,25 @@
+//go:build !linux
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// RunLimitsHelper is a no-op, the limits enforced by the system are only
+// supported on Linux.
+func RunLimitsHelper() {}
+
+// startHelper fails, the limits enforced by the system are only supported on Linux.
+func startHelper(ctx context.Context, c *exec.Cmd, l Limits, cgroupRoot string, sandbox *sandboxSpec) (func(*os.ProcessState) string, error) {
+	return nil, fmt.Errorf("%w: only the output limit is supported on this system", ErrLimits)
+}
+
+// EnableCgroup fails, cgroups only exist on Linux.
+func EnableCgroup(root string) error {
+	return errors.New("cgroups are only supported on Linux")
+}
@@ -5,25 +5,40 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"time"
+	"unicode/utf8"
 
-	"github.com/go-kit/kit/circuitbreaker"
+	storepb "bash_exec/pkg/grpc/storepb"
+	auth "github.com/gigi214/services_example/pkg/auth"
+	errs "github.com/gigi214/services_example/pkg/errs"
 	"github.com/go-kit/kit/endpoint"
-	"github.com/go-kit/kit/ratelimit"
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
 	"github.com/go-kit/kit/sd"
 	"github.com/go-kit/kit/sd/lb"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
 	httptransport "github.com/go-kit/kit/transport/http"
 	log "github.com/go-kit/log"
-	"github.com/sony/gobreaker"
-	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Middleware func(BashExecService) BashExecService
 
+// logOutputPrefix is the number of bytes of each output stream that
+// loggingMiddleware logs, the whole output is kept in the history.
+const logOutputPrefix = 256
+
 type loggingMiddleware struct {
 	logger log.Logger
 	next   BashExecService
@@ -35,89 +50,419 @@ func LoggingMiddleware(logger log.Logger) Middleware {
 	}
 }
 
-func (l loggingMiddleware) ExecCmd(ctx context.Context, cmd string) (stdOut string, stdErr string, exitCode int, err error) {
+func (l loggingMiddleware) ExecCmd(ctx context.Context, cmd string, opts ExecOptions) (res ExecResult, err error) {
 	defer func() {
 		l.logger.Log(
 			"method", "ExecCmd",
+			"principal", CallerFromContext(ctx),
 			"cmd", cmd,
-			"stdOut", stdOut,
-			"stdErr", stdErr,
-			"exitCode", exitCode,
+			"mode", opts.Mode,
+			"stdOut", outputPrefix(res.StdOut),
+			"stdErr", outputPrefix(res.StdErr),
+			"stdoutBytes", res.StdoutBytes,
+			"stdoutTruncated", res.StdoutTruncated,
+			"stderrBytes", res.StderrBytes,
+			"stderrTruncated", res.StderrTruncated,
+			"exitCode", res.ExitCode,
+			"limitExceeded", res.LimitExceeded,
+			"historyID", res.HistoryID,
+			"duration", res.Duration,
 			"err", err,
 		)
 	}()
 
-	return l.next.ExecCmd(ctx, cmd)
+	return l.next.ExecCmd(ctx, cmd, opts)
+}
+
+// outputPrefix returns the first logOutputPrefix bytes of s, cut before a
+// rune that does not fit.
+func outputPrefix(s string) string {
+	if len(s) <= logOutputPrefix {
+		return s
+	}
+	n := logOutputPrefix
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func (l loggingMiddleware) SubmitJob(ctx context.Context, cmd string, opts ExecOptions) (id string, err error) {
+	defer func() {
+		l.logger.Log("method", "SubmitJob", "principal", CallerFromContext(ctx), "cmd", cmd, "mode", opts.Mode, "id", id, "err", err)
+	}()
+	return l.next.SubmitJob(ctx, cmd, opts)
+}
+
+func (l loggingMiddleware) GetJob(ctx context.Context, id string) (job Job, err error) {
+	defer func() {
+		l.logger.Log("method", "GetJob", "principal", CallerFromContext(ctx), "id", id, "status", job.Status, "err", err)
+	}()
+	return l.next.GetJob(ctx, id)
+}
+
+func (l loggingMiddleware) CancelJob(ctx context.Context, id string) (err error) {
+	defer func() {
+		l.logger.Log("method", "CancelJob", "principal", CallerFromContext(ctx), "id", id, "err", err)
+	}()
+	return l.next.CancelJob(ctx, id)
+}
+
+func (l loggingMiddleware) ExecBatch(ctx context.Context, steps []BatchStep) (res BatchResult, err error) {
+	defer func() {
+		l.logger.Log("method", "ExecBatch", "principal", CallerFromContext(ctx), "steps", len(steps), "id", res.ID, "success", res.Success, "err", err)
+	}()
+	return l.next.ExecBatch(ctx, steps)
+}
+
+func (l loggingMiddleware) CreateSchedule(ctx context.Context, spec ScheduleSpec) (schedule Schedule, err error) {
+	defer func() {
+		l.logger.Log("method", "CreateSchedule", "principal", CallerFromContext(ctx), "cron", spec.Cron, "cmd", spec.Cmd, "mode", spec.Options.Mode, "id", schedule.ID, "err", err)
+	}()
+	return l.next.CreateSchedule(ctx, spec)
+}
+
+func (l loggingMiddleware) ListSchedules(ctx context.Context) (schedules []Schedule, err error) {
+	defer func() {
+		l.logger.Log("method", "ListSchedules", "principal", CallerFromContext(ctx), "schedules", len(schedules), "err", err)
+	}()
+	return l.next.ListSchedules(ctx)
+}
+
+func (l loggingMiddleware) GetSchedule(ctx context.Context, id string) (schedule Schedule, err error) {
+	defer func() {
+		l.logger.Log("method", "GetSchedule", "principal", CallerFromContext(ctx), "id", id, "err", err)
+	}()
+	return l.next.GetSchedule(ctx, id)
+}
+
+func (l loggingMiddleware) UpdateSchedule(ctx context.Context, id string, spec ScheduleSpec) (schedule Schedule, err error) {
+	defer func() {
+		l.logger.Log("method", "UpdateSchedule", "principal", CallerFromContext(ctx), "id", id, "cron", spec.Cron, "cmd", spec.Cmd, "mode", spec.Options.Mode, "err", err)
+	}()
+	return l.next.UpdateSchedule(ctx, id, spec)
+}
+
+func (l loggingMiddleware) DeleteSchedule(ctx context.Context, id string) (err error) {
+	defer func() {
+		l.logger.Log("method", "DeleteSchedule", "principal", CallerFromContext(ctx), "id", id, "err", err)
+	}()
+	return l.next.DeleteSchedule(ctx, id)
+}
+
+func (l loggingMiddleware) CreateTemplate(ctx context.Context, name string, spec TemplateSpec) (tmpl CommandTemplate, err error) {
+	defer func() {
+		l.logger.Log("method", "CreateTemplate", "principal", CallerFromContext(ctx), "name", name, "cmd", spec.Cmd, "params", len(spec.Params), "err", err)
+	}()
+	return l.next.CreateTemplate(ctx, name, spec)
+}
+
+func (l loggingMiddleware) ListTemplates(ctx context.Context) (tmpls []CommandTemplate, err error) {
+	defer func() {
+		l.logger.Log("method", "ListTemplates", "principal", CallerFromContext(ctx), "templates", len(tmpls), "err", err)
+	}()
+	return l.next.ListTemplates(ctx)
+}
+
+func (l loggingMiddleware) GetTemplate(ctx context.Context, name string) (tmpl CommandTemplate, err error) {
+	defer func() {
+		l.logger.Log("method", "GetTemplate", "principal", CallerFromContext(ctx), "name", name, "err", err)
+	}()
+	return l.next.GetTemplate(ctx, name)
+}
+
+func (l loggingMiddleware) UpdateTemplate(ctx context.Context, name string, spec TemplateSpec) (tmpl CommandTemplate, err error) {
+	defer func() {
+		l.logger.Log("method", "UpdateTemplate", "principal", CallerFromContext(ctx), "name", name, "cmd", spec.Cmd, "params", len(spec.Params), "err", err)
+	}()
+	return l.next.UpdateTemplate(ctx, name, spec)
+}
+
+func (l loggingMiddleware) DeleteTemplate(ctx context.Context, name string) (err error) {
+	defer func() {
+		l.logger.Log("method", "DeleteTemplate", "principal", CallerFromContext(ctx), "name", name, "err", err)
+	}()
+	return l.next.DeleteTemplate(ctx, name)
+}
+
+func (l loggingMiddleware) ExecTemplate(ctx context.Context, name string, params map[string]string) (res ExecResult, err error) {
+	defer func() {
+		l.logger.Log("method", "ExecTemplate", "principal", CallerFromContext(ctx), "name", name, "params", fmt.Sprint(params), "exitCode", res.ExitCode, "historyID", res.HistoryID, "err", err)
+	}()
+	return l.next.ExecTemplate(ctx, name, params)
 }
 
 type proxyStoreMiddleware struct {
 	storeService endpoint.Endpoint
+	outbox       *Outbox
+	host         string
+	instanceID   string
+	logger       log.Logger
 	next         BashExecService
 }
 
+// StoreClientConfig sets how the store service instances are called.
+type StoreClientConfig struct {
+	// QPS is the rate of the requests sent to each instance, beyond which
+	// they fail right away, 0 for no limit.
+	QPS float64
+	// Burst is the number of requests an instance can be sent at once above
+	// QPS, QPS rounded up when 0.
+	Burst int
+	// MaxAttempts is the number of times a request is sent, to the next
+	// instance each time, before it fails, 1 when 0.
+	MaxAttempts int
+	// AttemptTimeout bounds every attempt, 0 for no limit.
+	AttemptTimeout time.Duration
+	// Timeout bounds a request with all its attempts, 0 for no limit.
+	Timeout time.Duration
+	// BreakerFailures is the number of failures in a row that open the
+	// circuit breaker of an instance, 6 when 0 as in gobreaker.
+	BreakerFailures uint32
+	// BreakerOpenTimeout is how long an open breaker fails the requests
+	// before it lets probes through, 60s when 0.
+	BreakerOpenTimeout time.Duration
+	// BreakerProbes is the number of requests a half-open breaker lets
+	// through, it closes when they all succeed and opens again when one
+	// fails, 1 when 0.
+	BreakerProbes uint32
+	// BreakerInterval is how often a closed breaker clears its counts, 0 to
+	// never clear them.
+	BreakerInterval time.Duration
+	// BreakerState is set to the state of the breaker of each instance, 0
+	// closed, 1 half-open and 2 open, with the instance label. It is reset to
+	// 0 when the instance is no longer found.
+	BreakerState metrics.Gauge
+	// BreakerTransitions counts the changes of state of the breakers, with
+	// the instance and state labels.
+	BreakerTransitions metrics.Counter
+	// Retries counts the attempts of the requests after their first.
+	Retries metrics.Counter
+}
+
 // ProxyStoreMiddleware returns a BashExecService Middleware.
-// the instances is a string with the StoreService instances address separed by comma, if more than one.
-func ProxyStoreMiddleware(ctx context.Context, instances string, logger log.Logger) Middleware {
-	if instances == "" {
+// instancer finds the StoreService instances, see NewStoreInstancer, and none
+// are called when it is nil. The requests are balanced on the instances it
+// finds as they change, leaving out the ones that keep failing, as cfg sets.
+// instanceID identifies this instance in the records, with the host name.
+// credentials authenticate the requests sent to the store service, when set.
+// When outbox is not nil, every record is written to it before being sent, and
+// the records the store service did not accept are redelivered in the
+// background until ctx is done.
+func ProxyStoreMiddleware(ctx context.Context, instancer sd.Instancer, cfg StoreClientConfig, instanceID string, credentials auth.Credentials, outbox *Outbox, logger log.Logger) Middleware {
+	if instancer == nil {
 		logger.Log("call_to", "none")
 		return func(next BashExecService) BashExecService { return next }
 	}
 
-	// Set some parameters for our client.
-	var (
-		qps         = 100                    // beyond which we will return an error
-		maxAttempts = 3                      // per request, before giving up
-		maxTime     = 250 * time.Millisecond // wallclock time, before giving up
-	)
+	if cfg.BreakerState == nil {
+		cfg.BreakerState = discard.NewGauge()
+	}
+	if cfg.BreakerTransitions == nil {
+		cfg.BreakerTransitions = discard.NewCounter()
+	}
+	if cfg.Retries == nil {
+		cfg.Retries = discard.NewCounter()
+	}
+	maxAttempts, timeout := cfg.MaxAttempts, cfg.Timeout
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	if timeout <= 0 {
+		timeout = math.MaxInt64
+	}
 
-	var (
-		instanceList = splitInstances(instances)
-		endpointer   sd.FixedEndpointer
-	)
-	logger.Log("call_to", fmt.Sprint(instanceList))
-	for _, instance := range instanceList {
-		var e endpoint.Endpoint
-		e = makeStoreProxy(ctx, instance)
-		e = circuitbreaker.Gobreaker(gobreaker.NewCircuitBreaker(gobreaker.Settings{}))(e)
-		e = ratelimit.NewErroringLimiter(rate.NewLimiter(rate.Every(time.Second), qps))(e)
-		endpointer = append(endpointer, e)
+	factory := func(instance string) (endpoint.Endpoint, io.Closer, error) {
+		return makeStoreProxy(ctx, instance, credentials, logger)
 	}
+	endpointer := newStoreEndpointer(instancer, factory, cfg, logger)
+	go func() {
+		<-ctx.Done()
+		endpointer.Close()
+		instancer.Stop()
+	}()
 
 	// Now, build a single, retrying, load-balancing endpoint out of all of
 	// those individual endpoints.
 	balancer := lb.NewRoundRobin(endpointer)
-	retry := lb.Retry(maxAttempts, maxTime, balancer)
+	retry := lb.RetryWithCallback(timeout, balancer, func(n int, err error) (bool, error) {
+		if n < maxAttempts {
+			cfg.Retries.Add(1)
+			return true, nil
+		}
+		return false, nil
+	})
+
+	// The history records which host ran each command
+	host, err := os.Hostname()
+	if err != nil {
+		logger.Log("during", "Hostname", "err", err)
+	}
+
+	if outbox != nil {
+		go outbox.Run(ctx, retry, logger)
+	}
 
 	// And finally, return the ServiceMiddleware, implemented by proxyStoreMiddleware
 	return func(next BashExecService) BashExecService {
-		return &proxyStoreMiddleware{storeService: retry, next: next}
+		return &proxyStoreMiddleware{storeService: retry, outbox: outbox, host: host, instanceID: instanceID, logger: logger, next: next}
 	}
 
 }
-func (s proxyStoreMiddleware) ExecCmd(ctx context.Context, cmd string) (stdOut string, stdErr string, exitCode int, err error) {
-	stdOut, stdErr, exitCode, err = s.next.ExecCmd(ctx, cmd)
 
-	// Call store endpoint for save history of cmds
-	_, errDb := s.storeService(ctx, StoreRequest{
-		Cmd:      cmd,
-		Success:  err == nil,
-		ExitCode: exitCode,
-		Stdout:   stdOut,
-		Stderr:   stdErr,
-	})
+// ExecCmd stores the history record of the execution. The record is sent right
+// away, so that the ID it is given by the store service can be returned, and
+// is left in the outbox for a later delivery when that fails.
+func (s proxyStoreMiddleware) ExecCmd(ctx context.Context, cmd string, opts ExecOptions) (res ExecResult, err error) {
+	received := time.Now()
+	res, err = s.next.ExecCmd(ctx, cmd, opts)
 
-	fmt.Println("errDB: ", errDb)
+	key, errDb := newID()
+	if errDb != nil {
+		s.logger.Log("during", "Store", "err", errDb)
+		return
+	}
+	req := StoreRequest{
+		IdempotencyKey: key,
+		Cmd:            cmd,
+		TimestampExec:  res.StartedAt,
+		FinishedAt:     res.FinishedAt,
+		Success:        err == nil && res.ExitCode == 0,
+		ExitCode:       res.ExitCode,
+		Stdout:         res.StdOut,
+		Stderr:         res.StdErr,
+		Duration:       res.Duration,
+		UserTime:       res.UserTime,
+		SystemTime:     res.SystemTime,
+		PeakRSS:        res.PeakRSS,
+		Host:           s.host,
+		InstanceID:     s.instanceID,
+		Principal:      CallerFromContext(ctx),
+		Cwd:            opts.Cwd,
+		Env:            opts.Env,
+		CleanEnv:       opts.CleanEnv,
+		Stdin:          opts.Stdin,
+		LimitExceeded:  res.LimitExceeded,
+		OutputStats:    res.OutputStats,
+		BatchID:        opts.BatchID,
+		BatchStep:      opts.BatchStep,
+		ScheduleID:     opts.ScheduleID,
+		TemplateName:   opts.TemplateName,
+		TemplateParams: opts.TemplateParams,
+	}
+	// Commands rejected before they started are recorded at the time they were received.
+	if req.TimestampExec.IsZero() {
+		req.TimestampExec, req.FinishedAt = received, received
+	}
+	queued := false
+	if s.outbox != nil {
+		if errDb := s.outbox.Enqueue(key, req); errDb != nil {
+			s.logger.Log("during", "Enqueue", "key", key, "err", errDb)
+		} else {
+			queued = true
+		}
+	}
+
+	// Call store endpoint for save history of cmds
+	resp, errDb := s.storeService(ctx, req)
+	switch {
+	case errDb != nil && queued:
+		s.outbox.Fail(key)
+		s.logger.Log("during", "Store", "key", key, "outbox", "retry", "err", errDb)
+	case errDb != nil:
+		s.logger.Log("during", "Store", "key", key, "err", errDb)
+	default:
+		res.HistoryID = resp.(StoreResponse).ID
+		if queued {
+			if errDb := s.outbox.Ack(key); errDb != nil {
+				s.logger.Log("during", "Ack", "key", key, "err", errDb)
+			}
+		}
+	}
 
 	return
 }
 
-func makeStoreProxy(ctx context.Context, instance string) endpoint.Endpoint {
+func (s proxyStoreMiddleware) SubmitJob(ctx context.Context, cmd string, opts ExecOptions) (id string, err error) {
+	return s.next.SubmitJob(ctx, cmd, opts)
+}
+
+func (s proxyStoreMiddleware) GetJob(ctx context.Context, id string) (job Job, err error) {
+	return s.next.GetJob(ctx, id)
+}
+
+func (s proxyStoreMiddleware) CancelJob(ctx context.Context, id string) (err error) {
+	return s.next.CancelJob(ctx, id)
+}
+
+// ExecBatch stores nothing itself, every step is stored by ExecCmd with the ID
+// of the batch.
+func (s proxyStoreMiddleware) ExecBatch(ctx context.Context, steps []BatchStep) (res BatchResult, err error) {
+	return s.next.ExecBatch(ctx, steps)
+}
+
+// CreateSchedule stores nothing itself, every run is stored by ExecCmd with
+// the ID of the schedule.
+func (s proxyStoreMiddleware) CreateSchedule(ctx context.Context, spec ScheduleSpec) (schedule Schedule, err error) {
+	return s.next.CreateSchedule(ctx, spec)
+}
+
+func (s proxyStoreMiddleware) ListSchedules(ctx context.Context) (schedules []Schedule, err error) {
+	return s.next.ListSchedules(ctx)
+}
+
+func (s proxyStoreMiddleware) GetSchedule(ctx context.Context, id string) (schedule Schedule, err error) {
+	return s.next.GetSchedule(ctx, id)
+}
+
+func (s proxyStoreMiddleware) UpdateSchedule(ctx context.Context, id string, spec ScheduleSpec) (schedule Schedule, err error) {
+	return s.next.UpdateSchedule(ctx, id, spec)
+}
+
+func (s proxyStoreMiddleware) DeleteSchedule(ctx context.Context, id string) (err error) {
+	return s.next.DeleteSchedule(ctx, id)
+}
+
+// CreateTemplate stores nothing itself, every execution of the template is
+// stored by ExecCmd with its name and parameters.
+func (s proxyStoreMiddleware) CreateTemplate(ctx context.Context, name string, spec TemplateSpec) (tmpl CommandTemplate, err error) {
+	return s.next.CreateTemplate(ctx, name, spec)
+}
+
+func (s proxyStoreMiddleware) ListTemplates(ctx context.Context) (tmpls []CommandTemplate, err error) {
+	return s.next.ListTemplates(ctx)
+}
+
+func (s proxyStoreMiddleware) GetTemplate(ctx context.Context, name string) (tmpl CommandTemplate, err error) {
+	return s.next.GetTemplate(ctx, name)
+}
+
+func (s proxyStoreMiddleware) UpdateTemplate(ctx context.Context, name string, spec TemplateSpec) (tmpl CommandTemplate, err error) {
+	return s.next.UpdateTemplate(ctx, name, spec)
+}
+
+func (s proxyStoreMiddleware) DeleteTemplate(ctx context.Context, name string) (err error) {
+	return s.next.DeleteTemplate(ctx, name)
+}
+
+// ExecTemplate stores nothing itself, the execution is stored by ExecCmd.
+func (s proxyStoreMiddleware) ExecTemplate(ctx context.Context, name string, params map[string]string) (res ExecResult, err error) {
+	return s.next.ExecTemplate(ctx, name, params)
+}
+
+// makeStoreProxy returns an endpoint that calls the store service at the
+// instance address, over gRPC when it starts with grpc:// and over HTTP
+// otherwise, and the connection to close when the instance goes away, if any.
+func makeStoreProxy(ctx context.Context, instance string, credentials auth.Credentials, logger log.Logger) (endpoint.Endpoint, io.Closer, error) {
+	if strings.HasPrefix(instance, "grpc://") {
+		return makeStoreGRPCProxy(ctx, strings.TrimPrefix(instance, "grpc://"), credentials)
+	}
 	if !strings.HasPrefix(instance, "http") {
 		instance = "http://" + instance
 	}
 	u, err := url.Parse(instance)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 	if u.Path == "" {
 		u.Path = "/store"
@@ -127,7 +472,80 @@ func makeStoreProxy(ctx context.Context, instance string) endpoint.Endpoint {
 		u,
 		encodeStoreRequest,
 		decodeStoreResponse,
-	).Endpoint()
+		httptransport.ClientBefore(func(ctx context.Context, r *http.Request) context.Context {
+			if err := credentials.SignRequest(r); err != nil {
+				logger.Log("during", "SignRequest", "err", err)
+			}
+			return ctx
+		}),
+	).Endpoint(), nil, nil
+}
+
+// makeStoreGRPCProxy returns an endpoint that calls the Store method of the
+// store service over gRPC, at the host:port address, and its connection.
+func makeStoreGRPCProxy(ctx context.Context, address string, credentials auth.Credentials) (endpoint.Endpoint, io.Closer, error) {
+	if err := credentials.SetMetadata(metadata.MD{}); err != nil {
+		return nil, nil, err
+	}
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return grpctransport.NewClient(
+		conn,
+		"pb.StoreCmds",
+		"Store",
+		encodeGRPCStoreRequest,
+		decodeGRPCStoreResponse,
+		&storepb.StoreReply{},
+		grpctransport.ClientBefore(func(ctx context.Context, md *metadata.MD) context.Context {
+			credentials.SetMetadata(*md)
+			return ctx
+		}),
+	).Endpoint(), conn, nil
+}
+
+func encodeGRPCStoreRequest(_ context.Context, request interface{}) (interface{}, error) {
+	r := request.(StoreRequest)
+	return &storepb.StoreRequest{
+		Cmd:             r.Cmd,
+		TimestampExec:   timestamppb.New(r.TimestampExec),
+		Success:         r.Success,
+		ExitCode:        int32(r.ExitCode),
+		Stdout:          r.Stdout,
+		Stderr:          r.Stderr,
+		Host:            r.Host,
+		IdempotencyKey:  r.IdempotencyKey,
+		FinishedAt:      timestamppb.New(r.FinishedAt),
+		Duration:        durationpb.New(r.Duration),
+		UserTime:        durationpb.New(r.UserTime),
+		SystemTime:      durationpb.New(r.SystemTime),
+		PeakRssBytes:    r.PeakRSS,
+		InstanceId:      r.InstanceID,
+		Principal:       r.Principal,
+		Cwd:             r.Cwd,
+		Env:             r.Env,
+		CleanEnv:        r.CleanEnv,
+		Stdin:           r.Stdin,
+		LimitExceeded:   r.LimitExceeded,
+		StdoutBytes:     r.StdoutBytes,
+		StdoutTruncated: r.StdoutTruncated,
+		StderrBytes:     r.StderrBytes,
+		StderrTruncated: r.StderrTruncated,
+		BatchId:         r.BatchID,
+		BatchStep:       r.BatchStep,
+		ScheduleId:      r.ScheduleID,
+		TemplateName:    r.TemplateName,
+		TemplateParams:  r.TemplateParams,
+	}, nil
+}
+
+func decodeGRPCStoreResponse(_ context.Context, reply interface{}) (interface{}, error) {
+	r := reply.(*storepb.StoreReply)
+	if r.Err != "" {
+		return nil, fmt.Errorf("store service: %s", r.Err)
+	}
+	return StoreResponse{ID: r.Id}, nil
 }
 
 func splitInstances(s string) []string {
@@ -147,15 +565,61 @@ func encodeStoreRequest(_ context.Context, r *http.Request, request interface{})
 	return nil
 }
 
-func decodeStoreResponse(context.Context, *http.Response) (response interface{}, err error) {
-	return
+func decodeStoreResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		var w struct {
+			Error *errs.Error `json:"error"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&w); err != nil || w.Error == nil {
+			return nil, fmt.Errorf("store service: %w", errs.Decode(&errs.Error{}, r.StatusCode))
+		}
+		return nil, fmt.Errorf("store service: %w", errs.Decode(w.Error, r.StatusCode))
+	}
+	var resp StoreResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
 }
 
 type StoreRequest struct {
-	Cmd           string    `json:"cmd"`
-	TimestampExec time.Time `json:"timestamp_exec"`
-	Success       bool      `json:"success"`
-	ExitCode      int       `json:"exit_code"`
-	Stdout        string    `json:"stdout,omitempty"`
-	Stderr        string    `json:"stderr,omitempty"`
+	// IdempotencyKey identifies the record, so that the store service keeps a
+	// single entry when it is delivered more than once.
+	IdempotencyKey string        `json:"idempotency_key,omitempty"`
+	Cmd            string        `json:"cmd"`
+	TimestampExec  time.Time     `json:"timestamp_exec"`
+	FinishedAt     time.Time     `json:"finished_at"`
+	Success        bool          `json:"success"`
+	ExitCode       int           `json:"exit_code"`
+	Stdout         string        `json:"stdout,omitempty"`
+	Stderr         string        `json:"stderr,omitempty"`
+	Duration       time.Duration `json:"duration_ns"`
+	UserTime       time.Duration `json:"user_time_ns"`
+	SystemTime     time.Duration `json:"system_time_ns"`
+	PeakRSS        int64         `json:"peak_rss_bytes"`
+	Host           string        `json:"host,omitempty"`
+	InstanceID     string        `json:"instance_id,omitempty"`
+	Principal      string        `json:"principal,omitempty"`
+	// Cwd, Env, CleanEnv and Stdin are the environment the command ran in, as
+	// requested, see ExecOptions.
+	Cwd      string            `json:"cwd,omitempty"`
+	Env      map[string]string `json:"env,omitempty"`
+	CleanEnv bool              `json:"clean_env,omitempty"`
+	Stdin    []byte            `json:"stdin,omitempty"`
+	// LimitExceeded is the limit that stopped the command, see ExecResult.
+	LimitExceeded string `json:"limit_exceeded,omitempty"`
+	// OutputStats tells whether Stdout and Stderr were truncated.
+	OutputStats
+	// BatchID and BatchStep link the execution to a step of a batch.
+	BatchID   string `json:"batch_id,omitempty"`
+	BatchStep string `json:"batch_step,omitempty"`
+	// ScheduleID links the execution to the schedule that ran it.
+	ScheduleID string `json:"schedule_id,omitempty"`
+	// TemplateName and TemplateParams are the command template the command
+	// was rendered from and its parameters.
+	TemplateName   string            `json:"template_name,omitempty"`
+	TemplateParams map[string]string `json:"template_params,omitempty"`
+}
+
+// StoreResponse is the part of the response of the store service we care about.
+type StoreResponse struct {
+	ID string `json:"id"`
 }
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/metrics"
+	log "github.com/go-kit/log"
+)
+
+const (
+	// outboxPollInterval is how often the outbox looks for records to redeliver.
+	outboxPollInterval = time.Second
+	// outboxBatchSize bounds the records redelivered at each poll.
+	outboxBatchSize = 100
+	// outboxMinBackoff and outboxMaxBackoff bound the delay between two
+	// delivery attempts of a record, that doubles at each failure.
+	outboxMinBackoff = time.Second
+	outboxMaxBackoff = 5 * time.Minute
+	// outboxCompactThreshold is the number of delivered records kept in the
+	// file, beyond which it is rewritten with the pending records only.
+	outboxCompactThreshold = 1000
+)
+
+// outboxLine is a line of the outbox file. A record is appended with its
+// Request when it is enqueued, and again with Delivered set once the store
+// service has accepted it.
+type outboxLine struct {
+	Key       string        `json:"key"`
+	Request   *StoreRequest `json:"request,omitempty"`
+	Delivered bool          `json:"delivered,omitempty"`
+}
+
+type outboxRecord struct {
+	key      string
+	req      StoreRequest
+	seq      int64
+	attempts int
+	nextTry  time.Time
+}
+
+// Outbox is a durable queue of the history records that still have to be
+// delivered to the store service. It is backed by an append-only file of JSON
+// lines, so that no record is lost when the store service is unreachable or
+// bash_exec restarts. Records are identified by their idempotency key, which
+// lets the store service discard the ones delivered twice.
+type Outbox struct {
+	mtx     sync.Mutex
+	path    string
+	f       *os.File
+	pending map[string]*outboxRecord
+	seq     int64
+	acked   int
+	// rand draws the jitter of the retries, the global source is not seeded
+	// before Go 1.20, so every instance would retry in lockstep.
+	rand     *rand.Rand
+	depth    metrics.Gauge
+	failures metrics.Counter
+}
+
+// OpenOutbox opens the outbox file at path, creating it if needed, and loads
+// the records that were not delivered yet. depth is set to the number of
+// pending records, failures is incremented at each failed delivery.
+func OpenOutbox(path string, depth metrics.Gauge, failures metrics.Counter) (*Outbox, error) {
+	o := &Outbox{
+		path:     path,
+		pending:  map[string]*outboxRecord{},
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		depth:    depth,
+		failures: failures,
+	}
+
+	f, err := os.Open(path)
+	switch {
+	case err == nil:
+		err = o.load(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	// Rewriting the file drops the delivered records, and a line truncated by a crash.
+	if err = o.compact(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (o *Outbox) load(r io.Reader) error {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var l outboxLine
+		if err := dec.Decode(&l); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			if _, ok := err.(*json.SyntaxError); ok {
+				return nil
+			}
+			return err
+		}
+		switch {
+		case l.Delivered:
+			delete(o.pending, l.Key)
+		case l.Request != nil:
+			o.seq++
+			o.pending[l.Key] = &outboxRecord{key: l.Key, req: *l.Request, seq: o.seq}
+		}
+	}
+}
+
+// compact rewrites the file with the pending records only. The caller must
+// hold the mutex, or have exclusive access to o.
+func (o *Outbox) compact() error {
+	tmp := o.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, r := range o.sorted() {
+		req := r.req
+		if err = enc.Encode(outboxLine{Key: r.key, Request: &req}); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	f.Close()
+	if err == nil {
+		err = os.Rename(tmp, o.path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if o.f != nil {
+		o.f.Close()
+	}
+	if o.f, err = os.OpenFile(o.path, os.O_APPEND|os.O_WRONLY, 0o600); err != nil {
+		return err
+	}
+	o.acked = 0
+	o.depth.Set(float64(len(o.pending)))
+	return nil
+}
+
+// Enqueue durably stores req under key before it is delivered.
+func (o *Outbox) Enqueue(key string, req StoreRequest) error {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if err := o.append(outboxLine{Key: key, Request: &req}, true); err != nil {
+		return err
+	}
+	o.seq++
+	o.pending[key] = &outboxRecord{key: key, req: req, seq: o.seq}
+	o.depth.Set(float64(len(o.pending)))
+	return nil
+}
+
+// Ack removes the record of key, that has been delivered.
+func (o *Outbox) Ack(key string) error {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if _, ok := o.pending[key]; !ok {
+		return nil
+	}
+	// Losing an acknowledgement only means a duplicate delivery, no need to sync.
+	if err := o.append(outboxLine{Key: key, Delivered: true}, false); err != nil {
+		return err
+	}
+	delete(o.pending, key)
+	o.acked++
+	o.depth.Set(float64(len(o.pending)))
+	if o.acked > outboxCompactThreshold && o.acked > len(o.pending) {
+		return o.compact()
+	}
+	return nil
+}
+
+// Fail records a failed delivery of key, and postpones its next attempt.
+func (o *Outbox) Fail(key string) {
+	o.failures.Add(1)
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	r, ok := o.pending[key]
+	if !ok {
+		return
+	}
+	backoff := outboxMinBackoff << r.attempts
+	if backoff <= 0 || backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	r.attempts++
+	// Up to 20% of jitter keeps the instances from retrying in lockstep.
+	r.nextTry = time.Now().Add(backoff + time.Duration(o.rand.Int63n(int64(backoff)/5+1)))
+}
+
+// Depth returns the number of records waiting to be delivered.
+func (o *Outbox) Depth() int {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	return len(o.pending)
+}
+
+// Run redelivers the pending records through deliver until ctx is done.
+func (o *Outbox) Run(ctx context.Context, deliver endpoint.Endpoint, logger log.Logger) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, r := range o.due(time.Now()) {
+			if _, err := deliver(ctx, r.req); err != nil {
+				o.Fail(r.key)
+				logger.Log("outbox", "deliver", "key", r.key, "attempts", r.attempts+1, "err", err)
+				continue
+			}
+			if err := o.Ack(r.key); err != nil {
+				logger.Log("outbox", "ack", "key", r.key, "err", err)
+			}
+		}
+	}
+}
+
+// due returns the oldest pending records whose next attempt is not after now.
+func (o *Outbox) due(now time.Time) (res []outboxRecord) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	for _, r := range o.sorted() {
+		if len(res) == outboxBatchSize {
+			break
+		}
+		if !r.nextTry.After(now) {
+			res = append(res, *r)
+		}
+	}
+	return res
+}
+
+// sorted returns the pending records in the order they were enqueued. The
+// caller must hold the mutex.
+func (o *Outbox) sorted() []*outboxRecord {
+	res := make([]*outboxRecord, 0, len(o.pending))
+	for _, r := range o.pending {
+		res = append(res, r)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].seq < res[j].seq })
+	return res
+}
+
+// append writes l at the end of the file, and flushes it to disk if sync is set.
+// The caller must hold the mutex.
+func (o *Outbox) append(l outboxLine, sync bool) error {
+	b, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	if _, err = o.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	if sync {
+		return o.f.Sync()
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics/generic"
+)
+
+func openTestOutbox(t *testing.T, path string) (*Outbox, *generic.Gauge, *generic.Counter) {
+	t.Helper()
+	depth, failures := generic.NewGauge("depth"), generic.NewCounter("failures")
+	o, err := OpenOutbox(path, depth, failures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { o.f.Close() })
+	return o, depth, failures
+}
+
+func outboxKeys(records []outboxRecord) []string {
+	res := []string{}
+	for _, r := range records {
+		res = append(res, r.key)
+	}
+	return res
+}
+
+func TestOutbox(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	o, depth, failures := openTestOutbox(t, path)
+	for _, key := range []string{"a", "b", "c"} {
+		if err := o.Enqueue(key, StoreRequest{IdempotencyKey: key, Cmd: "echo " + key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	if got, want := outboxKeys(o.due(now)), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("due = %q, want %q", got, want)
+	}
+
+	// Each failure doubles the backoff, with up to 20% of jitter.
+	for i, backoff := range []time.Duration{outboxMinBackoff, 2 * outboxMinBackoff} {
+		before := time.Now()
+		o.Fail("b")
+		r := o.pending["b"]
+		if r.attempts != i+1 {
+			t.Errorf("attempts = %d, want %d", r.attempts, i+1)
+		}
+		if r.nextTry.Before(before.Add(backoff)) || r.nextTry.After(time.Now().Add(backoff+backoff/5)) {
+			t.Errorf("next try in %s, want %s to %s", r.nextTry.Sub(before), backoff, backoff+backoff/5)
+		}
+	}
+	o.Fail("unknown")
+	if got, want := outboxKeys(o.due(now)), []string{"a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("due = %q, want %q", got, want)
+	}
+	if got, want := outboxKeys(o.due(now.Add(outboxMaxBackoff))), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("due later = %q, want %q", got, want)
+	}
+	if got := failures.Value(); got != 3 {
+		t.Errorf("failures = %v, want 3", got)
+	}
+
+	for _, key := range []string{"a", "unknown"} {
+		if err := o.Ack(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if o.Depth() != 2 || depth.Value() != 2 {
+		t.Errorf("depth = %d, gauge %v, want 2", o.Depth(), depth.Value())
+	}
+
+	// The pending records survive a restart, in order, and a line truncated
+	// by a crash is dropped.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"key":"d","request":{"cmd":`)
+	f.Close()
+	o, depth, _ = openTestOutbox(t, path)
+	if got, want := outboxKeys(o.due(now)), []string{"b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("due after reload = %q, want %q", got, want)
+	}
+	if r := o.pending["c"]; r.req.Cmd != "echo c" {
+		t.Errorf("reloaded request = %+v", r.req)
+	}
+	if depth.Value() != 2 {
+		t.Errorf("depth gauge after reload = %v, want 2", depth.Value())
+	}
+	if err = o.Enqueue("e", StoreRequest{Cmd: "echo e"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := outboxKeys(o.due(now)), []string{"b", "c", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("due after enqueue = %q, want %q", got, want)
+	}
+}
+
+func TestOutboxCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	o, _, _ := openTestOutbox(t, path)
+	for _, key := range []string{"a", "b", "c"} {
+		if err := o.Enqueue(key, StoreRequest{Cmd: "echo " + key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lines := func() int {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bytes.Count(b, []byte("\n"))
+	}
+
+	// Below the threshold, the acknowledgements are appended.
+	if err := o.Ack("a"); err != nil {
+		t.Fatal(err)
+	}
+	if got := lines(); got != 4 {
+		t.Errorf("lines = %d, want 4", got)
+	}
+	// Beyond it, the file is rewritten with the pending records only.
+	o.acked = outboxCompactThreshold
+	if err := o.Ack("b"); err != nil {
+		t.Fatal(err)
+	}
+	if got := lines(); got != 1 {
+		t.Errorf("lines after compaction = %d, want 1", got)
+	}
+	if o.acked != 0 {
+		t.Errorf("acked after compaction = %d, want 0", o.acked)
+	}
+	// The file is still appended to.
+	if err := o.Enqueue("d", StoreRequest{Cmd: "echo d"}); err != nil {
+		t.Fatal(err)
+	}
+	o, _, _ = openTestOutbox(t, path)
+	if got, want := outboxKeys(o.due(time.Now())), []string{"c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("due after reload = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+	log "github.com/go-kit/log"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var (
+	ErrCommandForbidden = errs.New(http.StatusForbidden, "command_forbidden", "command forbidden")
+	ErrApprovalRequired = ErrCommandForbidden.Extend("approval_required", "approval required")
+	ErrInvalidPolicy    = errors.New("invalid policy")
+)
+
+// PolicyAction is the outcome of a policy rule.
+type PolicyAction string
+
+const (
+	PolicyAllow PolicyAction = "allow"
+	PolicyDeny  PolicyAction = "deny"
+	// PolicyApprovalRequired denies the execution like PolicyDeny, with
+	// ErrApprovalRequired to tell the caller that it needs an approval, which
+	// is given out of the service: the command is not held.
+	PolicyApprovalRequired PolicyAction = "approval-required"
+)
+
+// PolicyRule matches an execution on its executable, its arguments and its caller.
+// Every criterion left empty matches anything.
+type PolicyRule struct {
+	Name   string       `json:"name" yaml:"name"`
+	Action PolicyAction `json:"action" yaml:"action"`
+	// Executables are compared with the base name of argv[0], or with the full
+	// path when they contain a slash. An allow rule compares instead the
+	// absolute paths they resolve to, with the PATH of the service, to the
+	// one of argv[0], so that it does not allow another file of the same name.
+	// Shell mode executions run "bash", that a rule only allows by listing it.
+	Executables []string `json:"executables,omitempty" yaml:"executables,omitempty"`
+	// Args are regular expressions that must each match at least one argument.
+	Args []string `json:"args,omitempty" yaml:"args,omitempty"`
+	// Callers are the identities the rule applies to, see CallerFromContext.
+	Callers []string `json:"callers,omitempty" yaml:"callers,omitempty"`
+
+	args []*regexp.Regexp
+	// paths are the executables of an allow rule, resolved.
+	paths []string
+}
+
+// Policy is an ordered list of rules, the first matching rule decides the
+// outcome of an execution and Default applies when none matches.
+type Policy struct {
+	Default PolicyAction  `json:"default" yaml:"default"`
+	Rules   []*PolicyRule `json:"rules" yaml:"rules"`
+}
+
+// LoadPolicy reads a Policy from a YAML or JSON file, chosen by its extension.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Policy{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, p)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, p)
+	default:
+		err = fmt.Errorf("%w: unknown file extension of %s", ErrInvalidPolicy, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = p.compile(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// compile validates the policy and compiles the argument expressions.
+func (p *Policy) compile() error {
+	if p.Default == "" {
+		p.Default = PolicyDeny
+	}
+	if !p.Default.valid() {
+		return fmt.Errorf("%w: unknown default action %q", ErrInvalidPolicy, p.Default)
+	}
+	for i, r := range p.Rules {
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("rule-%d", i)
+		}
+		if !r.Action.valid() {
+			return fmt.Errorf("%w: unknown action %q in rule %s", ErrInvalidPolicy, r.Action, r.Name)
+		}
+		r.args = make([]*regexp.Regexp, 0, len(r.Args))
+		for _, a := range r.Args {
+			re, err := regexp.Compile(a)
+			if err != nil {
+				return fmt.Errorf("%w: rule %s: %v", ErrInvalidPolicy, r.Name, err)
+			}
+			r.args = append(r.args, re)
+		}
+		// An executable that cannot be resolved allows nothing.
+		r.paths = nil
+		if r.Action == PolicyAllow {
+			for _, e := range r.Executables {
+				if path := resolveExecutable(e, ""); path != "" {
+					r.paths = append(r.paths, path)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (a PolicyAction) valid() bool {
+	return a == PolicyAllow || a == PolicyDeny || a == PolicyApprovalRequired
+}
+
+// Evaluate returns the action for argv run in the working directory cwd by
+// caller, and the name of the rule that decided it, empty when the default
+// action applies. The shell runs anything, so it is only allowed by a rule
+// that lists it: neither the allow rules without executables nor an allow
+// default apply to it.
+func (p *Policy) Evaluate(argv []string, cwd, caller string) (PolicyAction, string) {
+	path := resolveExecutable(argv[0], cwd)
+	shell := isShell(argv[0], path)
+	for _, r := range p.Rules {
+		if shell && r.Action == PolicyAllow && len(r.Executables) == 0 {
+			continue
+		}
+		if r.matches(argv, path, caller) {
+			return r.Action, r.Name
+		}
+	}
+	if shell && p.Default == PolicyAllow {
+		return PolicyDeny, ""
+	}
+	return p.Default, ""
+}
+
+// matches reports whether the rule applies to argv, whose executable
+// resolves to path, run by caller.
+func (r *PolicyRule) matches(argv []string, path, caller string) bool {
+	switch {
+	case len(r.Executables) == 0:
+	case r.Action == PolicyAllow:
+		if path == "" || !contains(r.paths, path) {
+			return false
+		}
+	case !matchExecutable(r.Executables, argv[0], path):
+		return false
+	}
+	if len(r.Callers) > 0 && !contains(r.Callers, caller) {
+		return false
+	}
+	for _, re := range r.args {
+		if !matchAnyArg(re, argv[1:]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchExecutable reports whether one of executables names name, or path
+// it resolves to.
+func matchExecutable(executables []string, name, path string) bool {
+	for _, e := range executables {
+		if e == name || (path != "" && e == path) {
+			return true
+		}
+		if !strings.Contains(e, "/") && (e == filepath.Base(name) || (path != "" && e == filepath.Base(path))) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveExecutable returns the absolute path, with its symbolic links
+// resolved, of the executable name run in the working directory cwd, the one
+// of the service when empty. name is looked up in the PATH of the service
+// when it has no slash, like exec.Command does. It returns "" when name
+// cannot be found.
+func resolveExecutable(name, cwd string) string {
+	path := name
+	if !strings.Contains(name, "/") {
+		var err error
+		if path, err = exec.LookPath(name); err != nil {
+			return ""
+		}
+	} else if !filepath.IsAbs(path) && cwd != "" {
+		path = filepath.Join(cwd, path)
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// isShell reports whether name, that resolves to path, is the shell of
+// ExecModeShell.
+func isShell(name, path string) bool {
+	return filepath.Base(name) == filepath.Base(shellPath) || (path != "" && path == resolveExecutable(shellPath, ""))
+}
+
+func matchAnyArg(re *regexp.Regexp, args []string) bool {
+	for _, a := range args {
+		if re.MatchString(a) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s || e == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+type callerKey struct{}
+
+// ContextWithCaller returns a copy of ctx carrying the identity of the caller.
+func ContextWithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the identity of the caller stored in ctx, if any.
+// The transports store the principal they authenticated, see pkg/auth.
+func CallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+type policyMiddleware struct {
+	policy *Policy
+	logger log.Logger
+	next   BashExecService
+}
+
+// PolicyMiddleware returns a BashExecService Middleware that rejects the
+// executions denied by policy with ErrCommandForbidden, and the ones that
+// need an approval with ErrApprovalRequired.
+func PolicyMiddleware(policy *Policy, logger log.Logger) Middleware {
+	return func(next BashExecService) BashExecService {
+		return &policyMiddleware{policy, logger, next}
+	}
+}
+
+func (p policyMiddleware) ExecCmd(ctx context.Context, cmd string, opts ExecOptions) (res ExecResult, err error) {
+	argv, err := buildArgv(strings.TrimSpace(cmd), opts.Mode)
+	if err != nil || len(argv) == 0 {
+		// Let the service report malformed commands.
+		return p.next.ExecCmd(ctx, cmd, opts)
+	}
+
+	caller := CallerFromContext(ctx)
+	action, rule := p.policy.Evaluate(argv, opts.Cwd, caller)
+	switch action {
+	case PolicyAllow:
+		return p.next.ExecCmd(ctx, cmd, opts)
+	case PolicyApprovalRequired:
+		err = ErrApprovalRequired
+	default:
+		err = ErrCommandForbidden
+	}
+	if rule != "" {
+		err = errs.WithDetails(fmt.Errorf("%w by rule %s", err, rule), map[string]string{"rule": rule})
+	}
+	p.logger.Log("method", "ExecCmd", "cmd", cmd, "caller", caller, "policy", action, "rule", rule)
+	return ExecResult{ExitCode: -999}, err
+}
+
+// SubmitJob lets every job through, the policy is enforced when the job runs
+// its ExecCmd through the middleware chain.
+func (p policyMiddleware) SubmitJob(ctx context.Context, cmd string, opts ExecOptions) (id string, err error) {
+	return p.next.SubmitJob(ctx, cmd, opts)
+}
+
+func (p policyMiddleware) GetJob(ctx context.Context, id string) (job Job, err error) {
+	return p.next.GetJob(ctx, id)
+}
+
+func (p policyMiddleware) CancelJob(ctx context.Context, id string) (err error) {
+	return p.next.CancelJob(ctx, id)
+}
+
+// ExecBatch lets every batch through, the policy is enforced on each step
+// when it runs its ExecCmd through the middleware chain.
+func (p policyMiddleware) ExecBatch(ctx context.Context, steps []BatchStep) (res BatchResult, err error) {
+	return p.next.ExecBatch(ctx, steps)
+}
+
+// CreateSchedule lets every schedule through, the policy is enforced on each
+// run when it runs its ExecCmd through the middleware chain, on behalf of the
+// principal of the schedule.
+func (p policyMiddleware) CreateSchedule(ctx context.Context, spec ScheduleSpec) (schedule Schedule, err error) {
+	return p.next.CreateSchedule(ctx, spec)
+}
+
+func (p policyMiddleware) ListSchedules(ctx context.Context) (schedules []Schedule, err error) {
+	return p.next.ListSchedules(ctx)
+}
+
+func (p policyMiddleware) GetSchedule(ctx context.Context, id string) (schedule Schedule, err error) {
+	return p.next.GetSchedule(ctx, id)
+}
+
+// UpdateSchedule lets every schedule through, like CreateSchedule.
+func (p policyMiddleware) UpdateSchedule(ctx context.Context, id string, spec ScheduleSpec) (schedule Schedule, err error) {
+	return p.next.UpdateSchedule(ctx, id, spec)
+}
+
+func (p policyMiddleware) DeleteSchedule(ctx context.Context, id string) (err error) {
+	return p.next.DeleteSchedule(ctx, id)
+}
+
+func (p policyMiddleware) CreateTemplate(ctx context.Context, name string, spec TemplateSpec) (tmpl CommandTemplate, err error) {
+	return p.next.CreateTemplate(ctx, name, spec)
+}
+
+func (p policyMiddleware) ListTemplates(ctx context.Context) (tmpls []CommandTemplate, err error) {
+	return p.next.ListTemplates(ctx)
+}
+
+func (p policyMiddleware) GetTemplate(ctx context.Context, name string) (tmpl CommandTemplate, err error) {
+	return p.next.GetTemplate(ctx, name)
+}
+
+func (p policyMiddleware) UpdateTemplate(ctx context.Context, name string, spec TemplateSpec) (tmpl CommandTemplate, err error) {
+	return p.next.UpdateTemplate(ctx, name, spec)
+}
+
+func (p policyMiddleware) DeleteTemplate(ctx context.Context, name string) (err error) {
+	return p.next.DeleteTemplate(ctx, name)
+}
+
+// ExecTemplate lets every execution through, the policy is enforced on the
+// rendered command when it runs its ExecCmd through the middleware chain.
+func (p policyMiddleware) ExecTemplate(ctx context.Context, name string, params map[string]string) (res ExecResult, err error) {
+	return p.next.ExecTemplate(ctx, name, params)
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+	log "github.com/go-kit/log"
+)
+
+const testPolicy = `
+rules:
+  - name: no-rm-root
+    action: deny
+    executables: [rm]
+    args: ['^-[a-zA-Z]*r', '^/$']
+  - name: ops-restart
+    action: approval-required
+    executables: [systemctl]
+    args: ['^restart$']
+    callers: [alice, bob]
+  - name: admin
+    action: allow
+    callers: [root]
+  - name: read-only
+    action: allow
+    executables: [ls, cat, /usr/bin/id]
+`
+
+func loadTestPolicy(t *testing.T, name, content string) (*Policy, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return LoadPolicy(path)
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	p, err := loadTestPolicy(t, "policy.yaml", testPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// dir holds an executable named like an allowed one.
+	dir := t.TempDir()
+	if err = os.WriteFile(filepath.Join(dir, "ls"), []byte("#!/bin/sh\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		argv   []string
+		cwd    string
+		caller string
+		action PolicyAction
+		rule   string
+	}{
+		{name: "deny by default", argv: []string{"curl", "example.com"}, action: PolicyDeny},
+		{name: "allowed executable", argv: []string{"ls", "-l"}, action: PolicyAllow, rule: "read-only"},
+		{name: "base name of a path", argv: []string{"/bin/cat", "f"}, action: PolicyAllow, rule: "read-only"},
+		{name: "full path rule", argv: []string{"/usr/bin/id"}, action: PolicyAllow, rule: "read-only"},
+		{name: "full path rule and the name it resolves", argv: []string{"id"}, action: PolicyAllow, rule: "read-only"},
+		{name: "another file of an allowed name", argv: []string{"./ls"}, cwd: dir, action: PolicyDeny},
+		{name: "another file of an allowed name by path", argv: []string{filepath.Join(dir, "ls")}, action: PolicyDeny},
+		{name: "missing file of an allowed name", argv: []string{"./ls"}, cwd: t.TempDir(), action: PolicyDeny},
+		{name: "every arg regexp matches", argv: []string{"rm", "-rf", "/"}, caller: "root", action: PolicyDeny, rule: "no-rm-root"},
+		{name: "one arg regexp does not match", argv: []string{"rm", "-rf", "/tmp/x"}, action: PolicyDeny},
+		{name: "first matching rule wins", argv: []string{"rm", "-rf", "/tmp/x"}, caller: "root", action: PolicyAllow, rule: "admin"},
+		{name: "caller listed", argv: []string{"systemctl", "restart", "nginx"}, caller: "bob", action: PolicyApprovalRequired, rule: "ops-restart"},
+		{name: "caller not listed", argv: []string{"systemctl", "restart", "nginx"}, caller: "eve", action: PolicyDeny},
+		{name: "anonymous caller", argv: []string{"systemctl", "restart", "nginx"}, action: PolicyDeny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, rule := p.Evaluate(tt.argv, tt.cwd, tt.caller)
+			if action != tt.action || rule != tt.rule {
+				t.Errorf("Evaluate(%q, %q, %q) = %s, %q, want %s, %q", tt.argv, tt.cwd, tt.caller, action, rule, tt.action, tt.rule)
+			}
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr bool
+	}{
+		{name: "json", file: "p.json", content: `{"default":"allow","rules":[{"action":"deny","executables":["rm"]}]}`},
+		{name: "empty yaml", file: "p.yml", content: ``},
+		{name: "unknown action", file: "p.yaml", content: "rules:\n  - action: maybe\n", wantErr: true},
+		{name: "unknown default", file: "p.yaml", content: "default: sometimes\n", wantErr: true},
+		{name: "bad regexp", file: "p.yaml", content: "rules:\n  - action: deny\n    args: ['(']\n", wantErr: true},
+		{name: "unknown extension", file: "p.toml", content: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := loadTestPolicy(t, tt.file, tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("LoadPolicy succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			// A policy without a default denies what no rule allows.
+			if tt.content == "" && p.Default != PolicyDeny {
+				t.Errorf("Default = %s, want %s", p.Default, PolicyDeny)
+			}
+		})
+	}
+}
+
+// execRecorder is a BashExecService that records the commands it runs.
+type execRecorder struct {
+	BashExecService
+	ran []string
+}
+
+func (r *execRecorder) ExecCmd(ctx context.Context, cmd string, opts ExecOptions) (ExecResult, error) {
+	r.ran = append(r.ran, cmd)
+	return ExecResult{}, nil
+}
+
+func TestPolicyMiddleware(t *testing.T) {
+	p, err := loadTestPolicy(t, "policy.yaml", testPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		cmd    string
+		mode   ExecMode
+		caller string
+		err    error
+		rule   string
+	}{
+		{name: "allowed", cmd: "ls -l /", err: nil},
+		{name: "denied by default", cmd: "curl example.com", err: ErrCommandForbidden},
+		{name: "denied by rule", cmd: "rm -rf /", caller: "root", err: ErrCommandForbidden, rule: "no-rm-root"},
+		{name: "approval required", cmd: "systemctl restart nginx", caller: "alice", err: ErrApprovalRequired, rule: "ops-restart"},
+		{name: "shell mode runs bash", cmd: "ls | sh", mode: ExecModeShell, err: ErrCommandForbidden},
+		{name: "malformed command reaches the service", cmd: "ls 'a", err: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &execRecorder{}
+			svc := PolicyMiddleware(p, log.NewNopLogger())(next)
+			ctx := ContextWithCaller(context.Background(), tt.caller)
+			_, err := svc.ExecCmd(ctx, tt.cmd, ExecOptions{Mode: tt.mode})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ExecCmd(%q) error = %v, want %v", tt.cmd, err, tt.err)
+			}
+			if ran := len(next.ran) == 1; ran != (tt.err == nil) {
+				t.Errorf("ExecCmd(%q) ran the command: %v", tt.cmd, ran)
+			}
+			if tt.rule != "" {
+				if got := errs.From(err).Details["rule"]; got != tt.rule {
+					t.Errorf("rule detail = %q, want %q", got, tt.rule)
+				}
+			}
+		})
+	}
+}
+
+const testShellPolicy = `
+default: allow
+rules:
+  - name: no-rm
+    action: deny
+    executables: [rm]
+  - name: root
+    action: allow
+    callers: [root]
+  - name: ops-shell
+    action: allow
+    executables: [bash]
+    callers: [ops]
+`
+
+func TestPolicyShell(t *testing.T) {
+	p, err := loadTestPolicy(t, "policy.yaml", testShellPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		cmd    string
+		mode   ExecMode
+		caller string
+		err    error
+	}{
+		{name: "denied executable", cmd: "rm -rf /", err: ErrCommandForbidden},
+		{name: "denied executable in shell mode", cmd: "rm -rf /", mode: ExecModeShell, err: ErrCommandForbidden},
+		{name: "shell is not allowed by default", cmd: "ls", mode: ExecModeShell, err: ErrCommandForbidden},
+		{name: "shell is not allowed by a rule without executables", cmd: "ls", mode: ExecModeShell, caller: "root", err: ErrCommandForbidden},
+		{name: "shell in argv mode", cmd: "bash -c 'rm -rf /'", err: ErrCommandForbidden},
+		{name: "shell allowed by name", cmd: "rm -rf /tmp/x", mode: ExecModeShell, caller: "ops"},
+		{name: "allowed by default", cmd: "ls -l"},
+		{name: "allowed by a rule without executables", cmd: "id", caller: "root"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &execRecorder{}
+			svc := PolicyMiddleware(p, log.NewNopLogger())(next)
+			ctx := ContextWithCaller(context.Background(), tt.caller)
+			_, err := svc.ExecCmd(ctx, tt.cmd, ExecOptions{Mode: tt.mode})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ExecCmd(%q) error = %v, want %v", tt.cmd, err, tt.err)
+			}
+			if ran := len(next.ran) == 1; ran != (tt.err == nil) {
+				t.Errorf("ExecCmd(%q) ran the command: %v", tt.cmd, ran)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup is a no-op, process groups are not supported on Windows.
+func setProcessGroup(c *exec.Cmd) {}
+
+// signalProcessGroup kills p, Windows cannot deliver sig nor signal a group.
+func signalProcessGroup(p *os.Process, sig syscall.Signal) error {
+	return p.Kill()
+}
+
+// peakRSS returns 0, the peak memory of a process is not reported on Windows.
+func peakRSS(ps *os.ProcessState) int64 {
+	return 0
+}
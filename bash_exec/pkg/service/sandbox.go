@@ -0,0 +1,26 @@
+package service
+
+// SandboxConfig collects the settings of the sandbox executor.
+type SandboxConfig struct {
+	// CgroupRoot is the cgroup v2 under which the commands get a cgroup, see NewHostExecutor.
+	CgroupRoot string
+	// Hostname is the hostname the commands see, "sandbox" when empty.
+	Hostname string
+	// ScratchSize bounds the size of the scratch dir in bytes, 64 MiB when 0.
+	ScratchSize int64
+}
+
+const (
+	defaultSandboxHostname    = "sandbox"
+	defaultSandboxScratchSize = 64 << 20
+	// sandboxScratch is the writable dir of a sandbox, and the working
+	// directory of the commands that do not set one.
+	sandboxScratch = "/tmp"
+)
+
+// sandboxSpec tells the helper how to set up the sandbox of a command.
+type sandboxSpec struct {
+	Hostname    string `json:"hostname"`
+	ScratchSize int64  `json:"scratch_size"`
+	Dir         string `json:"dir"`
+}
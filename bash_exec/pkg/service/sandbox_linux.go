@@ -0,0 +1,175 @@
+//go:build linux
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+type sandboxExecutor struct {
+	cfg SandboxConfig
+}
+
+// NewSandboxExecutor returns the executor that runs every command in new PID,
+// mount, network, UTS, IPC and user namespaces: the command is PID 1 and sees
+// only its own processes, it has no network, not even loopback, and it is
+// root in its user namespace, mapped to the user of the service. Its root is
+// the one of the service, read-only, but for a tmpfs scratch dir at /tmp, its
+// working directory when the request does not set one. A seccomp filter
+// denies it the system calls that administer the system or escape the
+// namespaces, e.g. mount, ptrace or setns.
+//
+// As PID 1, the command ignores the signals it does not handle, so it is only
+// stopped by SIGKILL, killGracePeriod after a timeout. It fails on an
+// architecture the filter does not support, and the executions fail with
+// ErrSandbox when the system does not allow user namespaces.
+func NewSandboxExecutor(cfg SandboxConfig) (Executor, error) {
+	if _, err := seccompFilter(); err != nil {
+		return nil, err
+	}
+	if cfg.Hostname == "" {
+		cfg.Hostname = defaultSandboxHostname
+	}
+	if cfg.ScratchSize == 0 {
+		cfg.ScratchSize = defaultSandboxScratchSize
+	}
+	return &sandboxExecutor{cfg}, nil
+}
+
+func (e *sandboxExecutor) Start(ctx context.Context, c *exec.Cmd, l Limits) (func(*os.ProcessState) string, error) {
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	c.SysProcAttr.Cloneflags |= unix.CLONE_NEWUSER | unix.CLONE_NEWPID | unix.CLONE_NEWNS |
+		unix.CLONE_NEWNET | unix.CLONE_NEWUTS | unix.CLONE_NEWIPC
+	c.SysProcAttr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Geteuid(), Size: 1}}
+	c.SysProcAttr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getegid(), Size: 1}}
+	c.SysProcAttr.GidMappingsEnableSetgroups = false
+
+	dir := c.Dir
+	if dir == "" {
+		dir = sandboxScratch
+	}
+	return startHelper(ctx, c, l, e.cfg.CgroupRoot, &sandboxSpec{
+		Hostname:    e.cfg.Hostname,
+		ScratchSize: e.cfg.ScratchSize,
+		Dir:         dir,
+	})
+}
+
+// enter sets up the sandbox from within its namespaces: the new root is the
+// root of the host bound under the scratch dir, made read-only but for the
+// scratch dir, with the proc of the new PID namespace, and it is pivoted to.
+func (s *sandboxSpec) enter() error {
+	if err := unix.Sethostname([]byte(s.Hostname)); err != nil {
+		return fmt.Errorf("sethostname: %w", err)
+	}
+	// The mounts must not propagate to the host.
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("make / private: %w", err)
+	}
+	opts := "mode=1777,size=" + strconv.FormatInt(s.ScratchSize, 10)
+	if err := unix.Mount("tmpfs", sandboxScratch, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, opts); err != nil {
+		return fmt.Errorf("mount scratch: %w", err)
+	}
+	root := filepath.Join(sandboxScratch, ".root")
+	if err := os.Mkdir(root, 0o700); err != nil {
+		return err
+	}
+	if err := unix.Mount("/", root, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("bind root: %w", err)
+	}
+
+	mounts, err := mountPoints(root)
+	if err != nil {
+		return err
+	}
+	scratch, proc := filepath.Join(root, sandboxScratch), filepath.Join(root, "proc")
+	for _, m := range mounts {
+		if within(m, scratch) || within(m, proc) {
+			continue
+		}
+		if err := remountReadOnly(m); err != nil {
+			return fmt.Errorf("remount %s read-only: %w", strings.TrimPrefix(m, root), err)
+		}
+	}
+	if err := unix.Mount("proc", proc, "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+		return fmt.Errorf("mount proc: %w", err)
+	}
+
+	// Pivoting the root onto itself stacks the old root on the new one, from
+	// which it is detached.
+	if err := os.Chdir(root); err != nil {
+		return err
+	}
+	if err := unix.PivotRoot(".", "."); err != nil {
+		return fmt.Errorf("pivot_root: %w", err)
+	}
+	if err := unix.Unmount(".", unix.MNT_DETACH); err != nil {
+		return fmt.Errorf("detach the old root: %w", err)
+	}
+	// The mount point of the new root is left empty in the scratch dir.
+	os.Remove(root)
+	return os.Chdir(s.Dir)
+}
+
+// within reports whether path is dir or one of its subdirectories.
+func within(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+"/")
+}
+
+// mountPoints returns the mount points at or under dir, parents first.
+func mountPoints(dir string) ([]string, error) {
+	data, err := os.ReadFile("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	// The mount points escape their blanks and backslashes in octal.
+	unescape := strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+	var res []string
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		if p := unescape.Replace(fields[4]); within(p, dir) {
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
+
+// remountReadOnly makes the mount at path read-only. The flags of a mount
+// that are locked in a user namespace, like nosuid, must be kept.
+func remountReadOnly(path string) error {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return err
+	}
+	flags := uintptr(unix.MS_REMOUNT | unix.MS_BIND | unix.MS_RDONLY)
+	for _, f := range []struct {
+		st int64
+		ms uintptr
+	}{
+		{unix.ST_NOSUID, unix.MS_NOSUID},
+		{unix.ST_NODEV, unix.MS_NODEV},
+		{unix.ST_NOEXEC, unix.MS_NOEXEC},
+		{unix.ST_NOATIME, unix.MS_NOATIME},
+		{unix.ST_NODIRATIME, unix.MS_NODIRATIME},
+		{unix.ST_RELATIME, unix.MS_RELATIME},
+	} {
+		if int64(st.Flags)&f.st != 0 {
+			flags |= f.ms
+		}
+	}
+	return unix.Mount("", path, "", flags, "")
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package service
+
+import "errors"
+
+// NewSandboxExecutor fails, namespaces only exist on Linux.
+func NewSandboxExecutor(cfg SandboxConfig) (Executor, error) {
+	return nil, errors.New("the sandbox is only supported on Linux")
+}
@@ -0,0 +1,452 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+	log "github.com/go-kit/log"
+)
+
+var (
+	ErrScheduleNotFound  = errs.New(http.StatusNotFound, "schedule_not_found", "schedule not found")
+	ErrInvalidSchedule   = errs.New(http.StatusBadRequest, "invalid_schedule", "invalid schedule")
+	ErrSchedulerDisabled = errs.New(http.StatusNotImplemented, "scheduler_disabled", "scheduler is disabled")
+)
+
+// schedulerMaxWait bounds the time the scheduler sleeps, so that it notices
+// the changes of the clock.
+const schedulerMaxWait = time.Minute
+
+// OverlapPolicy tells what a schedule does when it is due while its previous
+// run is still in progress.
+type OverlapPolicy string
+
+const (
+	// OverlapSkip skips the run. It is the default.
+	OverlapSkip OverlapPolicy = "skip"
+	// OverlapAllow starts the run alongside the previous ones.
+	OverlapAllow OverlapPolicy = "allow"
+	// OverlapReplace cancels the previous runs and starts the run.
+	OverlapReplace OverlapPolicy = "replace"
+)
+
+// ScheduleSpec is what a schedule runs, and when.
+type ScheduleSpec struct {
+	// Cron is when the schedule is due, see parseCron.
+	Cron string `json:"cron"`
+	// Timezone is the IANA name of the location Cron is evaluated in, e.g.
+	// "Europe/Rome", UTC when empty.
+	Timezone string        `json:"timezone,omitempty"`
+	Overlap  OverlapPolicy `json:"overlap,omitempty"`
+	// Jitter delays every run by a random duration up to Jitter, so that the
+	// schedules due at the same time do not all start at once.
+	Jitter  time.Duration `json:"jitter,omitempty"`
+	Cmd     string        `json:"cmd"`
+	Options ExecOptions   `json:"options"`
+}
+
+// Schedule is a command that runs periodically, like a cron job.
+type Schedule struct {
+	ID string `json:"id"`
+	ScheduleSpec
+	// Principal is the caller that created or last updated the schedule, its
+	// runs are executed on its behalf.
+	Principal string    `json:"principal,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// NextRun is when the schedule is due next, jitter excluded.
+	NextRun time.Time `json:"next_run"`
+	// LastRun is the last run that finished, nil before the first one.
+	LastRun *ScheduleRun `json:"last_run,omitempty"`
+	// Running is the number of runs in progress, Skipped the number of runs
+	// skipped by OverlapSkip.
+	Running int `json:"running"`
+	Skipped int `json:"skipped"`
+}
+
+// ScheduleRun is the outcome of a run of a schedule. Err is set when the
+// command could not run, the exit status is given by ExitCode.
+type ScheduleRun struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	ExitCode   int       `json:"exit_code"`
+	HistoryID  string    `json:"history_id,omitempty"`
+	Err        string    `json:"err,omitempty"`
+}
+
+// schedule is the state behind a Schedule, guarded by the Scheduler mutex.
+type schedule struct {
+	Schedule
+	cron *cronSchedule
+	loc  *time.Location
+	// cancel cancels the runs in progress, by sequence number.
+	cancel map[uint64]context.CancelFunc
+}
+
+// Scheduler runs the commands of the schedules created through the service
+// when they are due. Each run goes through svc, the whole middleware chain,
+// like a synchronous execution, and is linked to its schedule in the history.
+// The schedules are kept in a JSON file, rewritten at every change.
+type Scheduler struct {
+	path   string
+	logger log.Logger
+	svc    BashExecService
+
+	mtx       sync.Mutex
+	schedules map[string]*schedule
+	seq       uint64
+	// rand draws the jitter of the runs, the global source is not seeded
+	// before Go 1.20, so every instance would draw the same delays.
+	rand *rand.Rand
+	// wake tells Run that the schedules changed.
+	wake chan struct{}
+	// runs are the runs in progress, that Run waits for.
+	runs sync.WaitGroup
+}
+
+// OpenScheduler returns the Scheduler of the schedules kept in the file at
+// path, that is created at the first change. The schedules start running
+// with Run, the runs they missed while the service was down are lost.
+func OpenScheduler(path string, logger log.Logger) (*Scheduler, error) {
+	s := &Scheduler{
+		path:      path,
+		logger:    logger,
+		schedules: map[string]*schedule{},
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		wake:      make(chan struct{}, 1),
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return s, nil
+	case err != nil:
+		return nil, err
+	}
+	var list []Schedule
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	now := time.Now()
+	for _, sch := range list {
+		c, err := newSchedule(sch)
+		if err != nil {
+			return nil, fmt.Errorf("%s: schedule %s: %w", path, sch.ID, err)
+		}
+		c.NextRun = c.next(now)
+		s.schedules[sch.ID] = c
+	}
+	return s, nil
+}
+
+// newSchedule validates the spec of sch and compiles it.
+func newSchedule(sch Schedule) (*schedule, error) {
+	cron, err := parseCron(sch.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	loc, err := time.LoadLocation(sch.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: timezone %q: %v", ErrInvalidSchedule, sch.Timezone, err)
+	}
+	if cron.next(time.Now().In(loc)).IsZero() {
+		return nil, fmt.Errorf("%w: %q is never due", ErrInvalidSchedule, sch.Cron)
+	}
+	switch sch.Overlap {
+	case "":
+		sch.Overlap = OverlapSkip
+	case OverlapSkip, OverlapAllow, OverlapReplace:
+	default:
+		return nil, fmt.Errorf("%w: unknown overlap policy %q", ErrInvalidSchedule, sch.Overlap)
+	}
+	if sch.Jitter < 0 {
+		return nil, fmt.Errorf("%w: negative jitter", ErrInvalidSchedule)
+	}
+
+	// The command is checked like ExecCmd does, but for its environment that
+	// is only checked when it runs.
+	argv, err := buildArgv(strings.TrimSpace(sch.Cmd), sch.Options.Mode)
+	if err != nil {
+		return nil, err
+	}
+	if len(argv) == 0 {
+		return nil, ErrInvalidCommand
+	}
+	if sch.Options.Timeout < 0 {
+		return nil, ErrInvalidTimeout
+	}
+	if err = sch.Options.Limits.validate(); err != nil {
+		return nil, err
+	}
+	sch.Running = 0
+	return &schedule{Schedule: sch, cron: cron, loc: loc, cancel: map[uint64]context.CancelFunc{}}, nil
+}
+
+// next returns when s is due after now.
+func (s *schedule) next(now time.Time) time.Time {
+	return s.cron.next(now.In(s.loc))
+}
+
+func (s *schedule) snapshot() Schedule {
+	snapshot := s.Schedule
+	snapshot.Running = len(s.cancel)
+	return snapshot
+}
+
+// Run starts the runs of the schedules when they are due, until ctx is done.
+// It then waits for the runs in progress, that are cancelled with ctx.
+func (s *Scheduler) Run(ctx context.Context) error {
+	defer s.runs.Wait()
+	for {
+		timer := time.NewTimer(s.dispatch(ctx, time.Now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-s.wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// dispatch starts the runs of the schedules due at now, and returns how long
+// to wait for the next one.
+func (s *Scheduler) dispatch(ctx context.Context, now time.Time) time.Duration {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	wait := schedulerMaxWait
+	for _, sch := range s.schedules {
+		if sch.NextRun.IsZero() {
+			continue
+		}
+		if !sch.NextRun.After(now) {
+			sch.NextRun = sch.next(now)
+			s.start(ctx, sch)
+		}
+		if d := sch.NextRun.Sub(now); !sch.NextRun.IsZero() && d < wait {
+			wait = d
+		}
+	}
+	return wait
+}
+
+// start starts a run of sch, or skips it, by its overlap policy. The caller
+// must hold the mutex.
+func (s *Scheduler) start(ctx context.Context, sch *schedule) {
+	if len(sch.cancel) > 0 {
+		switch sch.Overlap {
+		case OverlapSkip:
+			sch.Skipped++
+			s.logger.Log("schedule", sch.ID, "run", "skipped", "running", len(sch.cancel))
+			return
+		case OverlapReplace:
+			for _, cancel := range sch.cancel {
+				cancel()
+			}
+		}
+	}
+	var delay time.Duration
+	if sch.Jitter > 0 {
+		delay = time.Duration(s.rand.Int63n(int64(sch.Jitter)))
+	}
+	s.seq++
+	ctx, cancel := context.WithCancel(ContextWithCaller(ctx, sch.Principal))
+	sch.cancel[s.seq] = cancel
+	s.runs.Add(1)
+	go s.run(ctx, cancel, sch.ID, s.seq, sch.Cmd, sch.Options, delay)
+}
+
+// run executes a run of the schedule id after delay, unless it is cancelled first.
+func (s *Scheduler) run(ctx context.Context, cancel context.CancelFunc, id string, seq uint64, cmd string, opts ExecOptions, delay time.Duration) {
+	defer s.runs.Done()
+	defer cancel()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	run := ScheduleRun{ExitCode: -999}
+	select {
+	case <-ctx.Done():
+		run.Err = ctx.Err().Error()
+	case <-timer.C:
+		opts.ScheduleID = id
+		run.StartedAt = time.Now()
+		res, err := s.svc.ExecCmd(ctx, cmd, opts)
+		run.FinishedAt, run.ExitCode, run.HistoryID = time.Now(), res.ExitCode, res.HistoryID
+		if err != nil {
+			run.Err = err.Error()
+		}
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sch, ok := s.schedules[id]
+	if !ok {
+		// The schedule was deleted while it ran.
+		return
+	}
+	delete(sch.cancel, seq)
+	sch.LastRun = &run
+	if err := s.save(); err != nil {
+		s.logger.Log("schedules", s.path, "during", "save", "err", err)
+	}
+}
+
+func (s *Scheduler) create(ctx context.Context, spec ScheduleSpec) (Schedule, error) {
+	id, err := newID()
+	if err != nil {
+		return Schedule{}, err
+	}
+	now := time.Now()
+	sch, err := newSchedule(Schedule{
+		ID:           id,
+		ScheduleSpec: spec,
+		Principal:    CallerFromContext(ctx),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	if err != nil {
+		return Schedule{}, err
+	}
+	sch.NextRun = sch.next(now)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.schedules[id] = sch
+	if err = s.save(); err != nil {
+		delete(s.schedules, id)
+		return Schedule{}, err
+	}
+	s.notify()
+	return sch.snapshot(), nil
+}
+
+func (s *Scheduler) list() []Schedule {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.sorted()
+}
+
+func (s *Scheduler) get(id string) (Schedule, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sch, ok := s.schedules[id]
+	if !ok {
+		return Schedule{}, ErrScheduleNotFound
+	}
+	return sch.snapshot(), nil
+}
+
+// update replaces the spec of the schedule id, the runs in progress go on.
+func (s *Scheduler) update(ctx context.Context, id string, spec ScheduleSpec) (Schedule, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	old, ok := s.schedules[id]
+	if !ok {
+		return Schedule{}, ErrScheduleNotFound
+	}
+	now := time.Now()
+	sch, err := newSchedule(Schedule{
+		ID:           id,
+		ScheduleSpec: spec,
+		Principal:    CallerFromContext(ctx),
+		CreatedAt:    old.CreatedAt,
+		UpdatedAt:    now,
+		LastRun:      old.LastRun,
+		Skipped:      old.Skipped,
+	})
+	if err != nil {
+		return Schedule{}, err
+	}
+	sch.NextRun = sch.next(now)
+	sch.cancel = old.cancel
+
+	s.schedules[id] = sch
+	if err = s.save(); err != nil {
+		s.schedules[id] = old
+		return Schedule{}, err
+	}
+	s.notify()
+	return sch.snapshot(), nil
+}
+
+// delete removes the schedule id, the runs in progress go on.
+func (s *Scheduler) delete(id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sch, ok := s.schedules[id]
+	if !ok {
+		return ErrScheduleNotFound
+	}
+	delete(s.schedules, id)
+	if err := s.save(); err != nil {
+		s.schedules[id] = sch
+		return err
+	}
+	return nil
+}
+
+// notify wakes Run up, unless it is already going to.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// sorted returns the schedules in the order they were created. The caller
+// must hold the mutex.
+func (s *Scheduler) sorted() []Schedule {
+	res := make([]Schedule, 0, len(s.schedules))
+	for _, sch := range s.schedules {
+		res = append(res, sch.snapshot())
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
+
+// save rewrites the file with the schedules, NextRun and Running are
+// recomputed when it is loaded. The caller must hold the mutex.
+func (s *Scheduler) save() error {
+	return writeJSONFile(s.path, s.sorted())
+}
+
+// writeJSONFile replaces the file at path with v encoded in JSON, that only
+// the service can read. The file is written aside and renamed, so that it is
+// never left half written.
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if err == nil {
+		err = f.Sync()
+	}
+	f.Close()
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+//go:build linux && (amd64 || arm64)
+
+package service
+
+import (
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// The values of linux/seccomp.h and linux/audit.h that x/sys/unix lacks.
+const (
+	seccompRetKillProcess = 0x80000000
+	seccompRetErrno       = 0x00050000
+	seccompRetAllow       = 0x7fff0000
+
+	auditArchX86_64  = 0xc000003e
+	auditArchAARCH64 = 0xc00000b7
+
+	// x32SyscallBit is set in the number of the system calls of the x32 ABI,
+	// that have the x86_64 architecture.
+	x32SyscallBit = 0x40000000
+
+	// The offsets in struct seccomp_data of the syscall number, of the
+	// architecture and of the low word of the first argument, on a
+	// little-endian system.
+	seccompDataNr   = 0
+	seccompDataArch = 4
+	seccompDataArg0 = 16
+)
+
+// deniedSyscalls are the system calls a sandboxed command gets EPERM for:
+// they administer the system, escape or create namespaces, inspect other
+// processes, or reach kernel interfaces the namespaces do not isolate.
+var deniedSyscalls = []uint32{
+	unix.SYS_MOUNT, unix.SYS_UMOUNT2, unix.SYS_PIVOT_ROOT, unix.SYS_CHROOT,
+	unix.SYS_FSOPEN, unix.SYS_FSCONFIG, unix.SYS_FSMOUNT, unix.SYS_FSPICK,
+	unix.SYS_MOVE_MOUNT, unix.SYS_OPEN_TREE, unix.SYS_MOUNT_SETATTR,
+	unix.SYS_UNSHARE, unix.SYS_SETNS,
+	unix.SYS_PTRACE, unix.SYS_PROCESS_VM_READV, unix.SYS_PROCESS_VM_WRITEV,
+	unix.SYS_KEXEC_LOAD, unix.SYS_KEXEC_FILE_LOAD, unix.SYS_REBOOT,
+	unix.SYS_INIT_MODULE, unix.SYS_FINIT_MODULE, unix.SYS_DELETE_MODULE,
+	unix.SYS_SWAPON, unix.SYS_SWAPOFF, unix.SYS_ACCT, unix.SYS_QUOTACTL,
+	unix.SYS_BPF, unix.SYS_PERF_EVENT_OPEN, unix.SYS_USERFAULTFD,
+	unix.SYS_KEYCTL, unix.SYS_ADD_KEY, unix.SYS_REQUEST_KEY,
+	unix.SYS_OPEN_BY_HANDLE_AT, unix.SYS_NAME_TO_HANDLE_AT,
+	unix.SYS_SYSLOG, unix.SYS_SETTIMEOFDAY, unix.SYS_CLOCK_SETTIME,
+	unix.SYS_CLOCK_ADJTIME, unix.SYS_ADJTIMEX,
+	unix.SYS_SETHOSTNAME, unix.SYS_SETDOMAINNAME,
+	unix.SYS_IO_URING_SETUP, unix.SYS_IO_URING_ENTER, unix.SYS_IO_URING_REGISTER,
+}
+
+// cloneNamespaces are the flags of clone that create namespaces, that a
+// sandboxed command gets EPERM for.
+const cloneNamespaces = unix.CLONE_NEWNS | unix.CLONE_NEWUTS | unix.CLONE_NEWIPC |
+	unix.CLONE_NEWUSER | unix.CLONE_NEWPID | unix.CLONE_NEWNET | unix.CLONE_NEWCGROUP
+
+// seccompFilter returns the BPF program of the seccomp filter of the sandbox.
+func seccompFilter() ([]unix.SockFilter, error) {
+	arch := uint32(auditArchX86_64)
+	if runtime.GOARCH == "arm64" {
+		arch = auditArchAARCH64
+	}
+	stmt := func(code uint16, k uint32) unix.SockFilter {
+		return unix.SockFilter{Code: code, K: k}
+	}
+	jump := func(code uint16, k uint32, jt, jf uint8) unix.SockFilter {
+		return unix.SockFilter{Code: code, Jt: jt, Jf: jf, K: k}
+	}
+	n := uint8(len(deniedSyscalls))
+
+	// The jumps are relative to the next instruction. The program ends with
+	// the deny list, then allow, EPERM and ENOSYS.
+	f := []unix.SockFilter{
+		// A system call of another architecture, e.g. of i386 through int
+		// 0x80 on x86_64, kills the process.
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataArch),
+		jump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, arch, 1, 0),
+		stmt(unix.BPF_RET|unix.BPF_K, seccompRetKillProcess),
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataNr),
+	}
+	if runtime.GOARCH == "amd64" {
+		// The system calls of x32 have the architecture of x86_64 and other
+		// numbers, that the deny list would let through: they kill the
+		// process too.
+		f = append(f,
+			jump(unix.BPF_JMP|unix.BPF_JGE|unix.BPF_K, x32SyscallBit, 0, 1),
+			stmt(unix.BPF_RET|unix.BPF_K, seccompRetKillProcess),
+		)
+	}
+	f = append(f,
+		// The flags of clone3 are behind a pointer the filter cannot read:
+		// it is unsupported, so that the C libraries fall back to clone.
+		jump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, unix.SYS_CLONE3, n+6, 0),
+		jump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, unix.SYS_CLONE, 0, 3),
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataArg0),
+		jump(unix.BPF_JMP|unix.BPF_JSET|unix.BPF_K, cloneNamespaces, n+2, 0),
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataNr),
+	)
+	for i, nr := range deniedSyscalls {
+		f = append(f, jump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, nr, n-uint8(i), 0))
+	}
+	f = append(f,
+		stmt(unix.BPF_RET|unix.BPF_K, seccompRetAllow),
+		stmt(unix.BPF_RET|unix.BPF_K, seccompRetErrno|uint32(unix.EPERM)),
+		stmt(unix.BPF_RET|unix.BPF_K, seccompRetErrno|uint32(unix.ENOSYS)),
+	)
+	return f, nil
+}
+
+// installSeccomp installs filter on the process, that it keeps across exec.
+// The process cannot gain privileges anymore, e.g. by executing setuid
+// programs, which lets it install the filter without CAP_SYS_ADMIN.
+func installSeccomp(filter []unix.SockFilter) error {
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return err
+	}
+	prog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+	return unix.Prctl(unix.PR_SET_SECCOMP, unix.SECCOMP_MODE_FILTER, uintptr(unsafe.Pointer(&prog)), 0, 0)
+}
@@ -0,0 +1,96 @@
+//go:build linux && (amd64 || arm64)
+
+package service
+
+import (
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// runFilter runs the BPF program filter on the seccomp_data of the system
+// call nr of arch with its first argument arg0, and returns what it returns.
+func runFilter(t *testing.T, filter []unix.SockFilter, arch, nr uint32, arg0 uint64) uint32 {
+	t.Helper()
+	data := make([]byte, 64)
+	binary.LittleEndian.PutUint32(data[seccompDataNr:], nr)
+	binary.LittleEndian.PutUint32(data[seccompDataArch:], arch)
+	binary.LittleEndian.PutUint64(data[seccompDataArg0:], arg0)
+
+	var a uint32
+	for pc := 0; pc < len(filter); pc++ {
+		ins := filter[pc]
+		switch ins.Code {
+		case unix.BPF_LD | unix.BPF_W | unix.BPF_ABS:
+			a = binary.LittleEndian.Uint32(data[ins.K:])
+		case unix.BPF_RET | unix.BPF_K:
+			return ins.K
+		case unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, unix.BPF_JMP | unix.BPF_JGE | unix.BPF_K, unix.BPF_JMP | unix.BPF_JSET | unix.BPF_K:
+			var cond bool
+			switch ins.Code &^ (unix.BPF_JMP | unix.BPF_K) {
+			case unix.BPF_JEQ:
+				cond = a == ins.K
+			case unix.BPF_JGE:
+				cond = a >= ins.K
+			default:
+				cond = a&ins.K != 0
+			}
+			if cond {
+				pc += int(ins.Jt)
+			} else {
+				pc += int(ins.Jf)
+			}
+		default:
+			t.Fatalf("instruction %d: unknown code %#x", pc, ins.Code)
+		}
+	}
+	t.Fatal("the program does not return")
+	return 0
+}
+
+// seccompCall is a system call and what the filter returns for it.
+type seccompCall struct {
+	name string
+	arch uint32
+	nr   uint32
+	arg0 uint64
+	want uint32
+}
+
+func TestSeccompFilter(t *testing.T) {
+	filter, err := seccompFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	arch := uint32(auditArchX86_64)
+	if runtime.GOARCH == "arm64" {
+		arch = auditArchAARCH64
+	}
+	eperm := uint32(seccompRetErrno | unix.EPERM)
+	tests := []seccompCall{
+		{name: "allowed", arch: arch, nr: unix.SYS_READ, want: seccompRetAllow},
+		{name: "another architecture", arch: 0x40000003, nr: unix.SYS_READ, want: seccompRetKillProcess},
+		{name: "clone", arch: arch, nr: unix.SYS_CLONE, arg0: uint64(unix.SIGCHLD) | unix.CLONE_VM, want: seccompRetAllow},
+		{name: "clone of a namespace", arch: arch, nr: unix.SYS_CLONE, arg0: uint64(unix.SIGCHLD) | unix.CLONE_NEWUSER, want: eperm},
+		{name: "clone3", arch: arch, nr: unix.SYS_CLONE3, want: seccompRetErrno | uint32(unix.ENOSYS)},
+	}
+	for _, nr := range deniedSyscalls {
+		tests = append(tests, seccompCall{name: fmt.Sprintf("denied %d", nr), arch: arch, nr: nr, want: eperm})
+	}
+	if runtime.GOARCH == "amd64" {
+		tests = append(tests, []seccompCall{
+			{name: "x32 mount", arch: arch, nr: x32SyscallBit | unix.SYS_MOUNT, want: seccompRetKillProcess},
+			{name: "x32 read", arch: arch, nr: x32SyscallBit | unix.SYS_READ, want: seccompRetKillProcess},
+		}...)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runFilter(t, filter, tt.arch, tt.nr, tt.arg0); got != tt.want {
+				t.Errorf("filter returns %#x, want %#x", got, tt.want)
+			}
+		})
+	}
+}
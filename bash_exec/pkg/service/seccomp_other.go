@@ -0,0 +1,19 @@
+//go:build linux && !amd64 && !arm64
+
+package service
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+// seccompFilter fails, the filter of the sandbox only knows the system calls
+// of amd64 and arm64.
+func seccompFilter() ([]unix.SockFilter, error) {
+	return nil, errors.New("the sandbox is only supported on amd64 and arm64")
+}
+
+func installSeccomp(filter []unix.SockFilter) error {
+	return errors.New("the sandbox is only supported on amd64 and arm64")
+}
@@ -1,65 +1,487 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
 	"os/exec"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
 )
 
 // BashExecService describes the service.
 type BashExecService interface {
 	// Add your methods here
-	ExecCmd(ctx context.Context, cmd string) (stdOut string, stdErr string, exitCode int, err error)
+	ExecCmd(ctx context.Context, cmd string, opts ExecOptions) (res ExecResult, err error)
+	SubmitJob(ctx context.Context, cmd string, opts ExecOptions) (id string, err error)
+	GetJob(ctx context.Context, id string) (job Job, err error)
+	CancelJob(ctx context.Context, id string) (err error)
+	ExecBatch(ctx context.Context, steps []BatchStep) (res BatchResult, err error)
+	CreateSchedule(ctx context.Context, spec ScheduleSpec) (schedule Schedule, err error)
+	ListSchedules(ctx context.Context) (schedules []Schedule, err error)
+	GetSchedule(ctx context.Context, id string) (schedule Schedule, err error)
+	UpdateSchedule(ctx context.Context, id string, spec ScheduleSpec) (schedule Schedule, err error)
+	DeleteSchedule(ctx context.Context, id string) (err error)
+	CreateTemplate(ctx context.Context, name string, spec TemplateSpec) (tmpl CommandTemplate, err error)
+	ListTemplates(ctx context.Context) (tmpls []CommandTemplate, err error)
+	GetTemplate(ctx context.Context, name string) (tmpl CommandTemplate, err error)
+	UpdateTemplate(ctx context.Context, name string, spec TemplateSpec) (tmpl CommandTemplate, err error)
+	DeleteTemplate(ctx context.Context, name string) (err error)
+	ExecTemplate(ctx context.Context, name string, params map[string]string) (res ExecResult, err error)
+}
+
+// ExecOptions collects the optional settings of a single execution.
+type ExecOptions struct {
+	// Mode selects how cmd is turned into a process, ExecModeArgv when empty.
+	Mode ExecMode `json:"mode,omitempty"`
+	// Timeout bounds the execution time, Config.MaxTimeout is used when zero.
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// Cwd is the working directory of the command, one of Config.AllowedDirs,
+	// the one of the service when empty.
+	Cwd string `json:"cwd,omitempty"`
+	// Env sets variables, allowed by Config.AllowedEnv, on top of the
+	// environment of the service, or of an empty one when CleanEnv is set.
+	Env      map[string]string `json:"env,omitempty"`
+	CleanEnv bool              `json:"clean_env,omitempty"`
+	// Stdin is the standard input of the command, empty when nil.
+	Stdin []byte `json:"stdin,omitempty"`
+	// Limits lower the ones of Config.Limits, they cannot raise them.
+	Limits Limits `json:"limits,omitempty"`
+	// Output, when set, receives the output of the command while it runs.
+	Output OutputFunc `json:"-"`
+	// BatchID and BatchStep link the execution to a step of a batch in the
+	// history, they are set by ExecBatch.
+	BatchID   string `json:"-"`
+	BatchStep string `json:"-"`
+	// ScheduleID links the execution to its schedule in the history, it is
+	// set by the Scheduler.
+	ScheduleID string `json:"-"`
+	// TemplateName and TemplateParams link the execution to its command
+	// template in the history, they are set by ExecTemplate.
+	TemplateName   string            `json:"-"`
+	TemplateParams map[string]string `json:"-"`
+}
+
+// ExecResult collects the outcome of an execution.
+type ExecResult struct {
+	StdOut   string `json:"std_out"`
+	StdErr   string `json:"std_err"`
+	ExitCode int    `json:"exit_code"`
+	// LimitExceeded is the limit that stopped the command, one of LimitCPUTime,
+	// LimitMemory, LimitProcesses or LimitOutput, empty when none did.
+	LimitExceeded string `json:"limit_exceeded,omitempty"`
+	// HistoryID is the ID of the execution in the history kept by store_cmds,
+	// empty when it has not been stored.
+	HistoryID string `json:"history_id,omitempty"`
+
+	OutputStats
+	ExecStats
+}
+
+// ExecStats describes the process of an execution and where it ran. The
+// process fields are left to zero when the command could not be started.
+type ExecStats struct {
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Duration   time.Duration `json:"duration_ns"`
+	// UserTime and SystemTime are the CPU time of the process and of the
+	// children it waited for.
+	UserTime   time.Duration `json:"user_time_ns"`
+	SystemTime time.Duration `json:"system_time_ns"`
+	// PeakRSS is the maximum resident set size of the process, in bytes.
+	PeakRSS    int64  `json:"peak_rss_bytes"`
+	Host       string `json:"host,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
 }
 
-type basicBashExecService struct{}
+// Names of the output streams passed to an OutputFunc.
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+// OutputFunc receives a chunk of the output written by a command on stream,
+// StreamStdout or StreamStderr. Calls are never concurrent and data must not
+// be retained after the call returns.
+type OutputFunc func(stream string, data []byte)
+
+// Config collects the server-side settings of basicBashExecService.
+type Config struct {
+	// MaxTimeout is the upper bound for the execution time of every command, 0 for no limit.
+	MaxTimeout time.Duration
+	// MaxOutput is the size of the output kept on each stream of a command,
+	// its head and its tail beyond, 0 to keep all of it. Unlike Limits.Output,
+	// it does not stop the command.
+	MaxOutput int
+	// JobWorkers is the number of asynchronous jobs that run at the same time.
+	JobWorkers int
+	// JobQueueSize is the number of jobs that can wait for a worker, beyond which SubmitJob fails.
+	JobQueueSize int
+	// JobRetention is how long a finished job can be retrieved, 0 to keep them forever.
+	JobRetention time.Duration
+	// MaxBatchSteps is the number of commands a batch can run, 0 for no limit.
+	MaxBatchSteps int
+	// Host and InstanceID identify this instance in the ExecStats of every execution.
+	Host       string
+	InstanceID string
+	// AllowedDirs are the working directories a request can set, with their
+	// subdirectories. A request cannot set its working directory when empty.
+	AllowedDirs []string
+	// AllowedEnv are the environment variables a request can set, by name or
+	// by prefix followed by *, e.g. LC_*. A request cannot set any when empty.
+	AllowedEnv []string
+	// Limits are the limits of every command, that requests can lower.
+	Limits Limits
+	// Executor starts the commands, the host executor without cgroup when nil.
+	Executor Executor
+	// Scheduler runs the schedules, the schedule methods fail with
+	// ErrSchedulerDisabled when nil.
+	Scheduler *Scheduler
+	// Templates keeps the command templates, the template methods fail with
+	// ErrTemplatesDisabled when nil.
+	Templates *TemplateRegistry
+}
+
+type basicBashExecService struct {
+	cfg  Config
+	jobs *jobManager
+	// svc is the whole middleware chain, the steps of the batches and the
+	// executions of the templates go through it.
+	svc BashExecService
+	// allowedDirs are Config.AllowedDirs, absolute and resolved.
+	allowedDirs []string
+}
 
-// NewBasicBashExecService returns a naive, stateless implementation of BashExecService.
-func NewBasicBashExecService() BashExecService {
-	return &basicBashExecService{}
+// NewBasicBashExecService returns a naive implementation of BashExecService.
+func NewBasicBashExecService(cfg Config) BashExecService {
+	return newBasicBashExecService(cfg)
+}
+
+func newBasicBashExecService(cfg Config) *basicBashExecService {
+	if cfg.Executor == nil {
+		cfg.Executor = NewHostExecutor("")
+	}
+	b := &basicBashExecService{
+		cfg:         cfg,
+		jobs:        newJobManager(cfg.JobWorkers, cfg.JobQueueSize, cfg.JobRetention, cfg.MaxOutput),
+		allowedDirs: resolveDirs(cfg.AllowedDirs),
+	}
+	b.jobs.svc, b.svc = b, b
+	if cfg.Scheduler != nil {
+		cfg.Scheduler.svc = b
+	}
+	return b
 }
 
 // New returns a BashExecService with all of the expected middleware wired in.
-func New(middleware []Middleware) BashExecService {
-	var svc BashExecService = NewBasicBashExecService()
+func New(cfg Config, middleware []Middleware) BashExecService {
+	basic := newBasicBashExecService(cfg)
+	var svc BashExecService = basic
 	for _, m := range middleware {
 		svc = m(svc)
 	}
+	// Jobs, batch steps, scheduled runs and template executions go through
+	// the whole middleware chain, like synchronous executions.
+	basic.jobs.svc, basic.svc = svc, svc
+	if cfg.Scheduler != nil {
+		cfg.Scheduler.svc = svc
+	}
 	return svc
 }
 
-var ErrInvalidCommand = errors.New("invalid command")
+var (
+	ErrInvalidCommand = errs.New(http.StatusUnprocessableEntity, "invalid_command", "invalid command")
+	ErrInvalidMode    = errs.New(http.StatusBadRequest, "invalid_mode", "invalid execution mode")
+	ErrInvalidTimeout = errs.New(http.StatusBadRequest, "invalid_timeout", "invalid timeout")
+	// ErrTimeout is reported with 504, the command is what did not answer in
+	// time, while 408 is left to errs.ErrDeadlineExceeded, the deadline of
+	// the request itself. Its details hold the output captured before the
+	// command was killed.
+	ErrTimeout = errs.New(http.StatusGatewayTimeout, "timeout", "command timed out")
+	// ErrSpawn is returned when the process of a command cannot be started.
+	// ErrCommandNotFound and ErrPermissionDenied give the reason, when known.
+	ErrSpawn            = errs.New(http.StatusUnprocessableEntity, "spawn_failed", "cannot start command")
+	ErrCommandNotFound  = ErrSpawn.Extend("command_not_found", "command not found")
+	ErrPermissionDenied = ErrSpawn.Extend("permission_denied", "permission denied")
+)
 
-func (b *basicBashExecService) ExecCmd(ctx context.Context, cmd string) (stdOut string, stdErr string, exitCode int, err error) {
-	stdOut = ""
-	stdErr = ""
-	exitCode = -999
+// killGracePeriod is how long a process group has to exit after SIGTERM before it is sent SIGKILL.
+const killGracePeriod = 2 * time.Second
 
-	if len(cmd) < 3 {
+func (b *basicBashExecService) ExecCmd(ctx context.Context, cmd string, opts ExecOptions) (res ExecResult, err error) {
+	res.ExitCode = -999
+	res.Host, res.InstanceID = b.cfg.Host, b.cfg.InstanceID
+
+	// Trim the command string to remove spaces at the beginning and ending
+	cmd = strings.TrimSpace(cmd)
+	if cmd == "" {
 		err = ErrInvalidCommand
 		return
 	}
 
-	// Trim the command string to remove spaces at the beginning and ending
-	cmd = strings.TrimSpace(cmd)
+	// Take the first word as the command, and the others as args
+	argv, err := buildArgv(cmd, opts.Mode)
+	if err != nil {
+		return
+	}
+	if len(argv) == 0 {
+		err = ErrInvalidCommand
+		return
+	}
+
+	if opts.Timeout < 0 {
+		err = ErrInvalidTimeout
+		return
+	}
+	timeout := opts.Timeout
+	if timeout == 0 || (b.cfg.MaxTimeout > 0 && timeout > b.cfg.MaxTimeout) {
+		timeout = b.cfg.MaxTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if err = opts.Limits.validate(); err != nil {
+		return
+	}
+	limits := b.cfg.Limits.lower(opts.Limits)
+
+	stdoutbb, stderrbb := newCapture(b.cfg.MaxOutput), newCapture(b.cfg.MaxOutput)
 
-	// Split command by spaces in order to take the first token as the command, and the others as args
-	splittedCommand := strings.Split(cmd, " ")
+	c := exec.Command(argv[0], argv[1:]...)
+	var stdout, stderr io.Writer = stdoutbb, stderrbb
+	if opts.Output != nil {
+		var mtx sync.Mutex
+		stdout = io.MultiWriter(stdoutbb, &outputWriter{&mtx, StreamStdout, opts.Output})
+		stderr = io.MultiWriter(stderrbb, &outputWriter{&mtx, StreamStderr, opts.Output})
+	}
+	// The command is stopped like on a timeout when its output is too large.
+	var output *outputLimit
+	if limits.Output > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		output = &outputLimit{max: limits.Output, exceeded: cancel}
+		stdout, stderr = output.writer(stdout), output.writer(stderr)
+	}
+	c.Stdout, c.Stderr = stdout, stderr
+	if err = b.setEnvironment(c, opts); err != nil {
+		return
+	}
+	setProcessGroup(c)
 
-	var stdoutbb, stderrbb bytes.Buffer
+	res.StartedAt = time.Now()
+	exceeded, err := b.cfg.Executor.Start(ctx, c, limits)
+	if err != nil {
+		res.StartedAt = time.Time{}
+		return
+	}
+	err = waitOrKill(ctx, c)
+	res.FinishedAt = time.Now()
+	res.Duration = res.FinishedAt.Sub(res.StartedAt)
+	res.UserTime = c.ProcessState.UserTime()
+	res.SystemTime = c.ProcessState.SystemTime()
+	res.PeakRSS = peakRSS(c.ProcessState)
 
-	c := exec.Command(splittedCommand[0], splittedCommand[1:]...)
-	c.Stdout = &stdoutbb
-	c.Stderr = &stderrbb
+	res.ExitCode = c.ProcessState.ExitCode()
+	res.StdErr = stderrbb.String()
+	res.StdOut = stdoutbb.String()
+	res.OutputStats = newOutputStats(stdoutbb, stderrbb)
 
-	err = c.Run()
+	// A command stopped by a limit ran, like one that failed.
+	res.LimitExceeded = exceeded(c.ProcessState)
+	if output != nil && output.Exceeded() {
+		res.LimitExceeded, err = LimitOutput, nil
+	}
 
-	exitCode = c.ProcessState.ExitCode()
-	stdErr = stderrbb.String()
-	stdOut = stdoutbb.String()
+	// A command that ran is a success whatever its exit status, that is
+	// reported by ExitCode.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		err = nil
+	}
+	// The caller of a command that timed out gets what it wrote until then.
+	if errors.Is(err, ErrTimeout) {
+		err = errs.WithDetails(err, map[string]string{
+			"stdout":      res.StdOut,
+			"stderr":      res.StdErr,
+			"duration_ns": strconv.FormatInt(int64(res.Duration), 10),
+		})
+	}
 
 	// Return the output
-	return stdOut, stdErr, exitCode, err
+	return res, err
+}
+
+// spawnError returns the error of a process that could not be started, of
+// the kind of its reason.
+func spawnError(err error) error {
+	kind := ErrSpawn
+	switch {
+	case errors.Is(err, exec.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+		kind = ErrCommandNotFound
+	case errors.Is(err, fs.ErrPermission):
+		kind = ErrPermissionDenied
+	}
+	return fmt.Errorf("%w: %v", kind, err)
+}
+
+// waitOrKill waits for c to exit. If ctx is done first, the whole process group
+// of c is sent SIGTERM and, after killGracePeriod, SIGKILL. The returned error is
+// ErrTimeout when the deadline of ctx expired, and ctx.Err() when it was cancelled.
+func waitOrKill(ctx context.Context, c *exec.Cmd) error {
+	done := make(chan error, 1)
+	go func() { done <- c.Wait() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	signalProcessGroup(c.Process, syscall.SIGTERM)
+	select {
+	case <-done:
+	case <-time.After(killGracePeriod):
+		signalProcessGroup(c.Process, syscall.SIGKILL)
+		<-done
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+	return ctx.Err()
+}
+
+// outputWriter forwards the writes on one stream to an OutputFunc, the mutex
+// is shared between the streams of a command to serialize the calls.
+type outputWriter struct {
+	mtx    *sync.Mutex
+	stream string
+	fn     OutputFunc
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.fn(w.stream, p)
+	return len(p), nil
+}
+
+func (b *basicBashExecService) SubmitJob(ctx context.Context, cmd string, opts ExecOptions) (id string, err error) {
+	return b.jobs.submit(ctx, cmd, opts)
+}
+
+func (b *basicBashExecService) GetJob(ctx context.Context, id string) (job Job, err error) {
+	return b.jobs.get(id)
+}
+
+func (b *basicBashExecService) CancelJob(ctx context.Context, id string) (err error) {
+	return b.jobs.cancel(id)
+}
+
+func (b *basicBashExecService) CreateSchedule(ctx context.Context, spec ScheduleSpec) (schedule Schedule, err error) {
+	if b.cfg.Scheduler == nil {
+		return Schedule{}, ErrSchedulerDisabled
+	}
+	return b.cfg.Scheduler.create(ctx, spec)
+}
+
+func (b *basicBashExecService) ListSchedules(ctx context.Context) (schedules []Schedule, err error) {
+	if b.cfg.Scheduler == nil {
+		return nil, ErrSchedulerDisabled
+	}
+	return b.cfg.Scheduler.list(), nil
+}
+
+func (b *basicBashExecService) GetSchedule(ctx context.Context, id string) (schedule Schedule, err error) {
+	if b.cfg.Scheduler == nil {
+		return Schedule{}, ErrSchedulerDisabled
+	}
+	return b.cfg.Scheduler.get(id)
+}
+
+func (b *basicBashExecService) UpdateSchedule(ctx context.Context, id string, spec ScheduleSpec) (schedule Schedule, err error) {
+	if b.cfg.Scheduler == nil {
+		return Schedule{}, ErrSchedulerDisabled
+	}
+	return b.cfg.Scheduler.update(ctx, id, spec)
+}
+
+func (b *basicBashExecService) DeleteSchedule(ctx context.Context, id string) (err error) {
+	if b.cfg.Scheduler == nil {
+		return ErrSchedulerDisabled
+	}
+	return b.cfg.Scheduler.delete(id)
+}
+
+func (b *basicBashExecService) CreateTemplate(ctx context.Context, name string, spec TemplateSpec) (tmpl CommandTemplate, err error) {
+	if b.cfg.Templates == nil {
+		return CommandTemplate{}, ErrTemplatesDisabled
+	}
+	return b.cfg.Templates.create(ctx, name, spec)
+}
+
+func (b *basicBashExecService) ListTemplates(ctx context.Context) (tmpls []CommandTemplate, err error) {
+	if b.cfg.Templates == nil {
+		return nil, ErrTemplatesDisabled
+	}
+	return b.cfg.Templates.list(), nil
+}
+
+func (b *basicBashExecService) GetTemplate(ctx context.Context, name string) (tmpl CommandTemplate, err error) {
+	if b.cfg.Templates == nil {
+		return CommandTemplate{}, ErrTemplatesDisabled
+	}
+	t, err := b.cfg.Templates.get(name)
+	if err != nil {
+		return CommandTemplate{}, err
+	}
+	return t.CommandTemplate, nil
+}
+
+func (b *basicBashExecService) UpdateTemplate(ctx context.Context, name string, spec TemplateSpec) (tmpl CommandTemplate, err error) {
+	if b.cfg.Templates == nil {
+		return CommandTemplate{}, ErrTemplatesDisabled
+	}
+	return b.cfg.Templates.update(ctx, name, spec)
+}
+
+func (b *basicBashExecService) DeleteTemplate(ctx context.Context, name string) (err error) {
+	if b.cfg.Templates == nil {
+		return ErrTemplatesDisabled
+	}
+	return b.cfg.Templates.delete(name)
+}
+
+// ExecTemplate renders the template name with params and executes it through
+// the whole middleware chain, like a synchronous execution, in argv mode with
+// the options of the template.
+func (b *basicBashExecService) ExecTemplate(ctx context.Context, name string, params map[string]string) (res ExecResult, err error) {
+	res.ExitCode = -999
+	if b.cfg.Templates == nil {
+		return res, ErrTemplatesDisabled
+	}
+	t, err := b.cfg.Templates.get(name)
+	if err != nil {
+		return res, err
+	}
+	cmd, values, err := t.render(params)
+	if err != nil {
+		return res, err
+	}
+	opts := t.Options
+	opts.Mode = ExecModeArgv
+	opts.TemplateName, opts.TemplateParams = name, values
+	return b.svc.ExecCmd(ctx, cmd, opts)
 }
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExecMode selects how the command string of an execution is turned into a process.
+type ExecMode string
+
+const (
+	// ExecModeArgv splits the command with POSIX quoting rules and executes the
+	// resulting argv directly, without any shell involved. It is the default.
+	ExecModeArgv ExecMode = "argv"
+	// ExecModeShell hands the whole command string to "/bin/bash -c", so pipes,
+	// redirections, globs and && are interpreted by bash.
+	ExecModeShell ExecMode = "shell"
+)
+
+// shellPath is the interpreter used by ExecModeShell.
+const shellPath = "/bin/bash"
+
+// ParseExecMode returns the ExecMode named by s. The empty string maps to ExecModeArgv.
+func ParseExecMode(s string) (ExecMode, error) {
+	switch ExecMode(strings.ToLower(strings.TrimSpace(s))) {
+	case "", ExecModeArgv:
+		return ExecModeArgv, nil
+	case ExecModeShell:
+		return ExecModeShell, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidMode, s)
+}
+
+// quoteArg quotes s in single quotes, so that SplitArgs and bash read it as a
+// single word whatever it holds.
+func quoteArg(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// SplitArgs splits cmd into words following the POSIX shell quoting rules:
+// words are separated by unquoted blanks, single quotes preserve everything
+// literally, double quotes preserve everything but \", \\, \$ and \`, and an
+// unquoted backslash escapes the next character. No expansion of any kind is
+// performed, so characters such as |, > or && end up as plain arguments.
+func SplitArgs(cmd string) ([]string, error) {
+	var (
+		args    []string
+		word    strings.Builder
+		inWord  bool
+		escaped bool
+		quote   rune
+	)
+
+	for _, r := range cmd {
+		switch {
+		case escaped:
+			// Inside double quotes a backslash only escapes a few characters.
+			if quote == '"' && !strings.ContainsRune("\"\\$`\n", r) {
+				word.WriteRune('\\')
+			}
+			// An escaped newline is a line continuation and produces nothing,
+			// not even an empty word.
+			if r != '\n' {
+				word.WriteRune(r)
+				inWord = true
+			}
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				args = append(args, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("%w: trailing backslash", ErrInvalidCommand)
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("%w: unterminated %c quote", ErrInvalidCommand, quote)
+	}
+	if inWord {
+		args = append(args, word.String())
+	}
+	return args, nil
+}
+
+// buildArgv returns the argv to execute for cmd in the given mode.
+func buildArgv(cmd string, mode ExecMode) ([]string, error) {
+	switch mode {
+	case "", ExecModeArgv:
+		return SplitArgs(cmd)
+	case ExecModeShell:
+		return []string{shellPath, "-c", cmd}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrInvalidMode, mode)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  string
+		want []string
+		err  error
+	}{
+		{name: "empty", cmd: "", want: nil},
+		{name: "blanks only", cmd: " \t\n ", want: nil},
+		{name: "words", cmd: "ls -l  /tmp", want: []string{"ls", "-l", "/tmp"}},
+		{name: "tabs and newlines", cmd: "echo\ta\nb", want: []string{"echo", "a", "b"}},
+		{name: "single quotes", cmd: `echo 'a  b' '$HOME' '\n'`, want: []string{"echo", "a  b", "$HOME", `\n`}},
+		{name: "double quotes", cmd: `echo "a  b" "it's"`, want: []string{"echo", "a  b", "it's"}},
+		{name: "escapes in double quotes", cmd: `echo "\" \\ \$ \` + "`" + ` \a"`, want: []string{"echo", "\" \\ $ ` \\a"}},
+		{name: "unquoted backslash", cmd: `echo a\ b \'c\'`, want: []string{"echo", "a b", "'c'"}},
+		{name: "line continuation", cmd: "echo a\\\nb", want: []string{"echo", "ab"}},
+		{name: "line continuation between words", cmd: "echo \\\n b", want: []string{"echo", "b"}},
+		{name: "trailing line continuation", cmd: "echo a \\\n", want: []string{"echo", "a"}},
+		{name: "line continuation in double quotes", cmd: "echo \"a\\\nb\"", want: []string{"echo", "ab"}},
+		{name: "escaped blank", cmd: `echo \  b`, want: []string{"echo", " ", "b"}},
+		{name: "empty quoted words", cmd: `echo '' ""`, want: []string{"echo", "", ""}},
+		{name: "adjacent quoting", cmd: `echo a'b'"c"d`, want: []string{"echo", "abcd"}},
+		{name: "shell operators are plain words", cmd: "cat /etc/passwd | grep root && rm -rf / > out; $(id)", want: []string{"cat", "/etc/passwd", "|", "grep", "root", "&&", "rm", "-rf", "/", ">", "out;", "$(id)"}},
+		{name: "quoted argument round trip", cmd: "echo " + quoteArg(`it's "a" $x \ `), want: []string{"echo", `it's "a" $x \ `}},
+		{name: "unterminated single quote", cmd: "echo 'a", err: ErrInvalidCommand},
+		{name: "unterminated double quote", cmd: `echo "a`, err: ErrInvalidCommand},
+		{name: "trailing backslash", cmd: `echo a\`, err: ErrInvalidCommand},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitArgs(tt.cmd)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("SplitArgs(%q) error = %v, want %v", tt.cmd, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SplitArgs(%q) error = %v", tt.cmd, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitArgs(%q) = %q, want %q", tt.cmd, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildArgv(t *testing.T) {
+	tests := []struct {
+		mode ExecMode
+		want []string
+		err  error
+	}{
+		{mode: "", want: []string{"echo", "a|b"}},
+		{mode: ExecModeArgv, want: []string{"echo", "a|b"}},
+		{mode: ExecModeShell, want: []string{shellPath, "-c", "echo 'a|b'"}},
+		{mode: "zsh", err: ErrInvalidMode},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			got, err := buildArgv("echo 'a|b'", tt.mode)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("buildArgv error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildArgv = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+)
+
+var (
+	ErrTemplateNotFound  = errs.New(http.StatusNotFound, "template_not_found", "template not found")
+	ErrTemplateExists    = errs.New(http.StatusConflict, "template_exists", "template already exists")
+	ErrInvalidTemplate   = errs.New(http.StatusBadRequest, "invalid_template", "invalid template")
+	ErrInvalidParams     = errs.New(http.StatusBadRequest, "invalid_params", "invalid template parameters")
+	ErrTemplatesDisabled = errs.New(http.StatusNotImplemented, "templates_disabled", "command templates are disabled")
+)
+
+// ParamType is the type of the value of a template parameter.
+type ParamType string
+
+const (
+	// ParamString is any string. It is the default.
+	ParamString ParamType = "string"
+	// ParamInt is a decimal integer, rendered without sign nor leading zeros
+	// when positive.
+	ParamInt ParamType = "int"
+	// ParamBool is true or false, also given as 1, 0, t, f...
+	ParamBool ParamType = "bool"
+	// ParamPath is a non-empty file path without ".." elements.
+	ParamPath ParamType = "path"
+)
+
+var (
+	templateNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+	paramNameRe    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// placeholderRe matches the placeholders of the words of a template,
+	// the braces that do not hold a parameter name are kept as they are.
+	placeholderRe = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+)
+
+// TemplateParam is a parameter of a command template.
+type TemplateParam struct {
+	Name        string    `json:"name"`
+	Type        ParamType `json:"type,omitempty"`
+	Description string    `json:"description,omitempty"`
+	// Pattern is a regular expression the whole value must match, once
+	// normalized by its type, e.g. "[a-z]+" rejects "abc1".
+	Pattern string `json:"pattern,omitempty"`
+	// Default is the value of the parameter when it is not given, the
+	// parameter is required when nil.
+	Default *string `json:"default,omitempty"`
+}
+
+// TemplateSpec is the command of a template, and its parameters.
+type TemplateSpec struct {
+	Description string `json:"description,omitempty"`
+	// Cmd is split into words like with ExecModeArgv, then the placeholders
+	// {name} of the words are replaced by the values of the parameters, e.g.
+	// "du -sh {path}". A value stays inside the word of its placeholder,
+	// whatever it holds, so it can neither add arguments nor reach a shell.
+	Cmd    string          `json:"cmd"`
+	Params []TemplateParam `json:"params,omitempty"`
+	// Options are the options of every execution, their Mode must be
+	// ExecModeArgv.
+	Options ExecOptions `json:"options"`
+}
+
+// CommandTemplate is a named, parameterized command, that callers run by
+// giving the values of its parameters.
+type CommandTemplate struct {
+	Name string `json:"name"`
+	TemplateSpec
+	// Principal is the caller that created or last updated the template.
+	Principal string    `json:"principal,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// commandTemplate is a CommandTemplate compiled.
+type commandTemplate struct {
+	CommandTemplate
+	words    []string
+	patterns map[string]*regexp.Regexp
+}
+
+// newCommandTemplate validates t and compiles it.
+func newCommandTemplate(t CommandTemplate) (*commandTemplate, error) {
+	if !templateNameRe.MatchString(t.Name) {
+		return nil, fmt.Errorf("%w: invalid name %q", ErrInvalidTemplate, t.Name)
+	}
+	switch t.Options.Mode {
+	case "", ExecModeArgv:
+	default:
+		return nil, fmt.Errorf("%w: mode %q, templates run in argv mode", ErrInvalidTemplate, t.Options.Mode)
+	}
+	words, err := SplitArgs(t.Cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, ErrInvalidCommand
+	}
+	if t.Options.Timeout < 0 {
+		return nil, ErrInvalidTimeout
+	}
+	if err = t.Options.Limits.validate(); err != nil {
+		return nil, err
+	}
+
+	t.Params = append([]TemplateParam(nil), t.Params...)
+	ct := &commandTemplate{CommandTemplate: t, words: words, patterns: map[string]*regexp.Regexp{}}
+	// used tells, by name, whether a declared parameter has a placeholder.
+	used := map[string]bool{}
+	for i := range ct.Params {
+		p := &ct.Params[i]
+		if !paramNameRe.MatchString(p.Name) {
+			return nil, fmt.Errorf("%w: invalid parameter name %q", ErrInvalidTemplate, p.Name)
+		}
+		if _, ok := used[p.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate parameter %s", ErrInvalidTemplate, p.Name)
+		}
+		switch p.Type {
+		case "":
+			p.Type = ParamString
+		case ParamString, ParamInt, ParamBool, ParamPath:
+		default:
+			return nil, fmt.Errorf("%w: parameter %s: unknown type %q", ErrInvalidTemplate, p.Name, p.Type)
+		}
+		if p.Pattern != "" {
+			re, err := regexp.Compile(`^(?:` + p.Pattern + `)$`)
+			if err != nil {
+				return nil, fmt.Errorf("%w: parameter %s: %v", ErrInvalidTemplate, p.Name, err)
+			}
+			ct.patterns[p.Name] = re
+		}
+		if p.Default != nil {
+			if _, err = ct.value(*p, *p.Default); err != nil {
+				return nil, fmt.Errorf("%w: parameter %s: default: %v", ErrInvalidTemplate, p.Name, err)
+			}
+		}
+		used[p.Name] = false
+	}
+	for _, w := range words {
+		for _, m := range placeholderRe.FindAllStringSubmatch(w, -1) {
+			if _, ok := used[m[1]]; !ok {
+				return nil, fmt.Errorf("%w: undeclared parameter %s", ErrInvalidTemplate, m[1])
+			}
+			used[m[1]] = true
+		}
+	}
+	for _, p := range ct.Params {
+		if !used[p.Name] {
+			return nil, fmt.Errorf("%w: parameter %s is not used", ErrInvalidTemplate, p.Name)
+		}
+	}
+	return ct, nil
+}
+
+// value validates v, the value of p, and returns it normalized by its type.
+func (t *commandTemplate) value(p TemplateParam, v string) (string, error) {
+	if strings.ContainsRune(v, 0) {
+		return "", errors.New("NUL byte")
+	}
+	switch p.Type {
+	case ParamInt:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%q is not an integer", v)
+		}
+		v = strconv.FormatInt(n, 10)
+	case ParamBool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a boolean", v)
+		}
+		v = strconv.FormatBool(b)
+	case ParamPath:
+		if v == "" {
+			return "", errors.New("empty path")
+		}
+		for _, elem := range strings.Split(v, "/") {
+			if elem == ".." {
+				return "", fmt.Errorf("path %q has a .. element", v)
+			}
+		}
+	}
+	if re := t.patterns[p.Name]; re != nil && !re.MatchString(v) {
+		return "", fmt.Errorf("%q does not match %s", v, p.Pattern)
+	}
+	return v, nil
+}
+
+// render returns the command of t with the values of params, quoted so that
+// ExecModeArgv splits it into the words of the template, and the values of
+// every parameter, the defaults included. A value that starts a word cannot
+// start with "-", so that it cannot be read as an option.
+func (t *commandTemplate) render(params map[string]string) (string, map[string]string, error) {
+	values := make(map[string]string, len(t.Params))
+	for _, p := range t.Params {
+		v, ok := params[p.Name]
+		switch {
+		case !ok && p.Default == nil:
+			return "", nil, fmt.Errorf("%w: missing parameter %s", ErrInvalidParams, p.Name)
+		case !ok:
+			v = *p.Default
+		}
+		v, err := t.value(p, v)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: parameter %s: %v", ErrInvalidParams, p.Name, err)
+		}
+		values[p.Name] = v
+	}
+	for name := range params {
+		if _, ok := values[name]; !ok {
+			return "", nil, fmt.Errorf("%w: unknown parameter %s", ErrInvalidParams, name)
+		}
+	}
+
+	quoted := make([]string, len(t.words))
+	for i, w := range t.words {
+		if m := placeholderRe.FindStringSubmatchIndex(w); m != nil && m[0] == 0 {
+			if name := w[m[2]:m[3]]; strings.HasPrefix(values[name], "-") {
+				return "", nil, fmt.Errorf("%w: parameter %s: %q starts with -", ErrInvalidParams, name, values[name])
+			}
+		}
+		w = placeholderRe.ReplaceAllStringFunc(w, func(ph string) string {
+			return values[ph[1:len(ph)-1]]
+		})
+		quoted[i] = quoteArg(w)
+	}
+	return strings.Join(quoted, " "), values, nil
+}
+
+// TemplateRegistry keeps the command templates created through the service
+// in a JSON file, rewritten at every change.
+type TemplateRegistry struct {
+	path string
+
+	mtx       sync.RWMutex
+	templates map[string]*commandTemplate
+}
+
+// OpenTemplateRegistry returns the TemplateRegistry of the templates kept in
+// the file at path, that is created at the first change.
+func OpenTemplateRegistry(path string) (*TemplateRegistry, error) {
+	r := &TemplateRegistry{path: path, templates: map[string]*commandTemplate{}}
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return r, nil
+	case err != nil:
+		return nil, err
+	}
+	var list []CommandTemplate
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, t := range list {
+		ct, err := newCommandTemplate(t)
+		if err != nil {
+			return nil, fmt.Errorf("%s: template %s: %w", path, t.Name, err)
+		}
+		r.templates[t.Name] = ct
+	}
+	return r, nil
+}
+
+func (r *TemplateRegistry) create(ctx context.Context, name string, spec TemplateSpec) (CommandTemplate, error) {
+	now := time.Now()
+	t, err := newCommandTemplate(CommandTemplate{
+		Name:         name,
+		TemplateSpec: spec,
+		Principal:    CallerFromContext(ctx),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	if err != nil {
+		return CommandTemplate{}, err
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.templates[name]; ok {
+		return CommandTemplate{}, ErrTemplateExists
+	}
+	r.templates[name] = t
+	if err = r.save(); err != nil {
+		delete(r.templates, name)
+		return CommandTemplate{}, err
+	}
+	return t.CommandTemplate, nil
+}
+
+func (r *TemplateRegistry) list() []CommandTemplate {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.sorted()
+}
+
+func (r *TemplateRegistry) get(name string) (*commandTemplate, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	t, ok := r.templates[name]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	return t, nil
+}
+
+func (r *TemplateRegistry) update(ctx context.Context, name string, spec TemplateSpec) (CommandTemplate, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	old, ok := r.templates[name]
+	if !ok {
+		return CommandTemplate{}, ErrTemplateNotFound
+	}
+	t, err := newCommandTemplate(CommandTemplate{
+		Name:         name,
+		TemplateSpec: spec,
+		Principal:    CallerFromContext(ctx),
+		CreatedAt:    old.CreatedAt,
+		UpdatedAt:    time.Now(),
+	})
+	if err != nil {
+		return CommandTemplate{}, err
+	}
+	r.templates[name] = t
+	if err = r.save(); err != nil {
+		r.templates[name] = old
+		return CommandTemplate{}, err
+	}
+	return t.CommandTemplate, nil
+}
+
+func (r *TemplateRegistry) delete(name string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	t, ok := r.templates[name]
+	if !ok {
+		return ErrTemplateNotFound
+	}
+	delete(r.templates, name)
+	if err := r.save(); err != nil {
+		r.templates[name] = t
+		return err
+	}
+	return nil
+}
+
+// sorted returns the templates by name. The caller must hold the mutex.
+func (r *TemplateRegistry) sorted() []CommandTemplate {
+	res := make([]CommandTemplate, 0, len(r.templates))
+	for _, t := range r.templates {
+		res = append(res, t.CommandTemplate)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// save rewrites the file with the templates. The caller must hold the mutex.
+func (r *TemplateRegistry) save() error {
+	return writeJSONFile(r.path, r.sorted())
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewCommandTemplate(t *testing.T) {
+	def := func(v string) *string { return &v }
+	tests := []struct {
+		name   string
+		cmd    string
+		opts   ExecOptions
+		params []TemplateParam
+		err    error
+	}{
+		{name: "valid", cmd: "du -sh {path}", params: []TemplateParam{{Name: "path", Type: ParamPath}}},
+		{name: "placeholder inside a word", cmd: "grep --max-count={n} -- {pattern} /var/log/{file}", params: []TemplateParam{{Name: "n", Type: ParamInt}, {Name: "pattern"}, {Name: "file", Pattern: `[a-z]+\.log`}}},
+		{name: "braces that are not a placeholder", cmd: "find . -exec echo {} ;", params: nil},
+		{name: "undeclared parameter", cmd: "du -sh {path}", err: ErrInvalidTemplate},
+		{name: "unused parameter", cmd: "du -sh /", params: []TemplateParam{{Name: "path"}}, err: ErrInvalidTemplate},
+		{name: "duplicate parameter", cmd: "echo {a}", params: []TemplateParam{{Name: "a"}, {Name: "a"}}, err: ErrInvalidTemplate},
+		{name: "invalid parameter name", cmd: "echo {a}", params: []TemplateParam{{Name: "a"}, {Name: "1a"}}, err: ErrInvalidTemplate},
+		{name: "unknown type", cmd: "echo {a}", params: []TemplateParam{{Name: "a", Type: "float"}}, err: ErrInvalidTemplate},
+		{name: "invalid pattern", cmd: "echo {a}", params: []TemplateParam{{Name: "a", Pattern: "("}}, err: ErrInvalidTemplate},
+		{name: "invalid default", cmd: "head -n {n}", params: []TemplateParam{{Name: "n", Type: ParamInt, Default: def("ten")}}, err: ErrInvalidTemplate},
+		{name: "default that does not match", cmd: "echo {a}", params: []TemplateParam{{Name: "a", Pattern: "[a-z]+", Default: def("A")}}, err: ErrInvalidTemplate},
+		{name: "shell mode", cmd: "echo {a}", opts: ExecOptions{Mode: ExecModeShell}, params: []TemplateParam{{Name: "a"}}, err: ErrInvalidTemplate},
+		{name: "empty command", cmd: "  ", err: ErrInvalidCommand},
+		{name: "unterminated quote", cmd: "echo '{a}", params: []TemplateParam{{Name: "a"}}, err: ErrInvalidCommand},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newCommandTemplate(CommandTemplate{
+				Name:         "t",
+				TemplateSpec: TemplateSpec{Cmd: tt.cmd, Params: tt.params, Options: tt.opts},
+			})
+			if !errors.Is(err, tt.err) {
+				t.Errorf("newCommandTemplate(%q) error = %v, want %v", tt.cmd, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCommandTemplateRender(t *testing.T) {
+	ten := "10"
+	ct, err := newCommandTemplate(CommandTemplate{
+		Name: "t",
+		TemplateSpec: TemplateSpec{
+			Cmd: "grep -m {max} --label={label} {pattern} {dir}/app.log",
+			Params: []TemplateParam{
+				{Name: "max", Type: ParamInt, Default: &ten},
+				{Name: "label", Pattern: `[a-z -]*`},
+				{Name: "pattern"},
+				{Name: "dir", Type: ParamPath},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   []string
+		err    error
+	}{
+		{name: "plain", params: map[string]string{"label": "app", "pattern": "error", "dir": "/var/log"}, want: []string{"grep", "-m", "10", "--label=app", "error", "/var/log/app.log"}},
+		{name: "spaces", params: map[string]string{"max": "007", "label": "my app", "pattern": "a b  c", "dir": "/srv/my logs"}, want: []string{"grep", "-m", "7", "--label=my app", "a b  c", "/srv/my logs/app.log"}},
+		{name: "quotes", params: map[string]string{"label": "", "pattern": `it's "quoted"`, "dir": `'"`}, want: []string{"grep", "-m", "10", "--label=", `it's "quoted"`, `'"/app.log`}},
+		{name: "separators", params: map[string]string{"label": "", "pattern": "x; rm -rf / && id | sh", "dir": "."}, want: []string{"grep", "-m", "10", "--label=", "x; rm -rf / && id | sh", "./app.log"}},
+		{name: "substitutions", params: map[string]string{"label": "", "pattern": "$(id) `id` ${HOME} \\n", "dir": "~"}, want: []string{"grep", "-m", "10", "--label=", "$(id) `id` ${HOME} \\n", "~/app.log"}},
+		{name: "leading - inside a word", params: map[string]string{"label": "-x", "pattern": "e", "dir": "d"}, want: []string{"grep", "-m", "10", "--label=-x", "e", "d/app.log"}},
+		{name: "negative integer", params: map[string]string{"max": "-1", "label": "", "pattern": "e", "dir": "d"}, err: ErrInvalidParams},
+		{name: "leading - starting a word", params: map[string]string{"label": "", "pattern": "-f/etc/passwd", "dir": "d"}, err: ErrInvalidParams},
+		{name: "leading - starting a path", params: map[string]string{"label": "", "pattern": "e", "dir": "--help"}, err: ErrInvalidParams},
+		{name: "path with ..", params: map[string]string{"label": "", "pattern": "e", "dir": "/var/log/../../etc"}, err: ErrInvalidParams},
+		{name: "path that is ..", params: map[string]string{"label": "", "pattern": "e", "dir": ".."}, err: ErrInvalidParams},
+		{name: "empty path", params: map[string]string{"label": "", "pattern": "e", "dir": ""}, err: ErrInvalidParams},
+		{name: "pattern mismatch", params: map[string]string{"label": "App1", "pattern": "e", "dir": "d"}, err: ErrInvalidParams},
+		{name: "not an integer", params: map[string]string{"max": "1e3", "label": "", "pattern": "e", "dir": "d"}, err: ErrInvalidParams},
+		{name: "NUL byte", params: map[string]string{"label": "", "pattern": "a\x00b", "dir": "d"}, err: ErrInvalidParams},
+		{name: "missing parameter", params: map[string]string{"label": "", "pattern": "e"}, err: ErrInvalidParams},
+		{name: "unknown parameter", params: map[string]string{"label": "", "pattern": "e", "dir": "d", "user": "root"}, err: ErrInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, _, err := ct.render(tt.params)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("render(%q) error = %v, want %v", tt.params, err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			got, err := SplitArgs(cmd)
+			if err != nil {
+				t.Fatalf("SplitArgs(%q) error = %v", cmd, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("render(%q) = %q, split into %q, want %q", tt.params, cmd, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+namespace go bashexec
+
+// Times are Unix nanoseconds, 0 for the zero time, and durations nanoseconds.
+
+struct ExecCmdRequest {
+	1: string cmd
+	// mode is "argv" or "shell", argv when empty.
+	2: string mode
+	// timeout is a Go duration, such as "30s", the server maximum when empty.
+	3: string timeout
+	// cwd is the working directory, env the variables set on top of the
+	// environment of the server, or of an empty one when clean_env is set.
+	4: string cwd
+	5: map<string, string> env
+	6: bool clean_env
+	7: binary stdin
+	// limits lower the ones of the server, they cannot raise them.
+	8: Limits limits
+}
+
+// Limits bound the resources of a command, 0 or empty for the server default.
+struct Limits {
+	// cpu_time is a Go duration, such as "10s".
+	1: string cpu_time
+	2: i64 memory_bytes
+	3: i64 open_files
+	4: i64 processes
+	5: i64 output_bytes
+}
+
+struct ExecStats {
+	1: i64 started_at
+	2: i64 finished_at
+	3: i64 duration_ns
+	4: i64 user_time_ns
+	5: i64 system_time_ns
+	6: i64 peak_rss_bytes
+	7: string host
+	8: string instance_id
+}
+
+struct ExecCmdReply {
+	1: string std_out
+	2: string std_err
+	3: i32 exit_code
+	4: string history_id
+	5: string err
+	6: ExecStats stats
+	// limit_exceeded is the limit that stopped the command, such as "cpu_time",
+	// empty when none did.
+	7: string limit_exceeded
+	// stdout_bytes and stderr_bytes are the sizes of the whole output, std_out
+	// and std_err only hold its head and its tail when it is truncated.
+	8: i64 stdout_bytes
+	9: bool stdout_truncated
+	10: i64 stderr_bytes
+	11: bool stderr_truncated
+}
+
+struct SubmitJobReply {
+	1: string id
+	2: string err
+}
+
+struct Job {
+	1: string id
+	2: string cmd
+	3: string status
+	4: string std_out
+	5: string std_err
+	6: i32 exit_code
+	7: string history_id
+	8: string err
+	9: i64 created_at
+	10: i64 started_at
+	11: i64 finished_at
+	12: string limit_exceeded
+	13: i64 stdout_bytes
+	14: bool stdout_truncated
+	15: i64 stderr_bytes
+	16: bool stderr_truncated
+}
+
+struct GetJobReply {
+	1: Job job
+	2: string err
+}
+
+struct CancelJobReply {
+	1: string err
+}
+
+service BashExecService {
+	ExecCmdReply ExecCmd(1: ExecCmdRequest request)
+	SubmitJobReply SubmitJob(1: ExecCmdRequest request)
+	GetJobReply GetJob(1: string id)
+	CancelJobReply CancelJob(1: string id)
+}
@@ -0,0 +1,140 @@
+package thrift
+
+import (
+	endpoint "bash_exec/pkg/endpoint"
+	service "bash_exec/pkg/service"
+	bashexec "bash_exec/pkg/thrift/gen-go/bashexec"
+	"context"
+	"time"
+)
+
+type thriftServer struct {
+	endpoints endpoint.Endpoints
+}
+
+// NewThriftServer makes a set of endpoints available as a Thrift BashExecService.
+func NewThriftServer(endpoints endpoint.Endpoints) bashexec.BashExecService {
+	return &thriftServer{endpoints: endpoints}
+}
+
+func (s *thriftServer) ExecCmd(ctx context.Context, req *bashexec.ExecCmdRequest) (*bashexec.ExecCmdReply, error) {
+	response, err := s.endpoints.ExecCmdEndpoint(ctx, decodeExecCmdRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	resp := response.(endpoint.ExecCmdResponse)
+	return &bashexec.ExecCmdReply{
+		StdOut:          resp.StdOut,
+		StdErr:          resp.StdErr,
+		ExitCode:        int32(resp.ExitCode),
+		HistoryID:       resp.HistoryID,
+		Err:             errString(resp.Err),
+		LimitExceeded:   resp.LimitExceeded,
+		StdoutBytes:     resp.StdoutBytes,
+		StdoutTruncated: resp.StdoutTruncated,
+		StderrBytes:     resp.StderrBytes,
+		StderrTruncated: resp.StderrTruncated,
+		Stats: &bashexec.ExecStats{
+			StartedAt:    unixNano(resp.StartedAt),
+			FinishedAt:   unixNano(resp.FinishedAt),
+			DurationNs:   int64(resp.Duration),
+			UserTimeNs:   int64(resp.UserTime),
+			SystemTimeNs: int64(resp.SystemTime),
+			PeakRssBytes: resp.PeakRSS,
+			Host:         resp.Host,
+			InstanceID:   resp.InstanceID,
+		},
+	}, nil
+}
+
+func (s *thriftServer) SubmitJob(ctx context.Context, req *bashexec.ExecCmdRequest) (*bashexec.SubmitJobReply, error) {
+	response, err := s.endpoints.SubmitJobEndpoint(ctx, endpoint.SubmitJobRequest{ExecCmdRequest: decodeExecCmdRequest(req)})
+	if err != nil {
+		return nil, err
+	}
+	resp := response.(endpoint.SubmitJobResponse)
+	return &bashexec.SubmitJobReply{ID: resp.ID, Err: errString(resp.Err)}, nil
+}
+
+func (s *thriftServer) GetJob(ctx context.Context, id string) (*bashexec.GetJobReply, error) {
+	response, err := s.endpoints.GetJobEndpoint(ctx, endpoint.GetJobRequest{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	resp := response.(endpoint.GetJobResponse)
+	return &bashexec.GetJobReply{Job: encodeJob(resp.Job), Err: errString(resp.Err)}, nil
+}
+
+func (s *thriftServer) CancelJob(ctx context.Context, id string) (*bashexec.CancelJobReply, error) {
+	response, err := s.endpoints.CancelJobEndpoint(ctx, endpoint.CancelJobRequest{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	resp := response.(endpoint.CancelJobResponse)
+	return &bashexec.CancelJobReply{Err: errString(resp.Err)}, nil
+}
+
+func decodeExecCmdRequest(req *bashexec.ExecCmdRequest) endpoint.ExecCmdRequest {
+	if req == nil {
+		return endpoint.ExecCmdRequest{}
+	}
+	return endpoint.ExecCmdRequest{
+		Cmd:      req.Cmd,
+		Mode:     req.Mode,
+		Timeout:  req.Timeout,
+		Cwd:      req.Cwd,
+		Env:      req.Env,
+		CleanEnv: req.CleanEnv,
+		Stdin:    string(req.Stdin),
+		Limits:   decodeLimits(req.Limits),
+	}
+}
+
+func decodeLimits(l *bashexec.Limits) *endpoint.ExecLimits {
+	if l == nil {
+		return nil
+	}
+	return &endpoint.ExecLimits{
+		CPUTime:     l.CPUTime,
+		MemoryBytes: l.MemoryBytes,
+		OpenFiles:   l.OpenFiles,
+		Processes:   l.Processes,
+		OutputBytes: l.OutputBytes,
+	}
+}
+
+func encodeJob(j service.Job) *bashexec.Job {
+	return &bashexec.Job{
+		ID:              j.ID,
+		Cmd:             j.Cmd,
+		Status:          string(j.Status),
+		StdOut:          j.StdOut,
+		StdErr:          j.StdErr,
+		ExitCode:        int32(j.ExitCode),
+		HistoryID:       j.HistoryID,
+		Err:             j.Err,
+		CreatedAt:       unixNano(j.CreatedAt),
+		StartedAt:       unixNano(j.StartedAt),
+		FinishedAt:      unixNano(j.FinishedAt),
+		LimitExceeded:   j.LimitExceeded,
+		StdoutBytes:     j.StdoutBytes,
+		StdoutTruncated: j.StdoutTruncated,
+		StderrBytes:     j.StderrBytes,
+		StderrTruncated: j.StderrTruncated,
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// unixNano returns t in Unix nanoseconds, 0 for the zero time.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
@@ -0,0 +1,214 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+	endpoint "github.com/go-kit/kit/endpoint"
+)
+
+var (
+	ErrUnauthenticated    = errs.New(http.StatusUnauthorized, "unauthenticated", "unauthenticated")
+	ErrMissingCredentials = ErrUnauthenticated.Extend("missing_credentials", "missing credentials")
+	ErrInvalidCredentials = ErrUnauthenticated.Extend("invalid_credentials", "invalid credentials")
+	ErrBodyTooLarge       = errs.New(http.StatusRequestEntityTooLarge, "body_too_large", "signed request body too large")
+	ErrInvalidConfig      = errors.New("invalid authentication config")
+)
+
+const (
+	// APIKeyHeader is the request header that carries a static API key.
+	APIKeyHeader = "X-API-Key"
+	// AuthorizationHeader carries a JWT bearer token or an HMAC signature.
+	AuthorizationHeader = "Authorization"
+)
+
+// Method is the way a principal proved its identity.
+type Method string
+
+const (
+	MethodAPIKey Method = "api-key"
+	MethodHMAC   Method = "hmac"
+	MethodJWT    Method = "jwt"
+)
+
+// Principal is an authenticated caller.
+type Principal struct {
+	ID     string `json:"id"`
+	Method Method `json:"method"`
+}
+
+// Config locates the credentials an Authenticator accepts.
+type Config struct {
+	// KeysFile is a YAML or JSON file with the API keys and the HMAC secrets.
+	KeysFile string
+	// JWKSFile is a JSON Web Key Set whose keys verify the JWT bearer tokens.
+	JWKSFile string
+	// Issuer and Audience, when set, must match the iss and aud claims of the tokens.
+	Issuer   string
+	Audience string
+}
+
+// Enabled reports whether c configures at least one authentication method.
+func (c Config) Enabled() bool {
+	return c.KeysFile != "" || c.JWKSFile != ""
+}
+
+// Authenticator resolves the principal of a request, from a static API key,
+// an HMAC signature or a JWT bearer token.
+type Authenticator struct {
+	keys     *keys
+	jwks     *jwks
+	issuer   string
+	audience string
+	nonces   nonceCache
+}
+
+// New returns an Authenticator that accepts the credentials configured by cfg.
+func New(cfg Config) (*Authenticator, error) {
+	if !cfg.Enabled() {
+		return nil, fmt.Errorf("%w: neither keys file nor JWKS file", ErrInvalidConfig)
+	}
+	a := &Authenticator{keys: &keys{}, issuer: cfg.Issuer, audience: cfg.Audience}
+	var err error
+	if cfg.KeysFile != "" {
+		if a.keys, err = loadKeys(cfg.KeysFile); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.JWKSFile != "" {
+		if a.jwks, err = loadJWKS(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// Request authenticates r with the first credentials it carries: the
+// Authorization header, then the API key header. The body of the request is
+// read to check an HMAC signature, up to MaxSignedBody bytes, and restored.
+func (a *Authenticator) Request(r *http.Request) (Principal, error) {
+	if v := r.Header.Get(AuthorizationHeader); v != "" {
+		scheme, params := splitAuthorization(v)
+		switch {
+		case strings.EqualFold(scheme, "Bearer"):
+			return a.BearerToken(params)
+		case strings.EqualFold(scheme, hmacScheme):
+			body, err := readBody(r, MaxSignedBody)
+			if err != nil {
+				return Principal{}, err
+			}
+			return a.HMAC(params, r.Method, r.URL.RequestURI(), body)
+		}
+		return Principal{}, fmt.Errorf("%w: unsupported authorization scheme %q", ErrInvalidCredentials, scheme)
+	}
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return a.APIKey(key)
+	}
+	return Principal{}, ErrMissingCredentials
+}
+
+// Metadata authenticates the metadata of a gRPC request, that can carry an
+// API key or a bearer token, but no HMAC signature since there is no body to sign.
+func (a *Authenticator) Metadata(md map[string][]string) (Principal, error) {
+	if v := first(md, strings.ToLower(AuthorizationHeader)); v != "" {
+		scheme, params := splitAuthorization(v)
+		if !strings.EqualFold(scheme, "Bearer") {
+			return Principal{}, fmt.Errorf("%w: unsupported authorization scheme %q", ErrInvalidCredentials, scheme)
+		}
+		return a.BearerToken(params)
+	}
+	if key := first(md, strings.ToLower(APIKeyHeader)); key != "" {
+		return a.APIKey(key)
+	}
+	return Principal{}, ErrMissingCredentials
+}
+
+// APIKey returns the principal the key was issued to.
+func (a *Authenticator) APIKey(key string) (Principal, error) {
+	id, ok := a.keys.apiKey(key)
+	if !ok {
+		return Principal{}, fmt.Errorf("%w: unknown API key", ErrInvalidCredentials)
+	}
+	return Principal{ID: id, Method: MethodAPIKey}, nil
+}
+
+type principalKey struct{}
+
+type result struct {
+	principal Principal
+	err       error
+}
+
+// ToContext returns a copy of ctx carrying the outcome of an authentication.
+func ToContext(ctx context.Context, p Principal, err error) context.Context {
+	return context.WithValue(ctx, principalKey{}, result{p, err})
+}
+
+// FromContext returns the principal stored in ctx, or the reason why the
+// request could not be authenticated.
+func FromContext(ctx context.Context) (Principal, error) {
+	r, ok := ctx.Value(principalKey{}).(result)
+	if !ok {
+		return Principal{}, ErrMissingCredentials
+	}
+	return r.principal, r.err
+}
+
+// Middleware returns an endpoint middleware that rejects the requests that
+// were not authenticated by the transport, see ToContext.
+func Middleware() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if _, err := FromContext(ctx); err != nil {
+				return nil, err
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// splitAuthorization splits an Authorization header into its scheme and its parameters.
+func splitAuthorization(v string) (scheme, params string) {
+	v = strings.TrimSpace(v)
+	if i := strings.IndexByte(v, ' '); i >= 0 {
+		return v[:i], strings.TrimSpace(v[i+1:])
+	}
+	return v, ""
+}
+
+func first(md map[string][]string, key string) string {
+	if v := md[key]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// readBody returns the body of r, and replaces it so that it can be read
+// again. A body longer than limit, when positive, fails with ErrBodyTooLarge
+// and is replaced by an empty one.
+func readBody(r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	rc := r.Body
+	if limit > 0 {
+		rc = http.MaxBytesReader(nil, rc, limit)
+	}
+	body, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		r.Body = http.NoBody
+		if limit > 0 && int64(len(body)) >= limit {
+			return nil, ErrBodyTooLarge
+		}
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
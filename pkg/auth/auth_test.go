@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+const testKeys = `
+api_keys:
+  - principal: alice
+    key: alice-api-key
+hmac_keys:
+  - id: store
+    principal: bash-exec
+    secret: 0123456789abcdef0123456789abcdef
+`
+
+// newTestAuthenticator returns an Authenticator of testKeys, and of a JWKS
+// holding the public key of the returned ECDSA key.
+func newTestAuthenticator(t *testing.T) (*Authenticator, *ecdsa.PrivateKey) {
+	t.Helper()
+	dir := t.TempDir()
+	keysFile := filepath.Join(dir, "keys.yaml")
+	if err := os.WriteFile(keysFile, []byte(testKeys), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, _ := json.Marshal(map[string][]jwk{"keys": {{
+		Kty: "EC",
+		Kid: "test",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(priv.X.Bytes()),
+		Y:   base64.RawURLEncoding.EncodeToString(priv.Y.Bytes()),
+	}}})
+	jwksFile := filepath.Join(dir, "jwks.json")
+	if err = os.WriteFile(jwksFile, set, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	a, err := New(Config{KeysFile: keysFile, JWKSFile: jwksFile, Issuer: "issuer", Audience: "bash_exec"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a, priv
+}
+
+func TestBearerToken(t *testing.T) {
+	a, priv := newTestAuthenticator(t)
+	now := time.Now()
+	claims := func(exp time.Time) jwt.RegisteredClaims {
+		return jwt.RegisteredClaims{
+			Subject:   "alice",
+			Issuer:    "issuer",
+			Audience:  jwt.ClaimStrings{"bash_exec"},
+			ExpiresAt: jwt.NewNumericDate(exp),
+		}
+	}
+	sign := func(method jwt.SigningMethod, c jwt.Claims, key interface{}) string {
+		token := jwt.NewWithClaims(method, c)
+		token.Header["kid"] = "test"
+		s, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	noAudience := claims(now.Add(time.Hour))
+	noAudience.Audience = nil
+	noExpiry := claims(now)
+	noExpiry.ExpiresAt = nil
+	// A verifier that trusted the alg header would check an HS256 token with
+	// the public key as the secret.
+	public := elliptic.Marshal(elliptic.P256(), priv.X, priv.Y)
+
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "valid", token: sign(jwt.SigningMethodES256, claims(now.Add(time.Hour)), priv), want: "alice"},
+		{name: "expired", token: sign(jwt.SigningMethodES256, claims(now.Add(-time.Minute)), priv)},
+		{name: "no expiration", token: sign(jwt.SigningMethodES256, noExpiry, priv)},
+		{name: "wrong audience", token: sign(jwt.SigningMethodES256, noAudience, priv)},
+		{name: "alg none", token: sign(jwt.SigningMethodNone, claims(now.Add(time.Hour)), jwt.UnsafeAllowNoneSignatureType)},
+		{name: "alg HS256", token: sign(jwt.SigningMethodHS256, claims(now.Add(time.Hour)), public)},
+		{name: "malformed", token: "not.a.token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := a.BearerToken(tt.token)
+			if tt.want == "" {
+				if !errors.Is(err, ErrInvalidCredentials) {
+					t.Fatalf("BearerToken error = %v, want %v", err, ErrInvalidCredentials)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.ID != tt.want || p.Method != MethodJWT {
+				t.Errorf("BearerToken = %+v, want %s", p, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestHMAC(t *testing.T) {
+	a, _ := newTestAuthenticator(t)
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	// header builds the Authorization header of a signature of the POST to /store.
+	header := func(ts int64, nonce, body string) string {
+		sig := hmacSignature(secret, http.MethodPost, "/store", ts, nonce, []byte(body))
+		return fmt.Sprintf("%s KeyId=store, Timestamp=%d, Nonce=%s, Signature=%s", hmacScheme, ts, nonce, hex.EncodeToString(sig))
+	}
+	now := time.Now().Unix()
+	replayed := header(now, "replayed", `{}`)
+
+	tests := []struct {
+		name   string
+		header string
+		body   string
+		err    error
+	}{
+		{name: "valid", header: header(now, "n1", `{"a":1}`), body: `{"a":1}`},
+		{name: "first use", header: replayed, body: `{}`},
+		{name: "replay", header: replayed, body: `{}`, err: ErrInvalidCredentials},
+		{name: "tampered body", header: header(now, "n2", `{"a":1}`), body: `{"a":2}`, err: ErrInvalidCredentials},
+		{name: "too old", header: header(now-int64(MaxClockSkew/time.Second)-60, "n3", ``), err: ErrInvalidCredentials},
+		{name: "in the future", header: header(now+int64(MaxClockSkew/time.Second)+60, "n4", ``), err: ErrInvalidCredentials},
+		{name: "no nonce", header: strings.Replace(header(now, "", ``), "Nonce=, ", "", 1), err: ErrInvalidCredentials},
+		{name: "unknown key", header: strings.Replace(header(now, "n5", ``), "KeyId=store", "KeyId=other", 1), err: ErrInvalidCredentials},
+		{name: "body too large", header: header(now, "n6", ``), body: strings.Repeat("x", MaxSignedBody+1), err: ErrBodyTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/store", strings.NewReader(tt.body))
+			r.Header.Set(AuthorizationHeader, tt.header)
+			p, err := a.Request(r)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Request error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if p.ID != "bash-exec" || p.Method != MethodHMAC {
+				t.Errorf("Request = %+v, want bash-exec", p)
+			}
+			// The body is restored for the decoder.
+			if body, _ := io.ReadAll(r.Body); string(body) != tt.body {
+				t.Errorf("body = %q, want %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	a, _ := newTestAuthenticator(t)
+	tests := []struct {
+		name        string
+		credentials Credentials
+		want        Principal
+		err         error
+	}{
+		{name: "api key", credentials: Credentials{APIKey: "alice-api-key"}, want: Principal{ID: "alice", Method: MethodAPIKey}},
+		{name: "unknown api key", credentials: Credentials{APIKey: "mallory"}, err: ErrInvalidCredentials},
+		{name: "hmac", credentials: Credentials{KeyID: "store", Secret: "0123456789abcdef0123456789abcdef"}, want: Principal{ID: "bash-exec", Method: MethodHMAC}},
+		{name: "wrong secret", credentials: Credentials{KeyID: "store", Secret: "fedcba9876543210fedcba9876543210"}, err: ErrInvalidCredentials},
+		{name: "none", err: ErrMissingCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The same request is sent twice, as a client retry does, each
+			// signature has its own nonce.
+			for i := 0; i < 2; i++ {
+				r := httptest.NewRequest(http.MethodPost, "/store?x=1", strings.NewReader(`{"cmd":"ls"}`))
+				if err := tt.credentials.SignRequest(r); err != nil {
+					t.Fatal(err)
+				}
+				p, err := a.Request(r)
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Request error = %v, want %v", err, tt.err)
+				}
+				if p != tt.want {
+					t.Errorf("Request = %+v, want %+v", p, tt.want)
+				}
+			}
+		})
+	}
+}
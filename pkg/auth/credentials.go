@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Credentials authenticate the requests of a client. Only one of the API key,
+// the HMAC key and the token should be set, they are tried in that order.
+type Credentials struct {
+	APIKey string `json:"api_key,omitempty" yaml:"api_key,omitempty"`
+	// KeyID and Secret are an HMACKey of the server.
+	KeyID  string `json:"key_id,omitempty" yaml:"key_id,omitempty"`
+	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
+	// Token is a JWT bearer token.
+	Token string `json:"token,omitempty" yaml:"token,omitempty"`
+}
+
+// LoadCredentials reads Credentials from a YAML or JSON file, chosen by its extension.
+func LoadCredentials(path string) (c Credentials, err error) {
+	if err = unmarshalFile(path, &c); err != nil {
+		return
+	}
+	if c.APIKey == "" && c.KeyID == "" && c.Token == "" {
+		err = fmt.Errorf("%w: %s: no credentials", ErrInvalidConfig, path)
+	}
+	return
+}
+
+// SignRequest adds the credentials to r. An HMAC signature covers the body,
+// which is read and replaced, so r must be complete.
+func (c Credentials) SignRequest(r *http.Request) error {
+	switch {
+	case c.APIKey != "":
+		r.Header.Set(APIKeyHeader, c.APIKey)
+	case c.KeyID != "":
+		body, err := readBody(r, 0)
+		if err != nil {
+			return err
+		}
+		nonce, err := newNonce()
+		if err != nil {
+			return err
+		}
+		ts := time.Now().Unix()
+		sig := hmacSignature([]byte(c.Secret), r.Method, r.URL.RequestURI(), ts, nonce, body)
+		r.Header.Set(AuthorizationHeader, fmt.Sprintf("%s KeyId=%s, Timestamp=%d, Nonce=%s, Signature=%s", hmacScheme, c.KeyID, ts, nonce, hex.EncodeToString(sig)))
+	case c.Token != "":
+		r.Header.Set(AuthorizationHeader, "Bearer "+c.Token)
+	}
+	return nil
+}
+
+// SetMetadata adds the credentials to the metadata of a gRPC request. HMAC
+// signatures are not supported over gRPC, and fail.
+func (c Credentials) SetMetadata(md map[string][]string) error {
+	switch {
+	case c.APIKey != "":
+		md[strings.ToLower(APIKeyHeader)] = []string{c.APIKey}
+	case c.KeyID != "":
+		return fmt.Errorf("%w: HMAC signatures are not supported over gRPC", ErrInvalidConfig)
+	case c.Token != "":
+		md[strings.ToLower(AuthorizationHeader)] = []string{"Bearer " + c.Token}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// hmacScheme is the Authorization scheme of the signed requests, whose
+	// parameters are KeyId, Timestamp (Unix seconds), Nonce and Signature (hex), e.g.
+	//  Authorization: HMAC-SHA256 KeyId=bash-exec, Timestamp=1662026400, Nonce=5c1e..., Signature=8f2a...
+	hmacScheme = "HMAC-SHA256"
+	// MaxClockSkew bounds the age of a signature, and how far in the future
+	// it can be. The nonces are remembered for that long, so that a captured
+	// request cannot be replayed.
+	MaxClockSkew = 5 * time.Minute
+	// MaxSignedBody is the largest body read to check an HMAC signature, in bytes.
+	MaxSignedBody = 8 << 20
+	// minHMACSecret is the shortest secret accepted, in bytes.
+	minHMACSecret = 16
+)
+
+// HMAC checks the parameters of an HMAC-SHA256 Authorization header against
+// the request they sign, and returns the principal of the key.
+func (a *Authenticator) HMAC(params, method, requestURI string, body []byte) (Principal, error) {
+	p, err := parseHMACParams(params)
+	if err != nil {
+		return Principal{}, err
+	}
+	key, ok := a.keys.hmacKeys[p.keyID]
+	if !ok {
+		return Principal{}, fmt.Errorf("%w: unknown HMAC key %q", ErrInvalidCredentials, p.keyID)
+	}
+	if skew := time.Since(time.Unix(p.timestamp, 0)); skew > MaxClockSkew || skew < -MaxClockSkew {
+		return Principal{}, fmt.Errorf("%w: HMAC timestamp out of range", ErrInvalidCredentials)
+	}
+	want := hmacSignature([]byte(key.Secret), method, requestURI, p.timestamp, p.nonce, body)
+	if !hmac.Equal(want, p.signature) {
+		return Principal{}, fmt.Errorf("%w: bad HMAC signature", ErrInvalidCredentials)
+	}
+	// Only the valid signatures are remembered, so that the cache grows with
+	// the traffic of the key holders alone.
+	if !a.nonces.add(p.keyID+"/"+p.nonce, time.Unix(p.timestamp, 0).Add(MaxClockSkew)) {
+		return Principal{}, fmt.Errorf("%w: replayed HMAC nonce", ErrInvalidCredentials)
+	}
+	return Principal{ID: key.Principal, Method: MethodHMAC}, nil
+}
+
+// hmacSignature signs the method, the path and query, the timestamp, the
+// nonce and the SHA-256 of the body of a request, one per line.
+func hmacSignature(secret []byte, method, requestURI string, timestamp int64, nonce string, body []byte) []byte {
+	sum := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%s\n%s", strings.ToUpper(method), requestURI, timestamp, nonce, hex.EncodeToString(sum[:]))
+	return mac.Sum(nil)
+}
+
+// newNonce returns a random nonce, that makes every signature unique even
+// when the same request is sent twice within a second.
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// nonceCache remembers the nonces of the accepted signatures until their
+// timestamp leaves the MaxClockSkew window.
+type nonceCache struct {
+	mtx    sync.Mutex
+	seen   map[string]time.Time
+	pruned time.Time
+}
+
+// add records nonce until expires, and reports false if it is already known.
+func (c *nonceCache) add(nonce string, expires time.Time) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	now := time.Now()
+	if c.seen == nil {
+		c.seen = map[string]time.Time{}
+	}
+	if now.Sub(c.pruned) > time.Minute {
+		for n, exp := range c.seen {
+			if now.After(exp) {
+				delete(c.seen, n)
+			}
+		}
+		c.pruned = now
+	}
+	if exp, ok := c.seen[nonce]; ok && !now.After(exp) {
+		return false
+	}
+	c.seen[nonce] = expires
+	return true
+}
+
+type hmacParams struct {
+	keyID     string
+	timestamp int64
+	nonce     string
+	signature []byte
+}
+
+func parseHMACParams(params string) (p hmacParams, err error) {
+	for _, kv := range strings.Split(params, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok {
+			return p, fmt.Errorf("%w: malformed HMAC parameter %q", ErrInvalidCredentials, kv)
+		}
+		switch strings.ToLower(k) {
+		case "keyid":
+			p.keyID = v
+		case "timestamp":
+			if p.timestamp, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return p, fmt.Errorf("%w: malformed HMAC timestamp", ErrInvalidCredentials)
+			}
+		case "nonce":
+			p.nonce = v
+		case "signature":
+			if p.signature, err = hex.DecodeString(v); err != nil {
+				return p, fmt.Errorf("%w: malformed HMAC signature", ErrInvalidCredentials)
+			}
+		}
+	}
+	if p.keyID == "" || p.timestamp == 0 || p.nonce == "" || len(p.signature) == 0 {
+		return p, fmt.Errorf("%w: incomplete HMAC parameters", ErrInvalidCredentials)
+	}
+	return p, nil
+}
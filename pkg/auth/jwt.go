@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+// jwtMethods are the signature algorithms accepted, asymmetric only: the
+// JWKS holds public keys.
+var jwtMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// jwk is a JSON Web Key, RSA and EC public keys are supported.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwks struct {
+	keys map[string]crypto.PublicKey
+}
+
+// loadJWKS reads a JSON Web Key Set. The keys that are not for signatures are
+// ignored, the others must be usable.
+func loadJWKS(path string) (*jwks, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+	}
+
+	res := &jwks{keys: map[string]crypto.PublicKey{}}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: key %q: %v", ErrInvalidConfig, path, k.Kid, err)
+		}
+		if _, ok := res.keys[k.Kid]; ok {
+			return nil, fmt.Errorf("%w: %s: duplicate key %q", ErrInvalidConfig, path, k.Kid)
+		}
+		res.keys[k.Kid] = pub
+	}
+	if len(res.keys) == 0 {
+		return nil, fmt.Errorf("%w: %s: no signature key", ErrInvalidConfig, path)
+	}
+	return res, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("bad RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("malformed key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// key returns the key of a token, chosen by its kid header. A token without
+// kid can only be verified by a set of a single key.
+func (s *jwks) key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" && len(s.keys) == 1 {
+		for _, k := range s.keys {
+			return k, nil
+		}
+	}
+	k, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	return k, nil
+}
+
+// BearerToken verifies a JWT against the JWKS, checks its time claims and,
+// when configured, its issuer and audience. The principal is the subject.
+func (a *Authenticator) BearerToken(token string) (Principal, error) {
+	if a.jwks == nil {
+		return Principal{}, fmt.Errorf("%w: bearer tokens are not accepted", ErrInvalidCredentials)
+	}
+	claims := &jwt.RegisteredClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(jwtMethods))
+	if _, err := parser.ParseWithClaims(token, claims, a.jwks.key); err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	switch {
+	case claims.ExpiresAt == nil:
+		return Principal{}, fmt.Errorf("%w: token without expiration", ErrInvalidCredentials)
+	case a.issuer != "" && !claims.VerifyIssuer(a.issuer, true):
+		return Principal{}, fmt.Errorf("%w: bad token issuer", ErrInvalidCredentials)
+	case a.audience != "" && !claims.VerifyAudience(a.audience, true):
+		return Principal{}, fmt.Errorf("%w: bad token audience", ErrInvalidCredentials)
+	case claims.Subject == "":
+		return Principal{}, fmt.Errorf("%w: token without subject", ErrInvalidCredentials)
+	}
+	return Principal{ID: claims.Subject, Method: MethodJWT}, nil
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// KeysFile is the content of the file of Config.KeysFile.
+type KeysFile struct {
+	APIKeys  []APIKey  `json:"api_keys" yaml:"api_keys"`
+	HMACKeys []HMACKey `json:"hmac_keys" yaml:"hmac_keys"`
+}
+
+// APIKey is a static key issued to a principal. The key is better given by
+// its hex encoded SHA-256, so that the file does not hold it in clear.
+type APIKey struct {
+	Principal string `json:"principal" yaml:"principal"`
+	Key       string `json:"key,omitempty" yaml:"key,omitempty"`
+	KeySHA256 string `json:"key_sha256,omitempty" yaml:"key_sha256,omitempty"`
+}
+
+// HMACKey is a secret shared with a principal, that signs its requests with it.
+type HMACKey struct {
+	ID        string `json:"id" yaml:"id"`
+	Principal string `json:"principal" yaml:"principal"`
+	Secret    string `json:"secret" yaml:"secret"`
+}
+
+type keys struct {
+	apiKeys  map[[sha256.Size]byte]string
+	hmacKeys map[string]HMACKey
+}
+
+// loadKeys reads a KeysFile in YAML or JSON, chosen by its extension.
+func loadKeys(path string) (*keys, error) {
+	var f KeysFile
+	if err := unmarshalFile(path, &f); err != nil {
+		return nil, err
+	}
+
+	k := &keys{apiKeys: map[[sha256.Size]byte]string{}, hmacKeys: map[string]HMACKey{}}
+	for i, ak := range f.APIKeys {
+		var sum [sha256.Size]byte
+		switch {
+		case ak.Principal == "":
+			return nil, fmt.Errorf("%w: API key %d has no principal", ErrInvalidConfig, i)
+		case ak.KeySHA256 != "":
+			b, err := hex.DecodeString(ak.KeySHA256)
+			if err != nil || len(b) != sha256.Size {
+				return nil, fmt.Errorf("%w: API key of %s: malformed key_sha256", ErrInvalidConfig, ak.Principal)
+			}
+			copy(sum[:], b)
+		case ak.Key != "":
+			sum = sha256.Sum256([]byte(ak.Key))
+		default:
+			return nil, fmt.Errorf("%w: API key of %s has no key", ErrInvalidConfig, ak.Principal)
+		}
+		k.apiKeys[sum] = ak.Principal
+	}
+	for i, hk := range f.HMACKeys {
+		switch {
+		case hk.ID == "" || hk.Principal == "":
+			return nil, fmt.Errorf("%w: HMAC key %d has no id or no principal", ErrInvalidConfig, i)
+		case len(hk.Secret) < minHMACSecret:
+			return nil, fmt.Errorf("%w: HMAC key %s: the secret is shorter than %d bytes", ErrInvalidConfig, hk.ID, minHMACSecret)
+		}
+		if _, ok := k.hmacKeys[hk.ID]; ok {
+			return nil, fmt.Errorf("%w: duplicate HMAC key %s", ErrInvalidConfig, hk.ID)
+		}
+		k.hmacKeys[hk.ID] = hk
+	}
+	return k, nil
+}
+
+// apiKey returns the principal of key. The keys are compared by their hash, in
+// constant time.
+func (k *keys) apiKey(key string) (string, bool) {
+	sum := sha256.Sum256([]byte(key))
+	found := ""
+	for s, principal := range k.apiKeys {
+		if subtle.ConstantTimeCompare(s[:], sum[:]) == 1 {
+			found = principal
+		}
+	}
+	return found, found != ""
+}
+
+func unmarshalFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, v)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, v)
+	default:
+		err = fmt.Errorf("%w: unknown file extension of %s", ErrInvalidConfig, path)
+	}
+	return err
+}
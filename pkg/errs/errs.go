@@ -0,0 +1,116 @@
+// Package errs defines the errors returned to the callers of the service. Each
+// kind of error has a stable code, that the callers can rely on rather than on
+// the message, and the HTTP status it is reported with.
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Error is an error the callers can tell apart by its Code. The kinds of
+// errors are created by New, the errors returned by the service wrap them,
+// with fmt.Errorf and %w or with WithDetails, and the clients rebuild them
+// with Decode.
+type Error struct {
+	// Code identifies the kind of error, e.g. "job_not_found".
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Details are facts about this error that the callers can read without
+	// parsing the message, e.g. the exit code of a command.
+	Details map[string]string `json:"details,omitempty"`
+	// Status is the HTTP status of the kind of error.
+	Status int `json:"-"`
+
+	cause error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+var (
+	mtx   sync.RWMutex
+	kinds = map[string]*Error{}
+)
+
+// New returns a new kind of error, reported with the HTTP status. Codes are
+// unique, so that Decode can rebuild the kind of an error from its code.
+func New(status int, code, message string) *Error {
+	return register(&Error{Code: code, Message: message, Status: status})
+}
+
+// Extend returns a new kind of error that is also of the kind of e, with its
+// status, e.g. errors.Is(e.Extend(...), e) holds.
+func (e *Error) Extend(code, message string) *Error {
+	return register(&Error{Code: code, Message: e.Message + ": " + message, Status: e.Status, cause: e})
+}
+
+func register(e *Error) *Error {
+	mtx.Lock()
+	defer mtx.Unlock()
+	if _, ok := kinds[e.Code]; ok {
+		panic(fmt.Sprintf("errs: duplicate code %q", e.Code))
+	}
+	kinds[e.Code] = e
+	return e
+}
+
+// The kinds of errors that are not specific to a service.
+var (
+	ErrMalformedRequest = New(http.StatusBadRequest, "malformed_request", "malformed request")
+	ErrDeadlineExceeded = New(http.StatusRequestTimeout, "deadline_exceeded", "deadline exceeded")
+	ErrInternal         = New(http.StatusInternalServerError, "internal", "internal error")
+)
+
+// From returns the Error that describes err to the callers: the code, the
+// status and the details of its kind, and the message of err. Errors of no
+// kind are internal errors, except the expired deadlines.
+func From(err error) *Error {
+	var e *Error
+	if !errors.As(err, &e) {
+		e = ErrInternal
+		if errors.Is(err, context.DeadlineExceeded) {
+			e = ErrDeadlineExceeded
+		}
+	}
+	return &Error{Code: e.Code, Message: err.Error(), Details: e.Details, Status: e.Status, cause: err}
+}
+
+// WithDetails returns err with details added to the ones it already has.
+func WithDetails(err error, details map[string]string) error {
+	e := From(err)
+	all := make(map[string]string, len(e.Details)+len(details))
+	for k, v := range e.Details {
+		all[k] = v
+	}
+	for k, v := range details {
+		all[k] = v
+	}
+	e.Details = all
+	return e
+}
+
+// Decode rebuilds an error from its description received in a response of
+// the HTTP status. When its code is known, the error is of that kind and gets
+// its status, so that errors.Is works on both sides of the transport.
+func Decode(e *Error, status int) error {
+	res := &Error{Code: e.Code, Message: e.Message, Details: e.Details, Status: status}
+	if res.Message == "" {
+		res.Message = http.StatusText(status)
+	}
+	mtx.RLock()
+	kind, ok := kinds[e.Code]
+	mtx.RUnlock()
+	if ok {
+		res.Status, res.cause = kind.Status, kind
+	}
+	return res
+}
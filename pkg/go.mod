@@ -0,0 +1,9 @@
+module github.com/gigi214/services_example/pkg
+
+go 1.18
+
+require (
+	github.com/go-kit/kit v0.12.0
+	github.com/golang-jwt/jwt/v4 v4.4.2
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,163 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	endpoint1 "github.com/gigi214/services_example/store_cmds/pkg/endpoint"
+	pb "github.com/gigi214/services_example/store_cmds/pkg/grpc/pb"
+	service "github.com/gigi214/services_example/store_cmds/pkg/service"
+	endpoint "github.com/go-kit/kit/endpoint"
+	grpc1 "github.com/go-kit/kit/transport/grpc"
+	grpc "google.golang.org/grpc"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrNotSupported is returned by the methods that have no gRPC transport.
+var ErrNotSupported = errors.New("method not supported over gRPC")
+
+// New returns a StoreCmdsService backed by a gRPC server at the other end
+// of the conn. The caller is responsible for constructing the conn, and
+// eventually closing the underlying transport. Only Store and GetFromTo are
+// available over gRPC, the other methods fail with ErrNotSupported.
+func New(conn *grpc.ClientConn, options map[string][]grpc1.ClientOption) (service.StoreCmdsService, error) {
+	var storeEndpoint endpoint.Endpoint
+	{
+		storeEndpoint = grpc1.NewClient(conn, "pb.StoreCmds", "Store", encodeStoreRequest, decodeStoreResponse, &pb.StoreReply{}, options["Store"]...).Endpoint()
+	}
+
+	var getFromToEndpoint endpoint.Endpoint
+	{
+		getFromToEndpoint = grpc1.NewClient(conn, "pb.StoreCmds", "GetFromTo", encodeGetFromToRequest, decodeGetFromToResponse, &pb.GetFromToReply{}, options["GetFromTo"]...).Endpoint()
+	}
+
+	return endpoint1.Endpoints{
+		DeleteEndpoint:    notSupported,
+		GetEndpoint:       notSupported,
+		GetFromToEndpoint: getFromToEndpoint,
+		QueryEndpoint:     notSupported,
+		StoreEndpoint:     storeEndpoint,
+	}, nil
+}
+
+func notSupported(context.Context, interface{}) (interface{}, error) {
+	return nil, ErrNotSupported
+}
+
+// encodeStoreRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain Store request to a gRPC request.
+func encodeStoreRequest(_ context.Context, request interface{}) (interface{}, error) {
+	r := request.(endpoint1.StoreRequest)
+	return &pb.StoreRequest{
+		Cmd:             r.Cmd,
+		TimestampExec:   timestamp(r.TimestampExec),
+		Success:         r.Success,
+		ExitCode:        int32(r.ExitCode),
+		Stdout:          r.Stdout,
+		Stderr:          r.Stderr,
+		Host:            r.Host,
+		IdempotencyKey:  r.IdempotencyKey,
+		FinishedAt:      timestamp(r.FinishedAt),
+		Duration:        durationpb.New(r.Duration),
+		UserTime:        durationpb.New(r.UserTime),
+		SystemTime:      durationpb.New(r.SystemTime),
+		PeakRssBytes:    r.PeakRSS,
+		InstanceId:      r.InstanceID,
+		Principal:       r.Principal,
+		Cwd:             r.Cwd,
+		Env:             r.Env,
+		CleanEnv:        r.CleanEnv,
+		Stdin:           r.Stdin,
+		LimitExceeded:   r.LimitExceeded,
+		StdoutBytes:     r.StdoutBytes,
+		StdoutTruncated: r.StdoutTruncated,
+		StderrBytes:     r.StderrBytes,
+		StderrTruncated: r.StderrTruncated,
+		BatchId:         r.BatchID,
+		BatchStep:       r.BatchStep,
+		ScheduleId:      r.ScheduleID,
+		TemplateName:    r.TemplateName,
+		TemplateParams:  r.TemplateParams,
+	}, nil
+}
+
+// decodeStoreResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC Store reply to a user-domain Store response.
+func decodeStoreResponse(_ context.Context, reply interface{}) (interface{}, error) {
+	r := reply.(*pb.StoreReply)
+	return endpoint1.StoreResponse{ID: r.Id, Err: replyErr(r.Err)}, nil
+}
+
+// encodeGetFromToRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain GetFromTo request to a gRPC request.
+func encodeGetFromToRequest(_ context.Context, request interface{}) (interface{}, error) {
+	r := request.(endpoint1.GetFromToRequest)
+	return &pb.GetFromToRequest{From: timestamp(r.From), To: timestamp(r.To)}, nil
+}
+
+// decodeGetFromToResponse is a transport/grpc.DecodeResponseFunc that converts
+// a gRPC GetFromTo reply to a user-domain GetFromTo response.
+func decodeGetFromToResponse(_ context.Context, reply interface{}) (interface{}, error) {
+	r := reply.(*pb.GetFromToReply)
+	resp := endpoint1.GetFromToResponse{Err: replyErr(r.Err)}
+	for _, e := range r.Res {
+		resp.Res = append(resp.Res, &service.CmdExecutedEntry{
+			ID:              e.Id,
+			Cmd:             e.Cmd,
+			TimestampExec:   asTime(e.TimestampExec),
+			FinishedAt:      asTime(e.FinishedAt),
+			Success:         e.Success,
+			ExitCode:        int(e.ExitCode),
+			Stdout:          e.Stdout,
+			Stderr:          e.Stderr,
+			Duration:        e.Duration.AsDuration(),
+			UserTime:        e.UserTime.AsDuration(),
+			SystemTime:      e.SystemTime.AsDuration(),
+			PeakRSS:         e.PeakRssBytes,
+			Host:            e.Host,
+			InstanceID:      e.InstanceId,
+			Principal:       e.Principal,
+			Cwd:             e.Cwd,
+			Env:             e.Env,
+			CleanEnv:        e.CleanEnv,
+			Stdin:           e.Stdin,
+			LimitExceeded:   e.LimitExceeded,
+			StdoutBytes:     e.StdoutBytes,
+			StdoutTruncated: e.StdoutTruncated,
+			StderrBytes:     e.StderrBytes,
+			StderrTruncated: e.StderrTruncated,
+			BatchID:         e.BatchId,
+			BatchStep:       e.BatchStep,
+			ScheduleID:      e.ScheduleId,
+			TemplateName:    e.TemplateName,
+			TemplateParams:  e.TemplateParams,
+			IdempotencyKey:  e.IdempotencyKey,
+		})
+	}
+	return resp, nil
+}
+
+func replyErr(s string) error {
+	if s == "" {
+		return nil
+	}
+	return errors.New(s)
+}
+
+// timestamp converts t to a protobuf Timestamp, nil for the zero time.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// asTime converts t to a time.Time, the zero time for nil.
+func asTime(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
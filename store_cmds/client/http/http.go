@@ -36,8 +36,26 @@ func New(instance string, options map[string][]http.ClientOption) (service.Store
 		getFromToEndpoint = http.NewClient("POST", copyURL(u, "/get-from-to"), encodeHTTPGenericRequest, decodeGetFromToResponse, options["GetFromTo"]...).Endpoint()
 	}
 
+	var queryEndpoint endpoint.Endpoint
+	{
+		queryEndpoint = http.NewClient("GET", copyURL(u, "/history"), encodeQueryRequest, decodeQueryResponse, options["Query"]...).Endpoint()
+	}
+
+	var getEndpoint endpoint.Endpoint
+	{
+		getEndpoint = http.NewClient("GET", copyURL(u, "/history/"), encodeEntryIDRequest, decodeGetResponse, options["Get"]...).Endpoint()
+	}
+
+	var deleteEndpoint endpoint.Endpoint
+	{
+		deleteEndpoint = http.NewClient("DELETE", copyURL(u, "/history/"), encodeEntryIDRequest, decodeDeleteResponse, options["Delete"]...).Endpoint()
+	}
+
 	return endpoint1.Endpoints{
+		DeleteEndpoint:    deleteEndpoint,
+		GetEndpoint:       getEndpoint,
 		GetFromToEndpoint: getFromToEndpoint,
+		QueryEndpoint:     queryEndpoint,
 		StoreEndpoint:     storeEndpoint,
 	}, nil
 }
@@ -79,6 +97,64 @@ func decodeGetFromToResponse(_ context.Context, r *http1.Response) (interface{},
 	err := json.NewDecoder(r.Body).Decode(&resp)
 	return resp, err
 }
+// encodeQueryRequest is a transport/http.EncodeRequestFunc that encodes the
+// filter of a QueryRequest as URL query parameters.
+func encodeQueryRequest(_ context.Context, r *http1.Request, request interface{}) error {
+	r.URL.RawQuery = http2.EncodeQueryFilter(request.(endpoint1.QueryRequest).Filter).Encode()
+	return nil
+}
+
+// decodeQueryResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeQueryResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.QueryResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+// encodeEntryIDRequest is a transport/http.EncodeRequestFunc that appends the
+// entry ID of a GetRequest or DeleteRequest to the request path.
+func encodeEntryIDRequest(_ context.Context, r *http1.Request, request interface{}) error {
+	var id string
+	switch req := request.(type) {
+	case endpoint1.GetRequest:
+		id = req.ID
+	case endpoint1.DeleteRequest:
+		id = req.ID
+	}
+	r.URL.Path += url.PathEscape(id)
+	return nil
+}
+
+// decodeGetResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeGetResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.GetResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// decodeDeleteResponse is a transport/http.DecodeResponseFunc that decodes
+// a JSON-encoded concat response from the HTTP response body. If the response
+// as a non-200 status code, we will interpret that as an error and attempt to
+//  decode the specific error message from the response body.
+func decodeDeleteResponse(_ context.Context, r *http1.Response) (interface{}, error) {
+	if r.StatusCode != http1.StatusOK {
+		return nil, http2.ErrorDecoder(r)
+	}
+	var resp endpoint1.DeleteResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
 func copyURL(base *url.URL, path string) (next *url.URL) {
 	n := *base
 	n.Path = path
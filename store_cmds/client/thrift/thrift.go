@@ -0,0 +1,165 @@
+package thrift
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	thrift "github.com/apache/thrift/lib/go/thrift"
+	auth "github.com/gigi214/services_example/pkg/auth"
+	endpoint1 "github.com/gigi214/services_example/store_cmds/pkg/endpoint"
+	service "github.com/gigi214/services_example/store_cmds/pkg/service"
+	thrift1 "github.com/gigi214/services_example/store_cmds/pkg/thrift"
+	storecmds "github.com/gigi214/services_example/store_cmds/pkg/thrift/gen-go/storecmds"
+	endpoint "github.com/go-kit/kit/endpoint"
+)
+
+// Dial opens a connection to the Thrift server at addr, with the protocol,
+// buffer size and framing the server uses. The caller is responsible for
+// closing the returned transport.
+func Dial(addr, protocol string, buffer int, framed bool) (*storecmds.StoreCmdsServiceClient, thrift.TTransport, error) {
+	protocolFactory, err := thrift1.NewProtocolFactory(protocol)
+	if err != nil {
+		return nil, nil, err
+	}
+	socket, err := thrift.NewTSocket(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	transport, err := thrift1.NewTransportFactory(buffer, framed).GetTransport(socket)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = transport.Open(); err != nil {
+		return nil, nil, err
+	}
+	return storecmds.NewStoreCmdsServiceClientFactory(transport, protocolFactory), transport, nil
+}
+
+// New returns a StoreCmdsService backed by a Thrift server at the other end of
+// the client. A Thrift client holds a single connection, the calls are
+// serialized. The credentials are sent in the headers of the requests, with
+// the header protocol.
+func New(client *storecmds.StoreCmdsServiceClient, credentials auth.Credentials) (service.StoreCmdsService, error) {
+	var mtx sync.Mutex
+	serialize := func(e endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			ctx, err := thrift1.CredentialsToContext(ctx, credentials)
+			if err != nil {
+				return nil, err
+			}
+			mtx.Lock()
+			defer mtx.Unlock()
+			return e(ctx, request)
+		}
+	}
+	return endpoint1.Endpoints{
+		DeleteEndpoint:    serialize(makeDeleteEndpoint(client)),
+		GetEndpoint:       serialize(makeGetEndpoint(client)),
+		GetFromToEndpoint: serialize(makeGetFromToEndpoint(client)),
+		QueryEndpoint:     serialize(makeQueryEndpoint(client)),
+		StoreEndpoint:     serialize(makeStoreEndpoint(client)),
+	}, nil
+}
+
+func makeStoreEndpoint(client *storecmds.StoreCmdsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		r := request.(endpoint1.StoreRequest)
+		reply, err := client.Store(ctx, thrift1.EntryToThrift(&service.CmdExecutedEntry{
+			Cmd:             r.Cmd,
+			TimestampExec:   r.TimestampExec,
+			FinishedAt:      r.FinishedAt,
+			Success:         r.Success,
+			ExitCode:        r.ExitCode,
+			Stdout:          r.Stdout,
+			Stderr:          r.Stderr,
+			Duration:        r.Duration,
+			UserTime:        r.UserTime,
+			SystemTime:      r.SystemTime,
+			PeakRSS:         r.PeakRSS,
+			Host:            r.Host,
+			InstanceID:      r.InstanceID,
+			IdempotencyKey:  r.IdempotencyKey,
+			Principal:       r.Principal,
+			Cwd:             r.Cwd,
+			Env:             r.Env,
+			CleanEnv:        r.CleanEnv,
+			Stdin:           r.Stdin,
+			LimitExceeded:   r.LimitExceeded,
+			StdoutBytes:     r.StdoutBytes,
+			StdoutTruncated: r.StdoutTruncated,
+			StderrBytes:     r.StderrBytes,
+			StderrTruncated: r.StderrTruncated,
+			BatchID:         r.BatchID,
+			BatchStep:       r.BatchStep,
+			ScheduleID:      r.ScheduleID,
+			TemplateName:    r.TemplateName,
+			TemplateParams:  r.TemplateParams,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return endpoint1.StoreResponse{ID: reply.ID, Err: replyErr(reply.Err)}, nil
+	}
+}
+
+func makeGetFromToEndpoint(client *storecmds.StoreCmdsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		r := request.(endpoint1.GetFromToRequest)
+		reply, err := client.GetFromTo(ctx, thrift1.UnixNano(r.From), thrift1.UnixNano(r.To))
+		if err != nil {
+			return nil, err
+		}
+		return endpoint1.GetFromToResponse{Res: entriesFromThrift(reply.Res), Err: replyErr(reply.Err)}, nil
+	}
+}
+
+func makeQueryEndpoint(client *storecmds.StoreCmdsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		r := request.(endpoint1.QueryRequest)
+		reply, err := client.Query(ctx, thrift1.FilterToThrift(r.Filter))
+		if err != nil {
+			return nil, err
+		}
+		return endpoint1.QueryResponse{
+			Res:        entriesFromThrift(reply.Res),
+			NextCursor: reply.NextCursor,
+			Err:        replyErr(reply.Err),
+		}, nil
+	}
+}
+
+func makeGetEndpoint(client *storecmds.StoreCmdsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reply, err := client.Get(ctx, request.(endpoint1.GetRequest).ID)
+		if err != nil {
+			return nil, err
+		}
+		return endpoint1.GetResponse{Entry: thrift1.EntryFromThrift(reply.Entry), Err: replyErr(reply.Err)}, nil
+	}
+}
+
+func makeDeleteEndpoint(client *storecmds.StoreCmdsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reply, err := client.Delete(ctx, request.(endpoint1.DeleteRequest).ID)
+		if err != nil {
+			return nil, err
+		}
+		return endpoint1.DeleteResponse{Err: replyErr(reply.Err)}, nil
+	}
+}
+
+func entriesFromThrift(entries []*storecmds.CmdExecutedEntry) []*service.CmdExecutedEntry {
+	res := make([]*service.CmdExecutedEntry, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, thrift1.EntryFromThrift(e))
+	}
+	return res
+}
+
+func replyErr(s string) error {
+	if s == "" {
+		return nil
+	}
+	return errors.New(s)
+}
@@ -10,12 +10,20 @@ import (
 	"os/signal"
 	"syscall"
 
+	thrift "github.com/apache/thrift/lib/go/thrift"
+	auth "github.com/gigi214/services_example/pkg/auth"
 	endpoint "github.com/gigi214/services_example/store_cmds/pkg/endpoint"
+	grpc "github.com/gigi214/services_example/store_cmds/pkg/grpc"
+	pb "github.com/gigi214/services_example/store_cmds/pkg/grpc/pb"
 	http1 "github.com/gigi214/services_example/store_cmds/pkg/http"
 	service "github.com/gigi214/services_example/store_cmds/pkg/service"
+	thrift1 "github.com/gigi214/services_example/store_cmds/pkg/thrift"
+	storecmds "github.com/gigi214/services_example/store_cmds/pkg/thrift/gen-go/storecmds"
 	endpoint1 "github.com/go-kit/kit/endpoint"
 	log "github.com/go-kit/kit/log"
 	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	httptransport "github.com/go-kit/kit/transport/http"
 	lightsteptracergo "github.com/lightstep/lightstep-tracer-go"
 	group "github.com/oklog/oklog/pkg/group"
 	opentracinggo "github.com/opentracing/opentracing-go"
@@ -24,23 +32,38 @@ import (
 	http "github.com/openzipkin/zipkin-go/reporter/http"
 	prometheus1 "github.com/prometheus/client_golang/prometheus"
 	promhttp "github.com/prometheus/client_golang/prometheus/promhttp"
+	grpc1 "google.golang.org/grpc"
 	appdash "sourcegraph.com/sourcegraph/appdash"
 	opentracing "sourcegraph.com/sourcegraph/appdash/opentracing"
 )
 
 var tracer opentracinggo.Tracer
 var logger log.Logger
+var authenticator *auth.Authenticator
+var rbac *endpoint.RBAC
 
 // Define our flags
 var fs = flag.NewFlagSet("store_cmds", flag.ExitOnError)
 var debugAddr = fs.String("debug-addr", ":8080", "Debug and metrics listen address")
 var httpAddr = fs.String("http-addr", ":8081", "HTTP listen address")
+var grpcAddr = fs.String("grpc-addr", ":8082", "gRPC listen address")
+var thriftAddr = fs.String("thrift-addr", ":8083", "Thrift listen address")
+var thriftProtocol = fs.String("thrift-protocol", "binary", "binary, compact, json, simplejson, or header, the only one that carries credentials, with its own framing")
+var thriftBuffer = fs.Int("thrift-buffer", 0, "0 for unbuffered")
+var thriftFramed = fs.Bool("thrift-framed", false, "true to enable framing")
+var authKeysFile = fs.String("auth-keys-file", "", "YAML or JSON file with the API keys and HMAC secrets of the clients allowed to store history")
+var authJWKSFile = fs.String("auth-jwks-file", "", "JSON Web Key Set that verifies the JWT bearer tokens of the clients allowed to store history")
+var authJWTIssuer = fs.String("auth-jwt-issuer", "", "Issuer the JWT bearer tokens must have, any when empty")
+var authJWTAudience = fs.String("auth-jwt-audience", "", "Audience the JWT bearer tokens must have, any when empty")
+var rbacFile = fs.String("rbac-file", "", "YAML or JSON file that binds the principals to the writer, reader and admin roles, empty to let every caller, authenticated when keys are set, store and read, but not delete")
 
 // var debugAddr = fs.String("debug-addr", ":8082", "Debug and metrics listen address")
 // var httpAddr = fs.String("http-addr", ":8083", "HTTP listen address")
 var zipkinURL = fs.String("zipkin-url", "", "Enable Zipkin tracing via a collector URL e.g. http://localhost:9411/api/v1/spans")
 var lightstepToken = fs.String("lightstep-token", "", "Enable LightStep tracing via a LightStep access token")
 var appdashAddr = fs.String("appdash-addr", "", "Enable Appdash tracing via an Appdash server host:port")
+var repoKind = fs.String("repo", "memory", "Repository that stores the history of the commands: memory, sqlite")
+var dbPath = fs.String("db-path", "store_cmds.db", "Path of the SQLite database, used with -repo=sqlite")
 
 func Run() {
 	fs.Parse(os.Args[1:])
@@ -82,8 +105,34 @@ func Run() {
 		tracer = opentracinggo.GlobalTracer()
 	}
 
-	// Add Repository, with some changes it could be also a connection to a DBMS
-	repository, _ := service.NewInMemRepository()
+	repository, err := newRepository()
+	if err != nil {
+		logger.Log("repo", *repoKind, "err", err)
+		os.Exit(1)
+	}
+
+	// Without credentials to check, anyone can store history.
+	authCfg := auth.Config{KeysFile: *authKeysFile, JWKSFile: *authJWKSFile, Issuer: *authJWTIssuer, Audience: *authJWTAudience}
+	if authCfg.Enabled() {
+		if authenticator, err = auth.New(authCfg); err != nil {
+			logger.Log("auth", "enabled", "err", err)
+			os.Exit(1)
+		}
+		logger.Log("auth", "enabled", "keys", *authKeysFile, "jwks", *authJWKSFile)
+	} else {
+		logger.Log("auth", "disabled")
+	}
+	if *rbacFile != "" {
+		if authenticator == nil {
+			logger.Log("rbac", *rbacFile, "err", "RBAC needs -auth-keys-file or -auth-jwks-file to identify the principals")
+			os.Exit(1)
+		}
+		if rbac, err = endpoint.LoadRBAC(*rbacFile); err != nil {
+			logger.Log("rbac", *rbacFile, "err", err)
+			os.Exit(1)
+		}
+		logger.Log("rbac", *rbacFile)
+	}
 
 	svc := service.New(repository, getServiceMiddleware(logger))
 	eps := endpoint.New(svc, getEndpointMiddleware(logger))
@@ -93,9 +142,25 @@ func Run() {
 	logger.Log("exit", g.Run())
 
 }
+// newRepository returns the Repository selected by the -repo flag.
+func newRepository() (service.Repository, error) {
+	switch *repoKind {
+	case "memory":
+		return service.NewInMemRepository()
+	case "sqlite":
+		logger.Log("repo", "sqlite", "path", *dbPath)
+		return service.NewSQLiteRepository(*dbPath)
+	}
+	return nil, fmt.Errorf("unknown repository %q", *repoKind)
+}
 func initHttpHandler(endpoints endpoint.Endpoints, g *group.Group) {
 	options := defaultHttpOptions(logger, tracer)
 	// Add your http options here
+	if authenticator != nil {
+		for method := range options {
+			options[method] = append(options[method], httptransport.ServerBefore(http1.AuthToContext(authenticator)))
+		}
+	}
 
 	httpHandler := http1.NewHTTPHandler(endpoints, options)
 	httpListener, err := net.Listen("tcp", *httpAddr)
@@ -109,6 +174,58 @@ func initHttpHandler(endpoints endpoint.Endpoints, g *group.Group) {
 		httpListener.Close()
 	})
 
+}
+func initGRPCHandler(endpoints endpoint.Endpoints, g *group.Group) {
+	options := defaultGRPCOptions(logger, tracer)
+	// Add your GRPC options here
+	if authenticator != nil {
+		for method := range options {
+			options[method] = append(options[method], grpctransport.ServerBefore(grpc.AuthToContext(authenticator)))
+		}
+	}
+
+	grpcServer := grpc.NewGRPCServer(endpoints, options)
+	grpcListener, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		logger.Log("transport", "gRPC", "during", "Listen", "err", err)
+	}
+	g.Add(func() error {
+		logger.Log("transport", "gRPC", "addr", *grpcAddr)
+		baseServer := grpc1.NewServer()
+		pb.RegisterStoreCmdsServer(baseServer, grpcServer)
+		return baseServer.Serve(grpcListener)
+	}, func(error) {
+		grpcListener.Close()
+	})
+
+}
+func initThriftHandler(endpoints endpoint.Endpoints, g *group.Group) {
+	protocolFactory, err := thrift1.NewProtocolFactory(*thriftProtocol)
+	if err != nil {
+		logger.Log("transport", "Thrift", "during", "NewProtocolFactory", "err", err)
+		os.Exit(1)
+	}
+	transportFactory := thrift1.NewTransportFactory(*thriftBuffer, *thriftFramed)
+	thriftSocket, err := thrift.NewTServerSocket(*thriftAddr)
+	if err != nil {
+		logger.Log("transport", "Thrift", "during", "Listen", "err", err)
+	}
+	var processor thrift.TProcessor = storecmds.NewStoreCmdsServiceProcessor(thrift1.NewThriftServer(endpoints))
+	if authenticator != nil {
+		processor = thrift1.AuthProcessor(processor, authenticator)
+	}
+	g.Add(func() error {
+		logger.Log("transport", "Thrift", "addr", *thriftAddr, "protocol", *thriftProtocol, "buffer", *thriftBuffer, "framed", *thriftFramed)
+		return thrift.NewTSimpleServer4(
+			processor,
+			thriftSocket,
+			transportFactory,
+			protocolFactory,
+		).Serve()
+	}, func(error) {
+		thriftSocket.Close()
+	})
+
 }
 func getServiceMiddleware(logger log.Logger) (mw []service.Middleware) {
 	mw = []service.Middleware{}
@@ -127,6 +244,7 @@ func getEndpointMiddleware(logger log.Logger) (mw map[string][]endpoint1.Middlew
 	}, []string{"method", "success"})
 	addDefaultEndpointMiddleware(logger, duration, mw)
 	// Add you endpoint middleware here
+	endpoint.AddAccessMiddleware(mw, authenticator != nil, rbac)
 
 	return
 }
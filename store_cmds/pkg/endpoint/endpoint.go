@@ -2,29 +2,64 @@ package endpoint
 
 import (
 	"context"
-	"errors"
+	"net/http"
 	"time"
 
+	errs "github.com/gigi214/services_example/pkg/errs"
 	service "github.com/gigi214/services_example/store_cmds/pkg/service"
 	endpoint "github.com/go-kit/kit/endpoint"
 )
 
 // StoreRequest collects the request parameters for the Store method.
 type StoreRequest struct {
-	TimestampExec time.Time `json:"timestamp_exec"`
-	Cmd           string    `json:"cmd"`
-	Success       bool      `json:"success"`
-	ExitCode      int       `json:"exit_code"`
-	Stdout        string    `json:"stdout"`
-	Stderr        string    `json:"stderr"`
+	TimestampExec time.Time     `json:"timestamp_exec"`
+	Cmd           string        `json:"cmd"`
+	Success       bool          `json:"success"`
+	ExitCode      int           `json:"exit_code"`
+	Stdout        string        `json:"stdout"`
+	Stderr        string        `json:"stderr"`
+	Host          string        `json:"host"`
+	FinishedAt    time.Time     `json:"finished_at"`
+	Duration      time.Duration `json:"duration_ns"`
+	UserTime      time.Duration `json:"user_time_ns"`
+	SystemTime    time.Duration `json:"system_time_ns"`
+	PeakRSS       int64         `json:"peak_rss_bytes"`
+	InstanceID    string        `json:"instance_id"`
+	Principal     string        `json:"principal,omitempty"`
+	// Cwd, Env, CleanEnv and Stdin are the environment the command ran in,
+	// see service.CmdExecutedEntry.
+	Cwd      string            `json:"cwd,omitempty"`
+	Env      map[string]string `json:"env,omitempty"`
+	CleanEnv bool              `json:"clean_env,omitempty"`
+	Stdin    []byte            `json:"stdin,omitempty"`
+	// LimitExceeded is the limit that stopped the command, see service.CmdExecutedEntry.
+	LimitExceeded string `json:"limit_exceeded,omitempty"`
+	// StdoutBytes and StderrBytes are the sizes of the whole output, the
+	// sizes of Stdout and Stderr when zero.
+	StdoutBytes     int64 `json:"stdout_bytes,omitempty"`
+	StdoutTruncated bool  `json:"stdout_truncated,omitempty"`
+	StderrBytes     int64 `json:"stderr_bytes,omitempty"`
+	StderrTruncated bool  `json:"stderr_truncated,omitempty"`
+	// BatchID and BatchStep link the execution to a step of a batch.
+	BatchID   string `json:"batch_id,omitempty"`
+	BatchStep string `json:"batch_step,omitempty"`
+	// ScheduleID links the execution to the schedule that ran it.
+	ScheduleID string `json:"schedule_id,omitempty"`
+	// TemplateName and TemplateParams are the command template the command
+	// was rendered from and its parameters.
+	TemplateName   string            `json:"template_name,omitempty"`
+	TemplateParams map[string]string `json:"template_params,omitempty"`
+	// IdempotencyKey lets a client send the same entry again, until it gets a response.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // StoreResponse collects the response parameters for the Store method.
 type StoreResponse struct {
-	Err error `json:"err"`
+	ID  string `json:"id"`
+	Err error  `json:"err"`
 }
 
-var ErrInvalidInput = errors.New("invalid inputs")
+var ErrInvalidInput = errs.New(http.StatusBadRequest, "invalid_input", "invalid inputs")
 
 // MakeStoreEndpoint returns an endpoint that invokes Store on the service.
 func MakeStoreEndpoint(s service.StoreCmdsService) endpoint.Endpoint {
@@ -34,8 +69,41 @@ func MakeStoreEndpoint(s service.StoreCmdsService) endpoint.Endpoint {
 		}
 
 		req := request.(StoreRequest)
-		err := s.Store(ctx, req.TimestampExec, req.Cmd, req.Success, req.ExitCode, req.Stdout, req.Stderr)
-		return StoreResponse{Err: err}, nil
+		id, err := s.Store(ctx, service.CmdExecutedEntry{
+			Cmd:             req.Cmd,
+			TimestampExec:   req.TimestampExec,
+			Success:         req.Success,
+			ExitCode:        req.ExitCode,
+			Stdout:          req.Stdout,
+			Stderr:          req.Stderr,
+			Host:            req.Host,
+			IdempotencyKey:  req.IdempotencyKey,
+			FinishedAt:      req.FinishedAt,
+			Duration:        req.Duration,
+			UserTime:        req.UserTime,
+			SystemTime:      req.SystemTime,
+			PeakRSS:         req.PeakRSS,
+			InstanceID:      req.InstanceID,
+			Principal:       req.Principal,
+			Cwd:             req.Cwd,
+			Env:             req.Env,
+			CleanEnv:        req.CleanEnv,
+			Stdin:           req.Stdin,
+			LimitExceeded:   req.LimitExceeded,
+			StdoutBytes:     req.StdoutBytes,
+			StdoutTruncated: req.StdoutTruncated,
+			StderrBytes:     req.StderrBytes,
+			StderrTruncated: req.StderrTruncated,
+			BatchID:         req.BatchID,
+			BatchStep:       req.BatchStep,
+			ScheduleID:      req.ScheduleID,
+			TemplateName:    req.TemplateName,
+			TemplateParams:  req.TemplateParams,
+		})
+		return StoreResponse{
+			Err: err,
+			ID:  id,
+		}, nil
 	}
 }
 
@@ -52,20 +120,43 @@ type Failure interface {
 }
 
 // Store implements Service. Primarily useful in a client.
-func (e Endpoints) Store(ctx context.Context, timestamp_exec time.Time, cmd string, success bool, exit_code int, stdout string, stderr string) (err error) {
+func (e Endpoints) Store(ctx context.Context, entry service.CmdExecutedEntry) (id string, err error) {
 	request := StoreRequest{
-		Cmd:           cmd,
-		ExitCode:      exit_code,
-		Stderr:        stderr,
-		Stdout:        stdout,
-		Success:       success,
-		TimestampExec: timestamp_exec,
+		Cmd:             entry.Cmd,
+		ExitCode:        entry.ExitCode,
+		Host:            entry.Host,
+		IdempotencyKey:  entry.IdempotencyKey,
+		FinishedAt:      entry.FinishedAt,
+		Duration:        entry.Duration,
+		UserTime:        entry.UserTime,
+		SystemTime:      entry.SystemTime,
+		PeakRSS:         entry.PeakRSS,
+		InstanceID:      entry.InstanceID,
+		Principal:       entry.Principal,
+		Cwd:             entry.Cwd,
+		Env:             entry.Env,
+		CleanEnv:        entry.CleanEnv,
+		Stdin:           entry.Stdin,
+		LimitExceeded:   entry.LimitExceeded,
+		StdoutBytes:     entry.StdoutBytes,
+		StdoutTruncated: entry.StdoutTruncated,
+		StderrBytes:     entry.StderrBytes,
+		StderrTruncated: entry.StderrTruncated,
+		BatchID:         entry.BatchID,
+		BatchStep:       entry.BatchStep,
+		ScheduleID:      entry.ScheduleID,
+		TemplateName:    entry.TemplateName,
+		TemplateParams:  entry.TemplateParams,
+		Stderr:          entry.Stderr,
+		Stdout:          entry.Stdout,
+		Success:         entry.Success,
+		TimestampExec:   entry.TimestampExec,
 	}
 	response, err := e.StoreEndpoint(ctx, request)
 	if err != nil {
 		return
 	}
-	return response.(StoreResponse).Err
+	return response.(StoreResponse).ID, response.(StoreResponse).Err
 }
 
 // GetFromToRequest collects the request parameters for the GetFromTo method.
@@ -109,3 +200,115 @@ func (e Endpoints) GetFromTo(ctx context.Context, from time.Time, to time.Time)
 	}
 	return response.(GetFromToResponse).Res, response.(GetFromToResponse).Err
 }
+
+// QueryRequest collects the request parameters for the Query method.
+type QueryRequest struct {
+	Filter service.QueryFilter `json:"filter"`
+}
+
+// QueryResponse collects the response parameters for the Query method.
+type QueryResponse struct {
+	Res        []*service.CmdExecutedEntry `json:"res"`
+	NextCursor string                      `json:"next_cursor,omitempty"`
+	Err        error                       `json:"err"`
+}
+
+// MakeQueryEndpoint returns an endpoint that invokes Query on the service.
+func MakeQueryEndpoint(s service.StoreCmdsService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(QueryRequest)
+		res, nextCursor, err := s.Query(ctx, req.Filter)
+		return QueryResponse{
+			Err:        err,
+			NextCursor: nextCursor,
+			Res:        res,
+		}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r QueryResponse) Failed() error {
+	return r.Err
+}
+
+// Query implements Service. Primarily useful in a client.
+func (e Endpoints) Query(ctx context.Context, filter service.QueryFilter) (res []*service.CmdExecutedEntry, nextCursor string, err error) {
+	request := QueryRequest{Filter: filter}
+	response, err := e.QueryEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	return response.(QueryResponse).Res, response.(QueryResponse).NextCursor, response.(QueryResponse).Err
+}
+
+// GetRequest collects the request parameters for the Get method.
+type GetRequest struct {
+	ID string `json:"id"`
+}
+
+// GetResponse collects the response parameters for the Get method.
+type GetResponse struct {
+	Entry *service.CmdExecutedEntry `json:"entry"`
+	Err   error                     `json:"err"`
+}
+
+// MakeGetEndpoint returns an endpoint that invokes Get on the service.
+func MakeGetEndpoint(s service.StoreCmdsService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetRequest)
+		entry, err := s.Get(ctx, req.ID)
+		return GetResponse{
+			Entry: entry,
+			Err:   err,
+		}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r GetResponse) Failed() error {
+	return r.Err
+}
+
+// Get implements Service. Primarily useful in a client.
+func (e Endpoints) Get(ctx context.Context, id string) (entry *service.CmdExecutedEntry, err error) {
+	request := GetRequest{ID: id}
+	response, err := e.GetEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	return response.(GetResponse).Entry, response.(GetResponse).Err
+}
+
+// DeleteRequest collects the request parameters for the Delete method.
+type DeleteRequest struct {
+	ID string `json:"id"`
+}
+
+// DeleteResponse collects the response parameters for the Delete method.
+type DeleteResponse struct {
+	Err error `json:"err"`
+}
+
+// MakeDeleteEndpoint returns an endpoint that invokes Delete on the service.
+func MakeDeleteEndpoint(s service.StoreCmdsService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteRequest)
+		err := s.Delete(ctx, req.ID)
+		return DeleteResponse{Err: err}, nil
+	}
+}
+
+// Failed implements Failer.
+func (r DeleteResponse) Failed() error {
+	return r.Err
+}
+
+// Delete implements Service. Primarily useful in a client.
+func (e Endpoints) Delete(ctx context.Context, id string) (err error) {
+	request := DeleteRequest{ID: id}
+	response, err := e.DeleteEndpoint(ctx, request)
+	if err != nil {
+		return
+	}
+	return response.(DeleteResponse).Err
+}
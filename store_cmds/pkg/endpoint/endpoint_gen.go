@@ -12,13 +12,19 @@ import (
 type Endpoints struct {
 	StoreEndpoint     endpoint.Endpoint
 	GetFromToEndpoint endpoint.Endpoint
+	QueryEndpoint     endpoint.Endpoint
+	GetEndpoint       endpoint.Endpoint
+	DeleteEndpoint    endpoint.Endpoint
 }
 
 // New returns a Endpoints struct that wraps the provided service, and wires in all of the
 // expected endpoint middlewares
 func New(s service.StoreCmdsService, mdw map[string][]endpoint.Middleware) Endpoints {
 	eps := Endpoints{
+		DeleteEndpoint:    MakeDeleteEndpoint(s),
+		GetEndpoint:       MakeGetEndpoint(s),
 		GetFromToEndpoint: MakeGetFromToEndpoint(s),
+		QueryEndpoint:     MakeQueryEndpoint(s),
 		StoreEndpoint:     MakeStoreEndpoint(s),
 	}
 	for _, m := range mdw["Store"] {
@@ -27,5 +33,14 @@ func New(s service.StoreCmdsService, mdw map[string][]endpoint.Middleware) Endpo
 	for _, m := range mdw["GetFromTo"] {
 		eps.GetFromToEndpoint = m(eps.GetFromToEndpoint)
 	}
+	for _, m := range mdw["Query"] {
+		eps.QueryEndpoint = m(eps.QueryEndpoint)
+	}
+	for _, m := range mdw["Get"] {
+		eps.GetEndpoint = m(eps.GetEndpoint)
+	}
+	for _, m := range mdw["Delete"] {
+		eps.DeleteEndpoint = m(eps.DeleteEndpoint)
+	}
 	return eps
 }
@@ -0,0 +1,215 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	auth "github.com/gigi214/services_example/pkg/auth"
+	errs "github.com/gigi214/services_example/pkg/errs"
+	service "github.com/gigi214/services_example/store_cmds/pkg/service"
+	endpoint "github.com/go-kit/kit/endpoint"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var (
+	ErrForbidden   = errs.New(http.StatusForbidden, "forbidden", "forbidden")
+	ErrInvalidRBAC = errors.New("invalid RBAC config")
+)
+
+// Role is a set of permissions on the endpoints.
+type Role string
+
+const (
+	// RoleWriter stores history, it is given to the bash_exec instances.
+	RoleWriter Role = "writer"
+	// RoleReader reads the history of its own executions.
+	RoleReader Role = "reader"
+	// RoleAdmin reads and deletes all the history.
+	RoleAdmin Role = "admin"
+)
+
+// scope is the part of the history a role can access through a method,
+// a larger scope includes the smaller ones.
+type scope int
+
+const (
+	scopeNone scope = iota
+	scopeOwn
+	scopeAll
+)
+
+// permissions grants the roles access to each method. A method missing from
+// the table is denied to everyone, so that a new endpoint is not exposed until
+// it is given permissions.
+var permissions = map[string]map[Role]scope{
+	"Store":     {RoleWriter: scopeAll, RoleAdmin: scopeAll},
+	"GetFromTo": {RoleReader: scopeOwn, RoleAdmin: scopeAll},
+	"Query":     {RoleReader: scopeOwn, RoleAdmin: scopeAll},
+	"Get":       {RoleReader: scopeOwn, RoleAdmin: scopeAll},
+	"Delete":    {RoleAdmin: scopeAll},
+}
+
+// RoleBinding gives roles to a principal, see auth.Principal.
+type RoleBinding struct {
+	Principal string `json:"principal" yaml:"principal"`
+	Roles     []Role `json:"roles" yaml:"roles"`
+}
+
+// RBAC binds the principals to their roles.
+type RBAC struct {
+	Bindings []RoleBinding `json:"bindings" yaml:"bindings"`
+
+	roles map[string][]Role
+}
+
+// LoadRBAC reads the role bindings from a YAML or JSON file, chosen by its extension.
+func LoadRBAC(path string) (*RBAC, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &RBAC{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, r)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, r)
+	default:
+		err = fmt.Errorf("%w: unknown file extension of %s", ErrInvalidRBAC, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = r.compile(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RBAC) compile() error {
+	r.roles = map[string][]Role{}
+	for i, b := range r.Bindings {
+		if b.Principal == "" {
+			return fmt.Errorf("%w: binding %d has no principal", ErrInvalidRBAC, i)
+		}
+		for _, role := range b.Roles {
+			if role != RoleWriter && role != RoleReader && role != RoleAdmin {
+				return fmt.Errorf("%w: unknown role %q of %s", ErrInvalidRBAC, role, b.Principal)
+			}
+		}
+		r.roles[b.Principal] = append(r.roles[b.Principal], b.Roles...)
+	}
+	return nil
+}
+
+// scope returns the largest scope the roles of principal give on method.
+func (r *RBAC) scope(method, principal string) scope {
+	s := scopeNone
+	for _, role := range r.roles[principal] {
+		if rs := permissions[method][role]; rs > s {
+			s = rs
+		}
+	}
+	return s
+}
+
+// AuthorizationMiddleware returns an endpoint middleware that lets through the
+// requests to method whose principal has a role that allows it, and restricts
+// the ones limited to their own executions to the entries of the principal.
+// The principal is the one authenticated by the transport, see auth.ToContext.
+func AuthorizationMiddleware(rbac *RBAC, method string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			p, err := auth.FromContext(ctx)
+			if err != nil {
+				return nil, err
+			}
+			switch rbac.scope(method, p.ID) {
+			case scopeAll:
+				return next(ctx, request)
+			case scopeOwn:
+				return ownEntries(ctx, next, request, p.ID)
+			}
+			return nil, fmt.Errorf("%w: %s is not allowed to %s", ErrForbidden, p.ID, method)
+		}
+	}
+}
+
+// DenyMiddleware returns an endpoint middleware that forbids method to every
+// principal, for the methods that need a role when there is no RBAC.
+func DenyMiddleware(method string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, fmt.Errorf("%w: %s needs an RBAC role", ErrForbidden, method)
+		}
+	}
+}
+
+// AddAccessMiddleware appends to mw, the middleware of each method, the ones
+// that control the access to the history. The requests must be authenticated
+// when authenticated is true, and are authorized by rbac. Without an RBAC, the
+// Delete method is denied to everyone, authenticated or not, since no one has
+// the admin role.
+func AddAccessMiddleware(mw map[string][]endpoint.Middleware, authenticated bool, rbac *RBAC) {
+	if authenticated {
+		// Every method needs credentials. Thrift carries them with the header
+		// protocol only, the requests of the other protocols are all rejected.
+		for method := range mw {
+			mw[method] = append(mw[method], auth.Middleware())
+		}
+	}
+	if rbac == nil {
+		mw["Delete"] = append(mw["Delete"], DenyMiddleware("Delete"))
+		return
+	}
+	// Every method has the default middleware, the methods missing from the
+	// RBAC permissions are denied.
+	for method := range mw {
+		mw[method] = append(mw[method], AuthorizationMiddleware(rbac, method))
+	}
+}
+
+// ownEntries calls next with request limited to the entries of principal.
+// Requests that cannot be limited are forbidden.
+func ownEntries(ctx context.Context, next endpoint.Endpoint, request interface{}, principal string) (interface{}, error) {
+	switch req := request.(type) {
+	case QueryRequest:
+		if req.Filter.Principal != "" && req.Filter.Principal != principal {
+			return nil, fmt.Errorf("%w: %s can only query its own executions", ErrForbidden, principal)
+		}
+		req.Filter.Principal = principal
+		return next(ctx, req)
+	case GetFromToRequest:
+		response, err := next(ctx, req)
+		if err != nil {
+			return response, err
+		}
+		resp := response.(GetFromToResponse)
+		own := make([]*service.CmdExecutedEntry, 0, len(resp.Res))
+		for _, e := range resp.Res {
+			if e.Principal == principal {
+				own = append(own, e)
+			}
+		}
+		resp.Res = own
+		return resp, nil
+	case GetRequest:
+		response, err := next(ctx, req)
+		if err != nil {
+			return response, err
+		}
+		// Others' entries are not found rather than forbidden, not to reveal their IDs.
+		if resp := response.(GetResponse); resp.Entry != nil && resp.Entry.Principal != principal {
+			return GetResponse{Err: service.ErrNotFound}, nil
+		}
+		return response, nil
+	}
+	return nil, fmt.Errorf("%w: %T cannot be limited to the executions of %s", ErrForbidden, request, principal)
+}
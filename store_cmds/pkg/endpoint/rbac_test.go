@@ -0,0 +1,245 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	auth "github.com/gigi214/services_example/pkg/auth"
+	service "github.com/gigi214/services_example/store_cmds/pkg/service"
+	endpoint "github.com/go-kit/kit/endpoint"
+)
+
+const testRBAC = `
+bindings:
+  - principal: bash-exec
+    roles: [writer]
+  - principal: alice
+    roles: [reader]
+  - principal: bob
+    roles: [reader]
+  - principal: root
+    roles: [admin]
+`
+
+func writeRBAC(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newTestEndpoints returns the endpoints of an in-memory service, authorized
+// by testRBAC, and the IDs of the entries of alice and bob it holds.
+func newTestEndpoints(t *testing.T) (eps Endpoints, ids map[string][]string) {
+	t.Helper()
+	rbac, err := LoadRBAC(writeRBAC(t, "rbac.yaml", testRBAC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc, ids := newTestService(t)
+	mw := map[string][]endpoint.Middleware{}
+	for method := range permissions {
+		mw[method] = []endpoint.Middleware{AuthorizationMiddleware(rbac, method)}
+	}
+	return New(svc, mw), ids
+}
+
+// newTestService returns an in-memory service and the IDs of the entries of
+// alice and bob it holds.
+func newTestService(t *testing.T) (svc service.StoreCmdsService, ids map[string][]string) {
+	t.Helper()
+	repo, err := service.NewInMemRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc = service.New(repo, nil)
+	ids = map[string][]string{}
+	now := time.Now()
+	for i, principal := range []string{"alice", "bob", "alice"} {
+		id, err := svc.Store(context.Background(), service.CmdExecutedEntry{
+			TimestampExec: now.Add(time.Duration(i) * time.Second),
+			Cmd:           "id",
+			Principal:     principal,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[principal] = append(ids[principal], id)
+	}
+	return svc, ids
+}
+
+func withPrincipal(id string) context.Context {
+	return auth.ToContext(context.Background(), auth.Principal{ID: id, Method: auth.MethodAPIKey}, nil)
+}
+
+func entryIDs(entries []*service.CmdExecutedEntry) []string {
+	res := []string{}
+	for _, e := range entries {
+		res = append(res, e.ID)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func TestAuthorizationMiddleware(t *testing.T) {
+	eps, ids := newTestEndpoints(t)
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		endpoint endpoint.Endpoint
+		request  interface{}
+		err      error
+	}{
+		{name: "writer stores", ctx: withPrincipal("bash-exec"), endpoint: eps.StoreEndpoint, request: StoreRequest{Cmd: "ls", TimestampExec: time.Now()}},
+		{name: "reader cannot store", ctx: withPrincipal("alice"), endpoint: eps.StoreEndpoint, request: StoreRequest{Cmd: "ls", TimestampExec: time.Now()}, err: ErrForbidden},
+		{name: "writer cannot read", ctx: withPrincipal("bash-exec"), endpoint: eps.GetEndpoint, request: GetRequest{ID: ids["alice"][0]}, err: ErrForbidden},
+		{name: "reader cannot delete", ctx: withPrincipal("alice"), endpoint: eps.DeleteEndpoint, request: DeleteRequest{ID: ids["alice"][0]}, err: ErrForbidden},
+		{name: "reader cannot query others", ctx: withPrincipal("alice"), endpoint: eps.QueryEndpoint, request: QueryRequest{Filter: service.QueryFilter{Principal: "bob"}}, err: ErrForbidden},
+		{name: "principal without role", ctx: withPrincipal("mallory"), endpoint: eps.GetFromToEndpoint, request: GetFromToRequest{}, err: ErrForbidden},
+		{name: "unauthenticated", ctx: context.Background(), endpoint: eps.GetEndpoint, request: GetRequest{ID: ids["alice"][0]}, err: auth.ErrMissingCredentials},
+		{name: "failed authentication", ctx: auth.ToContext(context.Background(), auth.Principal{}, auth.ErrInvalidCredentials), endpoint: eps.StoreEndpoint, request: StoreRequest{}, err: auth.ErrInvalidCredentials},
+		{name: "admin deletes", ctx: withPrincipal("root"), endpoint: eps.DeleteEndpoint, request: DeleteRequest{ID: ids["bob"][0]}},
+		{name: "deny", ctx: withPrincipal("root"), endpoint: DenyMiddleware("Delete")(eps.DeleteEndpoint), request: DeleteRequest{ID: ids["alice"][0]}, err: ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.endpoint(tt.ctx, tt.request)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAddAccessMiddleware(t *testing.T) {
+	rbac, err := LoadRBAC(writeRBAC(t, "rbac.yaml", testRBAC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	anonymous := context.Background()
+	tests := []struct {
+		name          string
+		authenticated bool
+		rbac          *RBAC
+		ctx           context.Context
+		method        string
+		err           error
+	}{
+		{name: "unauthenticated stores", ctx: anonymous, method: "Store"},
+		{name: "unauthenticated reads", ctx: anonymous, method: "Get"},
+		{name: "unauthenticated cannot delete", ctx: anonymous, method: "Delete", err: ErrForbidden},
+		{name: "anonymous", authenticated: true, ctx: anonymous, method: "Get", err: auth.ErrMissingCredentials},
+		{name: "authenticated reads", authenticated: true, ctx: withPrincipal("alice"), method: "Get"},
+		{name: "authenticated cannot delete", authenticated: true, ctx: withPrincipal("root"), method: "Delete", err: ErrForbidden},
+		{name: "admin deletes", authenticated: true, rbac: rbac, ctx: withPrincipal("root"), method: "Delete"},
+		{name: "reader cannot delete", authenticated: true, rbac: rbac, ctx: withPrincipal("alice"), method: "Delete", err: ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, ids := newTestService(t)
+			mw := map[string][]endpoint.Middleware{}
+			for method := range permissions {
+				mw[method] = nil
+			}
+			AddAccessMiddleware(mw, tt.authenticated, tt.rbac)
+			eps := New(svc, mw)
+			id := ids["alice"][0]
+			var err error
+			switch tt.method {
+			case "Store":
+				_, err = eps.Store(tt.ctx, service.CmdExecutedEntry{Cmd: "ls", TimestampExec: time.Now()})
+			case "Get":
+				_, err = eps.Get(tt.ctx, id)
+			case "Delete":
+				err = eps.Delete(tt.ctx, id)
+				if _, gerr := svc.Get(context.Background(), id); (gerr == nil) != (err != nil) {
+					t.Errorf("Delete error = %v, but Get error = %v", err, gerr)
+				}
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("%s error = %v, want %v", tt.method, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestOwnEntries(t *testing.T) {
+	eps, ids := newTestEndpoints(t)
+	all := append(append([]string{}, ids["alice"]...), ids["bob"]...)
+	sort.Strings(all)
+	sort.Strings(ids["alice"])
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	tests := []struct {
+		principal string
+		want      []string
+	}{
+		{principal: "alice", want: ids["alice"]},
+		{principal: "bob", want: ids["bob"]},
+		{principal: "root", want: all},
+	}
+	for _, tt := range tests {
+		t.Run(tt.principal, func(t *testing.T) {
+			ctx := withPrincipal(tt.principal)
+			res, err := eps.GetFromTo(ctx, from, to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := entryIDs(res); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFromTo = %q, want %q", got, tt.want)
+			}
+			res, _, err = eps.Query(ctx, service.QueryFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := entryIDs(res); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query = %q, want %q", got, tt.want)
+			}
+			// The entries of the others are not found.
+			for _, id := range all {
+				_, err := eps.Get(ctx, id)
+				own := false
+				for _, w := range tt.want {
+					own = own || w == id
+				}
+				if own && err != nil {
+					t.Errorf("Get(%s) error = %v", id, err)
+				}
+				if !own && !errors.Is(err, service.ErrNotFound) {
+					t.Errorf("Get(%s) error = %v, want %v", id, err, service.ErrNotFound)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadRBAC(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr bool
+	}{
+		{name: "yaml", file: "rbac.yaml", content: testRBAC},
+		{name: "json", file: "rbac.json", content: `{"bindings":[{"principal":"root","roles":["admin"]}]}`},
+		{name: "unknown role", file: "rbac.yaml", content: "bindings:\n  - principal: root\n    roles: [owner]\n", wantErr: true},
+		{name: "no principal", file: "rbac.yaml", content: "bindings:\n  - roles: [admin]\n", wantErr: true},
+		{name: "unknown extension", file: "rbac.toml", content: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadRBAC(writeRBAC(t, tt.file, tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadRBAC error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
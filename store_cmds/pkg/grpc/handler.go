@@ -0,0 +1,169 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	auth "github.com/gigi214/services_example/pkg/auth"
+	endpoint "github.com/gigi214/services_example/store_cmds/pkg/endpoint"
+	pb "github.com/gigi214/services_example/store_cmds/pkg/grpc/pb"
+	service "github.com/gigi214/services_example/store_cmds/pkg/service"
+	grpc "github.com/go-kit/kit/transport/grpc"
+	metadata "google.golang.org/grpc/metadata"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuthToContext returns a transport/grpc.ServerRequestFunc that authenticates
+// the request with the API key or the bearer token of its metadata, like
+// the AuthToContext of the HTTP transport.
+func AuthToContext(a *auth.Authenticator) grpc.ServerRequestFunc {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		p, err := a.Metadata(md)
+		return auth.ToContext(ctx, p, err)
+	}
+}
+
+// makeStoreHandler creates the handler logic
+func makeStoreHandler(endpoints endpoint.Endpoints, options []grpc.ServerOption) grpc.Handler {
+	return grpc.NewServer(endpoints.StoreEndpoint, decodeStoreRequest, encodeStoreResponse, options...)
+}
+
+// decodeStoreRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain Store request.
+func decodeStoreRequest(_ context.Context, r interface{}) (interface{}, error) {
+	req := r.(*pb.StoreRequest)
+	return endpoint.StoreRequest{
+		TimestampExec:   asTime(req.TimestampExec),
+		Cmd:             req.Cmd,
+		Success:         req.Success,
+		ExitCode:        int(req.ExitCode),
+		Stdout:          req.Stdout,
+		Stderr:          req.Stderr,
+		Host:            req.Host,
+		FinishedAt:      asTime(req.FinishedAt),
+		Duration:        req.Duration.AsDuration(),
+		UserTime:        req.UserTime.AsDuration(),
+		SystemTime:      req.SystemTime.AsDuration(),
+		PeakRSS:         req.PeakRssBytes,
+		InstanceID:      req.InstanceId,
+		Principal:       req.Principal,
+		Cwd:             req.Cwd,
+		Env:             req.Env,
+		CleanEnv:        req.CleanEnv,
+		Stdin:           req.Stdin,
+		LimitExceeded:   req.LimitExceeded,
+		StdoutBytes:     req.StdoutBytes,
+		StdoutTruncated: req.StdoutTruncated,
+		StderrBytes:     req.StderrBytes,
+		StderrTruncated: req.StderrTruncated,
+		BatchID:         req.BatchId,
+		BatchStep:       req.BatchStep,
+		ScheduleID:      req.ScheduleId,
+		TemplateName:    req.TemplateName,
+		TemplateParams:  req.TemplateParams,
+		IdempotencyKey:  req.IdempotencyKey,
+	}, nil
+}
+
+// encodeStoreResponse is a transport/grpc.EncodeResponseFunc that converts
+// a user-domain response to a gRPC reply.
+func encodeStoreResponse(_ context.Context, r interface{}) (interface{}, error) {
+	resp := r.(endpoint.StoreResponse)
+	return &pb.StoreReply{Id: resp.ID, Err: errString(resp.Err)}, nil
+}
+func (g *grpcServer) Store(ctx context.Context, req *pb.StoreRequest) (*pb.StoreReply, error) {
+	_, rep, err := g.store.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*pb.StoreReply), nil
+}
+
+// makeGetFromToHandler creates the handler logic
+func makeGetFromToHandler(endpoints endpoint.Endpoints, options []grpc.ServerOption) grpc.Handler {
+	return grpc.NewServer(endpoints.GetFromToEndpoint, decodeGetFromToRequest, encodeGetFromToResponse, options...)
+}
+
+// decodeGetFromToRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain GetFromTo request.
+func decodeGetFromToRequest(_ context.Context, r interface{}) (interface{}, error) {
+	req := r.(*pb.GetFromToRequest)
+	return endpoint.GetFromToRequest{From: asTime(req.From), To: asTime(req.To)}, nil
+}
+
+// encodeGetFromToResponse is a transport/grpc.EncodeResponseFunc that converts
+// a user-domain response to a gRPC reply.
+func encodeGetFromToResponse(_ context.Context, r interface{}) (interface{}, error) {
+	resp := r.(endpoint.GetFromToResponse)
+	reply := &pb.GetFromToReply{Err: errString(resp.Err)}
+	for _, e := range resp.Res {
+		reply.Res = append(reply.Res, encodeEntry(e))
+	}
+	return reply, nil
+}
+func (g *grpcServer) GetFromTo(ctx context.Context, req *pb.GetFromToRequest) (*pb.GetFromToReply, error) {
+	_, rep, err := g.getFromTo.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*pb.GetFromToReply), nil
+}
+
+func encodeEntry(e *service.CmdExecutedEntry) *pb.CmdExecutedEntry {
+	return &pb.CmdExecutedEntry{
+		Id:              e.ID,
+		Cmd:             e.Cmd,
+		TimestampExec:   timestamp(e.TimestampExec),
+		Success:         e.Success,
+		ExitCode:        int32(e.ExitCode),
+		Stdout:          e.Stdout,
+		Stderr:          e.Stderr,
+		Host:            e.Host,
+		IdempotencyKey:  e.IdempotencyKey,
+		FinishedAt:      timestamp(e.FinishedAt),
+		Duration:        durationpb.New(e.Duration),
+		UserTime:        durationpb.New(e.UserTime),
+		SystemTime:      durationpb.New(e.SystemTime),
+		PeakRssBytes:    e.PeakRSS,
+		InstanceId:      e.InstanceID,
+		Principal:       e.Principal,
+		Cwd:             e.Cwd,
+		Env:             e.Env,
+		CleanEnv:        e.CleanEnv,
+		Stdin:           e.Stdin,
+		LimitExceeded:   e.LimitExceeded,
+		StdoutBytes:     e.StdoutBytes,
+		StdoutTruncated: e.StdoutTruncated,
+		StderrBytes:     e.StderrBytes,
+		StderrTruncated: e.StderrTruncated,
+		BatchId:         e.BatchID,
+		BatchStep:       e.BatchStep,
+		ScheduleId:      e.ScheduleID,
+		TemplateName:    e.TemplateName,
+		TemplateParams:  e.TemplateParams,
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// timestamp converts t to a protobuf Timestamp, nil for the zero time.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// asTime converts t to a time.Time, the zero time for nil.
+func asTime(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
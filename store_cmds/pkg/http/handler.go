@@ -3,10 +3,17 @@ package http
 import (
 	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	auth "github.com/gigi214/services_example/pkg/auth"
+	errs "github.com/gigi214/services_example/pkg/errs"
 	endpoint "github.com/gigi214/services_example/store_cmds/pkg/endpoint"
+	service "github.com/gigi214/services_example/store_cmds/pkg/service"
 	http1 "github.com/go-kit/kit/transport/http"
 )
 
@@ -19,11 +26,14 @@ func makeStoreHandler(m *http.ServeMux, endpoints endpoint.Endpoints, options []
 // JSON-encoded request from the HTTP request body.
 func decodeStoreRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := endpoint.StoreRequest{ExitCode: -999}
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil || req.ExitCode == -999 {
-		return nil, err
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
 	}
-	return req, err
+	if req.ExitCode == -999 {
+		// The endpoint rejects the entries without exit code, see ErrInvalidInput.
+		return nil, nil
+	}
+	return req, nil
 }
 
 // encodeStoreResponse is a transport/http.EncodeResponseFunc that encodes
@@ -47,8 +57,10 @@ func makeGetFromToHandler(m *http.ServeMux, endpoints endpoint.Endpoints, option
 // JSON-encoded request from the HTTP request body.
 func decodeGetFromToRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := endpoint.GetFromToRequest{}
-	err := json.NewDecoder(r.Body).Decode(&req)
-	return req, err
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, malformed(err)
+	}
+	return req, nil
 }
 
 // encodeGetFromToResponse is a transport/http.EncodeResponseFunc that encodes
@@ -62,24 +74,261 @@ func encodeGetFromToResponse(ctx context.Context, w http.ResponseWriter, respons
 	err = json.NewEncoder(w).Encode(response)
 	return
 }
+// makeQueryHandler creates the handler logic
+func makeQueryHandler(m *http.ServeMux, endpoints endpoint.Endpoints, options []http1.ServerOption) {
+	m.Handle("/history", http1.NewServer(endpoints.QueryEndpoint, decodeQueryRequest, encodeQueryResponse, options...))
+}
+
+// decodeQueryRequest is a transport/http.DecodeRequestFunc that decodes the
+// filter of a query from the URL query parameters: from, to, finished_from and
+// finished_to (RFC 3339), cmd, cmd_regex, exit_code, success, host, instance_id,
+// principal, batch_id, schedule_id, template_name, min_ and max_duration, user_time and system_time (Go durations),
+// min_ and max_peak_rss (bytes), order, limit and cursor.
+func decodeQueryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	f, err := DecodeQueryFilter(r.URL.Query())
+	return endpoint.QueryRequest{Filter: f}, err
+}
+
+// DecodeQueryFilter decodes a service.QueryFilter from URL query parameters.
+func DecodeQueryFilter(q url.Values) (f service.QueryFilter, err error) {
+	invalid := func(name string, err error) error {
+		return fmt.Errorf("%w: %s: %v", service.ErrInvalidQuery, name, err)
+	}
+	if v := q.Get("from"); v != "" {
+		if f.From, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return f, invalid("from", err)
+		}
+	}
+	if v := q.Get("to"); v != "" {
+		if f.To, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return f, invalid("to", err)
+		}
+	}
+	if v := q.Get("finished_from"); v != "" {
+		if f.FinishedFrom, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return f, invalid("finished_from", err)
+		}
+	}
+	if v := q.Get("finished_to"); v != "" {
+		if f.FinishedTo, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return f, invalid("finished_to", err)
+		}
+	}
+	for name, d := range map[string]*time.Duration{
+		"min_duration":    &f.MinDuration,
+		"max_duration":    &f.MaxDuration,
+		"min_user_time":   &f.MinUserTime,
+		"max_user_time":   &f.MaxUserTime,
+		"min_system_time": &f.MinSystemTime,
+		"max_system_time": &f.MaxSystemTime,
+	} {
+		if v := q.Get(name); v != "" {
+			if *d, err = time.ParseDuration(v); err != nil {
+				return f, invalid(name, err)
+			}
+		}
+	}
+	for name, n := range map[string]*int64{
+		"min_peak_rss": &f.MinPeakRSS,
+		"max_peak_rss": &f.MaxPeakRSS,
+	} {
+		if v := q.Get(name); v != "" {
+			if *n, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return f, invalid(name, err)
+			}
+		}
+	}
+	if v := q.Get("exit_code"); v != "" {
+		exitCode, err := strconv.Atoi(v)
+		if err != nil {
+			return f, invalid("exit_code", err)
+		}
+		f.ExitCode = &exitCode
+	}
+	if v := q.Get("success"); v != "" {
+		success, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, invalid("success", err)
+		}
+		f.Success = &success
+	}
+	if v := q.Get("limit"); v != "" {
+		if f.Limit, err = strconv.Atoi(v); err != nil {
+			return f, invalid("limit", err)
+		}
+	}
+	f.Cmd = q.Get("cmd")
+	f.CmdRegex = q.Get("cmd_regex")
+	f.Host = q.Get("host")
+	f.InstanceID = q.Get("instance_id")
+	f.Principal = q.Get("principal")
+	f.BatchID = q.Get("batch_id")
+	f.ScheduleID = q.Get("schedule_id")
+	f.TemplateName = q.Get("template_name")
+	f.Order = service.SortOrder(q.Get("order"))
+	f.Cursor = q.Get("cursor")
+	return f, nil
+}
+
+// EncodeQueryFilter encodes f as URL query parameters, the inverse of DecodeQueryFilter.
+func EncodeQueryFilter(f service.QueryFilter) url.Values {
+	q := url.Values{}
+	set := func(name, value string) {
+		if value != "" {
+			q.Set(name, value)
+		}
+	}
+	if !f.From.IsZero() {
+		q.Set("from", f.From.Format(time.RFC3339Nano))
+	}
+	if !f.To.IsZero() {
+		q.Set("to", f.To.Format(time.RFC3339Nano))
+	}
+	if !f.FinishedFrom.IsZero() {
+		q.Set("finished_from", f.FinishedFrom.Format(time.RFC3339Nano))
+	}
+	if !f.FinishedTo.IsZero() {
+		q.Set("finished_to", f.FinishedTo.Format(time.RFC3339Nano))
+	}
+	for name, d := range map[string]time.Duration{
+		"min_duration":    f.MinDuration,
+		"max_duration":    f.MaxDuration,
+		"min_user_time":   f.MinUserTime,
+		"max_user_time":   f.MaxUserTime,
+		"min_system_time": f.MinSystemTime,
+		"max_system_time": f.MaxSystemTime,
+	} {
+		if d != 0 {
+			q.Set(name, d.String())
+		}
+	}
+	if f.MinPeakRSS != 0 {
+		q.Set("min_peak_rss", strconv.FormatInt(f.MinPeakRSS, 10))
+	}
+	if f.MaxPeakRSS != 0 {
+		q.Set("max_peak_rss", strconv.FormatInt(f.MaxPeakRSS, 10))
+	}
+	if f.ExitCode != nil {
+		q.Set("exit_code", strconv.Itoa(*f.ExitCode))
+	}
+	if f.Success != nil {
+		q.Set("success", strconv.FormatBool(*f.Success))
+	}
+	if f.Limit != 0 {
+		q.Set("limit", strconv.Itoa(f.Limit))
+	}
+	set("cmd", f.Cmd)
+	set("cmd_regex", f.CmdRegex)
+	set("host", f.Host)
+	set("instance_id", f.InstanceID)
+	set("principal", f.Principal)
+	set("batch_id", f.BatchID)
+	set("schedule_id", f.ScheduleID)
+	set("template_name", f.TemplateName)
+	set("order", string(f.Order))
+	set("cursor", f.Cursor)
+	return q
+}
+
+// encodeQueryResponse is a transport/http.EncodeResponseFunc that encodes
+// the response as JSON to the response writer
+func encodeQueryResponse(ctx context.Context, w http.ResponseWriter, response interface{}) (err error) {
+	if f, ok := response.(endpoint.Failure); ok && f.Failed() != nil {
+		ErrorEncoder(ctx, f.Failed(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	err = json.NewEncoder(w).Encode(response)
+	return
+}
+// makeEntryHandler creates the handler logic of GET and DELETE /history/{id}
+func makeEntryHandler(m *http.ServeMux, endpoints endpoint.Endpoints, getOptions, deleteOptions []http1.ServerOption) {
+	m.Handle("/history/", methods{
+		"GET":    http1.NewServer(endpoints.GetEndpoint, decodeGetRequest, encodeGenericResponse, getOptions...),
+		"DELETE": http1.NewServer(endpoints.DeleteEndpoint, decodeDeleteRequest, encodeGenericResponse, deleteOptions...),
+	})
+}
+
+// decodeGetRequest is a transport/http.DecodeRequestFunc that decodes the
+// entry ID from the request path.
+func decodeGetRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return endpoint.GetRequest{ID: entryID(r)}, nil
+}
+
+// decodeDeleteRequest is a transport/http.DecodeRequestFunc that decodes the
+// entry ID from the request path.
+func decodeDeleteRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return endpoint.DeleteRequest{ID: entryID(r)}, nil
+}
+
+func entryID(r *http.Request) string {
+	return strings.TrimPrefix(r.URL.Path, "/history/")
+}
+
+// encodeGenericResponse is a transport/http.EncodeResponseFunc that encodes
+// the response as JSON to the response writer
+func encodeGenericResponse(ctx context.Context, w http.ResponseWriter, response interface{}) (err error) {
+	if f, ok := response.(endpoint.Failure); ok && f.Failed() != nil {
+		ErrorEncoder(ctx, f.Failed(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	err = json.NewEncoder(w).Encode(response)
+	return
+}
+
+// methods routes a request to the handler registered for its HTTP method.
+type methods map[string]http.Handler
+
+func (m methods) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h, ok := m[r.Method]
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	h.ServeHTTP(w, r)
+}
+// ErrorEncoder writes err as {"error": {"code", "message", "details"}}, with
+// the HTTP status of its kind, see errs.Error.
 func ErrorEncoder(_ context.Context, err error, w http.ResponseWriter) {
-	w.WriteHeader(err2code(err))
-	json.NewEncoder(w).Encode(errorWrapper{Error: err.Error()})
+	e := errs.From(err)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(err2code(e))
+	json.NewEncoder(w).Encode(errorWrapper{Error: e})
 }
+
+// ErrorDecoder rebuilds the error written by ErrorEncoder, of the same kind,
+// so that the callers can check it with errors.Is against the errors of the
+// service. A body that cannot be decoded gives an error of the status alone.
 func ErrorDecoder(r *http.Response) error {
 	var w errorWrapper
-	if err := json.NewDecoder(r.Body).Decode(&w); err != nil {
-		return err
+	if err := json.NewDecoder(r.Body).Decode(&w); err != nil || w.Error == nil {
+		return errs.Decode(&errs.Error{}, r.StatusCode)
 	}
-	return errors.New(w.Error)
+	return errs.Decode(w.Error, r.StatusCode)
 }
 
 // This is used to set the http status, see an example here :
 // https://github.com/go-kit/kit/blob/master/examples/addsvc/pkg/addtransport/http.go#L133
-func err2code(err error) int {
-	return http.StatusInternalServerError
+func err2code(e *errs.Error) int {
+	return e.Status
 }
 
 type errorWrapper struct {
-	Error string `json:"error"`
+	Error *errs.Error `json:"error"`
+}
+
+// malformed reports the errors of the decoding of a request body.
+func malformed(err error) error {
+	return fmt.Errorf("%w: %v", errs.ErrMalformedRequest, err)
+}
+
+// AuthToContext returns a transport/http.RequestFunc that authenticates the
+// request with a, and stores the outcome in the context. The requests that
+// failed are rejected by auth.Middleware.
+func AuthToContext(a *auth.Authenticator) http1.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		p, err := a.Request(r)
+		return auth.ToContext(ctx, p, err)
+	}
 }
@@ -13,5 +13,7 @@ func NewHTTPHandler(endpoints endpoint.Endpoints, options map[string][]http.Serv
 	m := http1.NewServeMux()
 	makeStoreHandler(m, endpoints, options["Store"])
 	makeGetFromToHandler(m, endpoints, options["GetFromTo"])
+	makeQueryHandler(m, endpoints, options["Query"])
+	makeEntryHandler(m, endpoints, options["Get"], options["Delete"])
 	return m
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	log "github.com/go-kit/log"
@@ -21,11 +22,11 @@ func LoggingMiddleware(logger log.Logger) Middleware {
 
 }
 
-func (l loggingMiddleware) Store(ctx context.Context, timestamp_exec time.Time, cmd string, success bool, exit_code int, stdout string, stderr string) (err error) {
+func (l loggingMiddleware) Store(ctx context.Context, entry CmdExecutedEntry) (id string, err error) {
 	defer func() {
-		l.logger.Log("method", "Store", "timestamp_exec", timestamp_exec, "cmd", cmd, "success", success, "exit_code", exit_code, "stdout", stdout, "stderr", stderr, "err", err)
+		l.logger.Log("method", "Store", "id", id, "timestamp_exec", entry.TimestampExec, "cmd", entry.Cmd, "success", entry.Success, "exit_code", entry.ExitCode, "stdout", entry.Stdout, "stderr", entry.Stderr, "host", entry.Host, "instance_id", entry.InstanceID, "duration", entry.Duration, "err", err)
 	}()
-	return l.next.Store(ctx, timestamp_exec, cmd, success, exit_code, stdout, stderr)
+	return l.next.Store(ctx, entry)
 }
 
 func (l loggingMiddleware) GetFromTo(ctx context.Context, from time.Time, to time.Time) (res []*CmdExecutedEntry, err error) {
@@ -34,3 +35,24 @@ func (l loggingMiddleware) GetFromTo(ctx context.Context, from time.Time, to tim
 	}()
 	return l.next.GetFromTo(ctx, from, to)
 }
+
+func (l loggingMiddleware) Query(ctx context.Context, filter QueryFilter) (res []*CmdExecutedEntry, nextCursor string, err error) {
+	defer func() {
+		l.logger.Log("method", "Query", "filter", fmt.Sprintf("%+v", filter), "res", len(res), "next_cursor", nextCursor, "err", err)
+	}()
+	return l.next.Query(ctx, filter)
+}
+
+func (l loggingMiddleware) Get(ctx context.Context, id string) (entry *CmdExecutedEntry, err error) {
+	defer func() {
+		l.logger.Log("method", "Get", "id", id, "err", err)
+	}()
+	return l.next.Get(ctx, id)
+}
+
+func (l loggingMiddleware) Delete(ctx context.Context, id string) (err error) {
+	defer func() {
+		l.logger.Log("method", "Delete", "id", id, "err", err)
+	}()
+	return l.next.Delete(ctx, id)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+)
+
+var ErrInvalidQuery = errs.New(http.StatusBadRequest, "invalid_query", "invalid query")
+
+const (
+	// DefaultQueryLimit is the page size used when a QueryFilter sets no Limit.
+	DefaultQueryLimit = 100
+	// MaxQueryLimit is the largest page size a QueryFilter can ask for.
+	MaxQueryLimit = 1000
+)
+
+// SortOrder is the order of the results of a query, by execution timestamp.
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// QueryFilter selects the entries returned by a query. Every criterion left
+// to its zero value matches anything.
+type QueryFilter struct {
+	// From and To bound TimestampExec, From is inclusive and To is exclusive.
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Cmd matches the commands that contain it.
+	Cmd string `json:"cmd,omitempty"`
+	// CmdRegex matches the commands that match the regular expression.
+	CmdRegex   string `json:"cmd_regex,omitempty"`
+	ExitCode   *int   `json:"exit_code,omitempty"`
+	Success    *bool  `json:"success,omitempty"`
+	Host       string `json:"host,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
+	Principal  string `json:"principal,omitempty"`
+	// BatchID matches the steps of a batch, ScheduleID the runs of a schedule
+	// and TemplateName the executions of a command template.
+	BatchID      string `json:"batch_id,omitempty"`
+	ScheduleID   string `json:"schedule_id,omitempty"`
+	TemplateName string `json:"template_name,omitempty"`
+	// FinishedFrom and FinishedTo bound FinishedAt like From and To.
+	FinishedFrom time.Time `json:"finished_from"`
+	FinishedTo   time.Time `json:"finished_to"`
+	// The Min and Max bounds are inclusive, and unbounded when zero.
+	MinDuration   time.Duration `json:"min_duration,omitempty"`
+	MaxDuration   time.Duration `json:"max_duration,omitempty"`
+	MinUserTime   time.Duration `json:"min_user_time,omitempty"`
+	MaxUserTime   time.Duration `json:"max_user_time,omitempty"`
+	MinSystemTime time.Duration `json:"min_system_time,omitempty"`
+	MaxSystemTime time.Duration `json:"max_system_time,omitempty"`
+	MinPeakRSS    int64         `json:"min_peak_rss,omitempty"`
+	MaxPeakRSS    int64         `json:"max_peak_rss,omitempty"`
+
+	// Order defaults to SortAsc.
+	Order SortOrder `json:"order,omitempty"`
+	// Limit is the page size, DefaultQueryLimit when zero.
+	Limit int `json:"limit,omitempty"`
+	// Cursor is the NextCursor of the previous page, empty for the first page.
+	Cursor string `json:"cursor,omitempty"`
+
+	cmdRegex *regexp.Regexp
+	after    *cursor
+}
+
+// normalize validates f and fills in the defaults.
+func (f *QueryFilter) normalize() (err error) {
+	switch f.Order {
+	case "":
+		f.Order = SortAsc
+	case SortAsc, SortDesc:
+	default:
+		return fmt.Errorf("%w: unknown order %q", ErrInvalidQuery, f.Order)
+	}
+	for _, bound := range []int64{
+		int64(f.MinDuration), int64(f.MaxDuration),
+		int64(f.MinUserTime), int64(f.MaxUserTime),
+		int64(f.MinSystemTime), int64(f.MaxSystemTime),
+		f.MinPeakRSS, f.MaxPeakRSS,
+	} {
+		if bound < 0 {
+			return fmt.Errorf("%w: negative bound", ErrInvalidQuery)
+		}
+	}
+	switch {
+	case f.Limit < 0:
+		return fmt.Errorf("%w: negative limit", ErrInvalidQuery)
+	case f.Limit == 0:
+		f.Limit = DefaultQueryLimit
+	case f.Limit > MaxQueryLimit:
+		f.Limit = MaxQueryLimit
+	}
+	if f.CmdRegex != "" {
+		if f.cmdRegex, err = regexp.Compile(f.CmdRegex); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+	}
+	if f.Cursor != "" {
+		if f.after, err = decodeCursor(f.Cursor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matches reports whether e satisfies every criterion of f but the cursor.
+func (f *QueryFilter) matches(e *CmdExecutedEntry) bool {
+	switch {
+	case !f.From.IsZero() && e.TimestampExec.Before(f.From):
+		return false
+	case !f.To.IsZero() && !e.TimestampExec.Before(f.To):
+		return false
+	case f.Cmd != "" && !strings.Contains(e.Cmd, f.Cmd):
+		return false
+	case f.cmdRegex != nil && !f.cmdRegex.MatchString(e.Cmd):
+		return false
+	case f.ExitCode != nil && e.ExitCode != *f.ExitCode:
+		return false
+	case f.Success != nil && e.Success != *f.Success:
+		return false
+	case f.Host != "" && e.Host != f.Host:
+		return false
+	case f.InstanceID != "" && e.InstanceID != f.InstanceID:
+		return false
+	case f.Principal != "" && e.Principal != f.Principal:
+		return false
+	case f.BatchID != "" && e.BatchID != f.BatchID:
+		return false
+	case f.ScheduleID != "" && e.ScheduleID != f.ScheduleID:
+		return false
+	case f.TemplateName != "" && e.TemplateName != f.TemplateName:
+		return false
+	case !f.FinishedFrom.IsZero() && e.FinishedAt.Before(f.FinishedFrom):
+		return false
+	case !f.FinishedTo.IsZero() && !e.FinishedAt.Before(f.FinishedTo):
+		return false
+	}
+	return inRange(int64(e.Duration), int64(f.MinDuration), int64(f.MaxDuration)) &&
+		inRange(int64(e.UserTime), int64(f.MinUserTime), int64(f.MaxUserTime)) &&
+		inRange(int64(e.SystemTime), int64(f.MinSystemTime), int64(f.MaxSystemTime)) &&
+		inRange(e.PeakRSS, f.MinPeakRSS, f.MaxPeakRSS)
+}
+
+// inRange reports whether min <= v <= max, a zero bound being unbounded.
+func inRange(v, min, max int64) bool {
+	return (min == 0 || v >= min) && (max == 0 || v <= max)
+}
+
+// cursor is the position of the last entry of a page: its timestamp, and the
+// sequence number the repository uses to break the ties between timestamps.
+type cursor struct {
+	ts  time.Time
+	seq int64
+}
+
+// compare returns -1, 0 or +1 when the entry at (ts, seq) comes before, at or
+// after c in ascending order.
+func (c *cursor) compare(ts time.Time, seq int64) int {
+	switch {
+	case ts.Before(c.ts) || (ts.Equal(c.ts) && seq < c.seq):
+		return -1
+	case ts.Equal(c.ts) && seq == c.seq:
+		return 0
+	}
+	return 1
+}
+
+// skips reports whether the entry at (ts, seq) belongs to a page up to the
+// cursor of f, in the order of f.
+func (f *QueryFilter) skips(ts time.Time, seq int64) bool {
+	if f.after == nil {
+		return false
+	}
+	if f.Order == SortDesc {
+		return f.after.compare(ts, seq) >= 0
+	}
+	return f.after.compare(ts, seq) <= 0
+}
+
+func encodeCursor(ts time.Time, seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(ts.UTC().Format(time.RFC3339Nano) + "," + strconv.FormatInt(seq, 10)))
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	parts := strings.SplitN(string(b), ",", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	ts, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	seq, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	return &cursor{ts: ts, seq: seq}, nil
+}
@@ -2,28 +2,95 @@ package service
 
 import (
 	"context"
+	"net/http"
+	"sort"
 	"sync"
 	"time"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+)
+
+var (
+	ErrNotFound = errs.New(http.StatusNotFound, "not_found", "entry not found")
+	// ErrUnavailable is returned when the repository fails, e.g. when the
+	// database cannot be reached. The request can be retried.
+	ErrUnavailable = errs.New(http.StatusServiceUnavailable, "unavailable", "history unavailable")
 )
 
 type Repository interface {
+	// CreateCmdExec stores e. When an entry with the same IdempotencyKey is
+	// already stored, it sets e.ID to the ID of that entry and stores nothing.
 	CreateCmdExec(ctx context.Context, e *CmdExecutedEntry) (err error)
 	GetAllCmdExec(ctx context.Context) (res []*CmdExecutedEntry, err error)
 	GetCmdExecFromTo(ctx context.Context, from, to time.Time) (res []*CmdExecutedEntry, err error)
+	// Query returns a page of the entries selected by f, and the cursor of the
+	// next page, empty when there are no more entries.
+	Query(ctx context.Context, f QueryFilter) (res []*CmdExecutedEntry, nextCursor string, err error)
+	// GetCmdExec returns the entry with the given ID, or ErrNotFound.
+	GetCmdExec(ctx context.Context, id string) (e *CmdExecutedEntry, err error)
+	// DeleteCmdExec removes the entry with the given ID, or returns ErrNotFound.
+	DeleteCmdExec(ctx context.Context, id string) (err error)
 }
 
 type CmdExecutedEntry struct {
-	Cmd           string    `json:"cmd"`
+	ID  string `json:"id"`
+	Cmd string `json:"cmd"`
+	// TimestampExec is the time the command started.
 	TimestampExec time.Time `json:"timestamp_exec"`
+	FinishedAt    time.Time `json:"finished_at"`
 	Success       bool      `json:"success"`
 	ExitCode      int       `json:"exit_code"`
 	Stdout        string    `json:"stdout,omitempty"`
 	Stderr        string    `json:"stderr,omitempty"`
+	// StdoutBytes and StderrBytes are the sizes of the whole output, Stdout
+	// and Stderr only hold its head and its tail when it was truncated.
+	StdoutBytes     int64 `json:"stdout_bytes"`
+	StdoutTruncated bool  `json:"stdout_truncated"`
+	StderrBytes     int64 `json:"stderr_bytes"`
+	StderrTruncated bool  `json:"stderr_truncated"`
+
+	// Duration is the wall time of the execution, UserTime and SystemTime the
+	// CPU time of the process, and PeakRSS its maximum resident set size.
+	Duration   time.Duration `json:"duration_ns"`
+	UserTime   time.Duration `json:"user_time_ns"`
+	SystemTime time.Duration `json:"system_time_ns"`
+	PeakRSS    int64         `json:"peak_rss_bytes"`
+	// Host and InstanceID identify the bash_exec instance that ran the command.
+	Host       string `json:"host,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
+	// Principal is the authenticated caller that asked for the execution.
+	Principal string `json:"principal,omitempty"`
+	// Cwd, Env, CleanEnv and Stdin are the environment the command ran in: its
+	// working directory, the variables set on top of the environment of the
+	// instance, or of an empty one when CleanEnv is set, and its standard input.
+	Cwd      string            `json:"cwd,omitempty"`
+	Env      map[string]string `json:"env,omitempty"`
+	CleanEnv bool              `json:"clean_env,omitempty"`
+	Stdin    []byte            `json:"stdin,omitempty"`
+	// LimitExceeded is the resource limit that stopped the command, such as
+	// "cpu_time" or "output", empty when none did.
+	LimitExceeded string `json:"limit_exceeded,omitempty"`
+	// BatchID and BatchStep link the execution to a step of a batch, the
+	// executions of a batch share its ID.
+	BatchID   string `json:"batch_id,omitempty"`
+	BatchStep string `json:"batch_step,omitempty"`
+	// ScheduleID links the execution to the schedule that ran it, the runs of
+	// a schedule share its ID.
+	ScheduleID string `json:"schedule_id,omitempty"`
+	// TemplateName and TemplateParams are the command template the command
+	// was rendered from and the parameters it was rendered with.
+	TemplateName   string            `json:"template_name,omitempty"`
+	TemplateParams map[string]string `json:"template_params,omitempty"`
+	// IdempotencyKey is chosen by the client, an empty key never matches another entry.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 type repoInMem struct {
 	mtx sync.RWMutex
 	Db  []*CmdExecutedEntry
+	// seqs[i] is the sequence number of Db[i], the cursors of Query refer to it.
+	seqs    []int64
+	nextSeq int64
 }
 
 func NewInMemRepository() (Repository, error) {
@@ -35,25 +102,104 @@ func NewInMemRepository() (Repository, error) {
 func (r *repoInMem) CreateCmdExec(ctx context.Context, e *CmdExecutedEntry) (err error) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
+	if e.IdempotencyKey != "" {
+		for _, stored := range r.Db {
+			if stored.IdempotencyKey == e.IdempotencyKey {
+				e.ID = stored.ID
+				return
+			}
+		}
+	}
 	r.Db = append(r.Db, e)
+	r.seqs = append(r.seqs, r.nextSeq)
+	r.nextSeq++
 	return
 }
 
 func (r *repoInMem) GetAllCmdExec(ctx context.Context) (res []*CmdExecutedEntry, err error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	res = append(make([]*CmdExecutedEntry, 0, len(r.Db)), r.Db...)
+	return
+}
+
+func (r *repoInMem) GetCmdExec(ctx context.Context, id string) (e *CmdExecutedEntry, err error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if i := r.indexOf(id); i >= 0 {
+		return r.Db[i], nil
+	}
+	return nil, ErrNotFound
+}
+
+func (r *repoInMem) DeleteCmdExec(ctx context.Context, id string) (err error) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
-	res = r.Db
+	i := r.indexOf(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	r.Db = append(r.Db[:i:i], r.Db[i+1:]...)
+	r.seqs = append(r.seqs[:i:i], r.seqs[i+1:]...)
 	return
 }
 
+// indexOf returns the position of the entry with the given ID in Db, or -1.
+// The caller must hold the mutex.
+func (r *repoInMem) indexOf(id string) int {
+	for i, e := range r.Db {
+		if e.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
 func (r *repoInMem) GetCmdExecFromTo(ctx context.Context, from, to time.Time) (res []*CmdExecutedEntry, err error) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 	for _, e := range r.Db {
-		if from.After(e.TimestampExec) && to.Before(e.TimestampExec) {
+		if !e.TimestampExec.Before(from) && e.TimestampExec.Before(to) {
 			res = append(res, e)
 		}
 	}
 
 	return
 }
+
+func (r *repoInMem) Query(ctx context.Context, f QueryFilter) (res []*CmdExecutedEntry, nextCursor string, err error) {
+	if err = f.normalize(); err != nil {
+		return
+	}
+
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	// Db is in insertion order, so idx is sorted by sequence number.
+	var idx []int
+	for i, e := range r.Db {
+		if f.matches(e) && !f.skips(e.TimestampExec, r.seqs[i]) {
+			idx = append(idx, i)
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		ta, tb := r.Db[idx[a]].TimestampExec, r.Db[idx[b]].TimestampExec
+		return ta.Before(tb)
+	})
+	if f.Order == SortDesc {
+		for a, b := 0, len(idx)-1; a < b; a, b = a+1, b-1 {
+			idx[a], idx[b] = idx[b], idx[a]
+		}
+	}
+
+	if len(idx) > f.Limit {
+		last := idx[f.Limit-1]
+		nextCursor = encodeCursor(r.Db[last].TimestampExec, r.seqs[last])
+		idx = idx[:f.Limit]
+	}
+	res = make([]*CmdExecutedEntry, 0, len(idx))
+	for _, i := range idx {
+		res = append(res, r.Db[i])
+	}
+	return
+}
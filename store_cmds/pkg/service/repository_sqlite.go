@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteTimeLayout has a fixed width, so that timestamps stored as text in UTC
+// sort in chronological order.
+const sqliteTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// sqliteMigrations are applied in order, the index of a migration plus one is
+// the schema version it brings the database to. Never edit a released
+// migration, append a new one instead.
+var sqliteMigrations = []string{
+	`CREATE TABLE cmd_executions (
+		id             INTEGER PRIMARY KEY AUTOINCREMENT,
+		cmd            TEXT    NOT NULL,
+		timestamp_exec TEXT    NOT NULL,
+		success        INTEGER NOT NULL,
+		exit_code      INTEGER NOT NULL,
+		stdout         TEXT    NOT NULL DEFAULT '',
+		stderr         TEXT    NOT NULL DEFAULT ''
+	);
+	CREATE INDEX idx_cmd_executions_timestamp_exec ON cmd_executions (timestamp_exec);`,
+	`ALTER TABLE cmd_executions ADD COLUMN host TEXT NOT NULL DEFAULT '';
+	CREATE INDEX idx_cmd_executions_timestamp_exec_id ON cmd_executions (timestamp_exec, id);`,
+	`ALTER TABLE cmd_executions ADD COLUMN entry_id TEXT;
+	UPDATE cmd_executions SET entry_id = lower(hex(randomblob(16))) WHERE entry_id IS NULL;
+	CREATE UNIQUE INDEX idx_cmd_executions_entry_id ON cmd_executions (entry_id);`,
+	`ALTER TABLE cmd_executions ADD COLUMN idempotency_key TEXT;
+	CREATE UNIQUE INDEX idx_cmd_executions_idempotency_key ON cmd_executions (idempotency_key);`,
+	`ALTER TABLE cmd_executions ADD COLUMN finished_at TEXT NOT NULL DEFAULT '';
+	ALTER TABLE cmd_executions ADD COLUMN duration_ns INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE cmd_executions ADD COLUMN user_time_ns INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE cmd_executions ADD COLUMN system_time_ns INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE cmd_executions ADD COLUMN peak_rss_bytes INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE cmd_executions ADD COLUMN instance_id TEXT NOT NULL DEFAULT '';
+	UPDATE cmd_executions SET finished_at = timestamp_exec;
+	CREATE INDEX idx_cmd_executions_instance_id ON cmd_executions (instance_id);`,
+	`ALTER TABLE cmd_executions ADD COLUMN principal TEXT NOT NULL DEFAULT '';
+	CREATE INDEX idx_cmd_executions_principal ON cmd_executions (principal);`,
+	`ALTER TABLE cmd_executions ADD COLUMN cwd TEXT NOT NULL DEFAULT '';
+	ALTER TABLE cmd_executions ADD COLUMN env TEXT NOT NULL DEFAULT '';
+	ALTER TABLE cmd_executions ADD COLUMN clean_env INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE cmd_executions ADD COLUMN stdin BLOB NOT NULL DEFAULT x'';`,
+	`ALTER TABLE cmd_executions ADD COLUMN limit_exceeded TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE cmd_executions ADD COLUMN stdout_bytes INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE cmd_executions ADD COLUMN stdout_truncated INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE cmd_executions ADD COLUMN stderr_bytes INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE cmd_executions ADD COLUMN stderr_truncated INTEGER NOT NULL DEFAULT 0;
+	UPDATE cmd_executions SET stdout_bytes = length(CAST(stdout AS BLOB)), stderr_bytes = length(CAST(stderr AS BLOB));`,
+	`ALTER TABLE cmd_executions ADD COLUMN batch_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE cmd_executions ADD COLUMN batch_step TEXT NOT NULL DEFAULT '';
+	CREATE INDEX idx_cmd_executions_batch_id ON cmd_executions (batch_id);`,
+	`ALTER TABLE cmd_executions ADD COLUMN schedule_id TEXT NOT NULL DEFAULT '';
+	CREATE INDEX idx_cmd_executions_schedule_id ON cmd_executions (schedule_id);`,
+	`ALTER TABLE cmd_executions ADD COLUMN template_name TEXT NOT NULL DEFAULT '';
+	ALTER TABLE cmd_executions ADD COLUMN template_params TEXT NOT NULL DEFAULT '';
+	CREATE INDEX idx_cmd_executions_template_name ON cmd_executions (template_name);`,
+}
+
+// sqliteColumns are the columns scanned by repoSQLite.query, in order.
+const sqliteColumns = `id, entry_id, cmd, timestamp_exec, success, exit_code, stdout, stderr, host, COALESCE(idempotency_key, ''),
+	finished_at, duration_ns, user_time_ns, system_time_ns, peak_rss_bytes, instance_id, principal, cwd, env, clean_env, stdin, limit_exceeded,
+	stdout_bytes, stdout_truncated, stderr_bytes, stderr_truncated, batch_id, batch_step, schedule_id,
+	template_name, template_params`
+
+type repoSQLite struct {
+	db *sql.DB
+}
+
+// NewSQLiteRepository returns a Repository persisted in the SQLite database at
+// path, which is created if needed and migrated to the latest schema.
+func NewSQLiteRepository(path string) (Repository, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer, serialize the accesses instead of failing with SQLITE_BUSY.
+	db.SetMaxOpenConns(1)
+	if _, err = db.Exec(`PRAGMA journal_mode = WAL; PRAGMA busy_timeout = 5000;`); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err = migrateSQLite(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &repoSQLite{db: db}, nil
+}
+
+// migrateSQLite applies the migrations that are newer than the schema version
+// recorded in the database, each in its own transaction.
+func migrateSQLite(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return err
+	}
+	var version int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return err
+	}
+	for i := version; i < len(sqliteMigrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec(sqliteMigrations[i]); err == nil {
+			_, err = tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, i+1)
+		}
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repoSQLite) CreateCmdExec(ctx context.Context, e *CmdExecutedEntry) (err error) {
+	// The environment and the template parameters are stored as JSON
+	// objects, empty when there are none, and stdin as a blob that is never NULL.
+	env, params, stdin := []byte{}, []byte{}, append([]byte{}, e.Stdin...)
+	if len(e.Env) > 0 {
+		if env, err = json.Marshal(e.Env); err != nil {
+			return err
+		}
+	}
+	if len(e.TemplateParams) > 0 {
+		if params, err = json.Marshal(e.TemplateParams); err != nil {
+			return err
+		}
+	}
+	// An empty key is stored as NULL, that the unique index lets repeat.
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO cmd_executions (entry_id, cmd, timestamp_exec, success, exit_code, stdout, stderr, host, idempotency_key,
+			finished_at, duration_ns, user_time_ns, system_time_ns, peak_rss_bytes, instance_id, principal, cwd, env, clean_env, stdin, limit_exceeded,
+			stdout_bytes, stdout_truncated, stderr_bytes, stderr_truncated, batch_id, batch_step, schedule_id,
+			template_name, template_params)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (idempotency_key) DO NOTHING`,
+		e.ID, e.Cmd, formatSQLiteTime(e.TimestampExec), e.Success, e.ExitCode, e.Stdout, e.Stderr, e.Host, e.IdempotencyKey,
+		formatSQLiteTime(e.FinishedAt), e.Duration, e.UserTime, e.SystemTime, e.PeakRSS, e.InstanceID, e.Principal,
+		e.Cwd, string(env), e.CleanEnv, stdin, e.LimitExceeded,
+		e.StdoutBytes, e.StdoutTruncated, e.StderrBytes, e.StderrTruncated, e.BatchID, e.BatchStep, e.ScheduleID,
+		e.TemplateName, string(params),
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n > 0 {
+		return err
+	}
+	return r.db.QueryRowContext(ctx, `SELECT entry_id FROM cmd_executions WHERE idempotency_key = ?`, e.IdempotencyKey).Scan(&e.ID)
+}
+
+func (r *repoSQLite) GetCmdExec(ctx context.Context, id string) (e *CmdExecutedEntry, err error) {
+	err = r.query(ctx, func(found *CmdExecutedEntry, _ int64) bool {
+		e = found
+		return false
+	}, `SELECT `+sqliteColumns+` FROM cmd_executions WHERE entry_id = ?`, id)
+	if err == nil && e == nil {
+		err = ErrNotFound
+	}
+	return
+}
+
+func (r *repoSQLite) DeleteCmdExec(ctx context.Context, id string) (err error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM cmd_executions WHERE entry_id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *repoSQLite) GetAllCmdExec(ctx context.Context) (res []*CmdExecutedEntry, err error) {
+	err = r.query(ctx, func(e *CmdExecutedEntry, _ int64) bool {
+		res = append(res, e)
+		return true
+	}, `SELECT `+sqliteColumns+` FROM cmd_executions ORDER BY id`)
+	return
+}
+
+func (r *repoSQLite) GetCmdExecFromTo(ctx context.Context, from, to time.Time) (res []*CmdExecutedEntry, err error) {
+	err = r.query(ctx, func(e *CmdExecutedEntry, _ int64) bool {
+		res = append(res, e)
+		return true
+	}, `SELECT `+sqliteColumns+` FROM cmd_executions WHERE timestamp_exec >= ? AND timestamp_exec < ? ORDER BY id`,
+		formatSQLiteTime(from), formatSQLiteTime(to),
+	)
+	return
+}
+
+func (r *repoSQLite) Query(ctx context.Context, f QueryFilter) (res []*CmdExecutedEntry, nextCursor string, err error) {
+	if err = f.normalize(); err != nil {
+		return
+	}
+
+	var (
+		where []string
+		args  []interface{}
+	)
+	if !f.From.IsZero() {
+		where, args = append(where, `timestamp_exec >= ?`), append(args, formatSQLiteTime(f.From))
+	}
+	if !f.To.IsZero() {
+		where, args = append(where, `timestamp_exec < ?`), append(args, formatSQLiteTime(f.To))
+	}
+	if f.Cmd != "" {
+		where, args = append(where, `instr(cmd, ?) > 0`), append(args, f.Cmd)
+	}
+	if f.ExitCode != nil {
+		where, args = append(where, `exit_code = ?`), append(args, *f.ExitCode)
+	}
+	if f.Success != nil {
+		where, args = append(where, `success = ?`), append(args, *f.Success)
+	}
+	if f.Host != "" {
+		where, args = append(where, `host = ?`), append(args, f.Host)
+	}
+	if f.InstanceID != "" {
+		where, args = append(where, `instance_id = ?`), append(args, f.InstanceID)
+	}
+	if f.Principal != "" {
+		where, args = append(where, `principal = ?`), append(args, f.Principal)
+	}
+	if f.BatchID != "" {
+		where, args = append(where, `batch_id = ?`), append(args, f.BatchID)
+	}
+	if f.ScheduleID != "" {
+		where, args = append(where, `schedule_id = ?`), append(args, f.ScheduleID)
+	}
+	if f.TemplateName != "" {
+		where, args = append(where, `template_name = ?`), append(args, f.TemplateName)
+	}
+	if !f.FinishedFrom.IsZero() {
+		where, args = append(where, `finished_at >= ?`), append(args, formatSQLiteTime(f.FinishedFrom))
+	}
+	if !f.FinishedTo.IsZero() {
+		where, args = append(where, `finished_at < ?`), append(args, formatSQLiteTime(f.FinishedTo))
+	}
+	for _, b := range []struct {
+		column   string
+		min, max int64
+	}{
+		{"duration_ns", int64(f.MinDuration), int64(f.MaxDuration)},
+		{"user_time_ns", int64(f.MinUserTime), int64(f.MaxUserTime)},
+		{"system_time_ns", int64(f.MinSystemTime), int64(f.MaxSystemTime)},
+		{"peak_rss_bytes", f.MinPeakRSS, f.MaxPeakRSS},
+	} {
+		if b.min != 0 {
+			where, args = append(where, b.column+` >= ?`), append(args, b.min)
+		}
+		if b.max != 0 {
+			where, args = append(where, b.column+` <= ?`), append(args, b.max)
+		}
+	}
+	op, dir := ">", "ASC"
+	if f.Order == SortDesc {
+		op, dir = "<", "DESC"
+	}
+	if f.after != nil {
+		ts := formatSQLiteTime(f.after.ts)
+		where = append(where, `(timestamp_exec `+op+` ? OR (timestamp_exec = ? AND id `+op+` ?))`)
+		args = append(args, ts, ts, f.after.seq)
+	}
+
+	query := `SELECT ` + sqliteColumns + ` FROM cmd_executions`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, ` AND `)
+	}
+	query += ` ORDER BY timestamp_exec ` + dir + `, id ` + dir
+	// The regular expression is matched here, so the rows cannot be limited by SQLite.
+	if f.cmdRegex == nil {
+		query += fmt.Sprintf(` LIMIT %d`, f.Limit+1)
+	}
+
+	var lastSeq int64
+	err = r.query(ctx, func(e *CmdExecutedEntry, seq int64) bool {
+		if f.cmdRegex != nil && !f.cmdRegex.MatchString(e.Cmd) {
+			return true
+		}
+		if len(res) == f.Limit {
+			last := res[len(res)-1]
+			nextCursor = encodeCursor(last.TimestampExec, lastSeq)
+			return false
+		}
+		res, lastSeq = append(res, e), seq
+		return true
+	}, query, args...)
+	return
+}
+
+// query runs query and calls fn with every entry and its id, until fn returns false.
+func (r *repoSQLite) query(ctx context.Context, fn func(e *CmdExecutedEntry, seq int64) bool, query string, args ...interface{}) error {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			e            CmdExecutedEntry
+			seq          int64
+			ts, finished string
+			env, params  string
+		)
+		if err = rows.Scan(&seq, &e.ID, &e.Cmd, &ts, &e.Success, &e.ExitCode, &e.Stdout, &e.Stderr, &e.Host, &e.IdempotencyKey,
+			&finished, &e.Duration, &e.UserTime, &e.SystemTime, &e.PeakRSS, &e.InstanceID, &e.Principal,
+			&e.Cwd, &env, &e.CleanEnv, &e.Stdin, &e.LimitExceeded,
+			&e.StdoutBytes, &e.StdoutTruncated, &e.StderrBytes, &e.StderrTruncated, &e.BatchID, &e.BatchStep, &e.ScheduleID,
+			&e.TemplateName, &params); err != nil {
+			return err
+		}
+		if env != "" {
+			if err = json.Unmarshal([]byte(env), &e.Env); err != nil {
+				return err
+			}
+		}
+		if params != "" {
+			if err = json.Unmarshal([]byte(params), &e.TemplateParams); err != nil {
+				return err
+			}
+		}
+		if len(e.Stdin) == 0 {
+			e.Stdin = nil
+		}
+		if e.TimestampExec, err = time.Parse(sqliteTimeLayout, ts); err != nil {
+			return err
+		}
+		if e.FinishedAt, err = time.Parse(sqliteTimeLayout, finished); err != nil {
+			return err
+		}
+		if !fn(&e, seq) {
+			break
+		}
+	}
+	return rows.Err()
+}
+
+func formatSQLiteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeLayout)
+}
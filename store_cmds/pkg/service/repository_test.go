@@ -0,0 +1,22 @@
+package service_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	service "github.com/gigi214/services_example/store_cmds/pkg/service"
+	"github.com/gigi214/services_example/store_cmds/pkg/service/repotest"
+)
+
+func TestInMemRepository(t *testing.T) {
+	repotest.TestRepository(t, service.NewInMemRepository)
+}
+
+func TestSQLiteRepository(t *testing.T) {
+	dir, n := t.TempDir(), 0
+	repotest.TestRepository(t, func() (service.Repository, error) {
+		n++
+		return service.NewSQLiteRepository(filepath.Join(dir, fmt.Sprintf("history-%d.db", n)))
+	})
+}
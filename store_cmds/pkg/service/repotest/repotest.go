@@ -0,0 +1,363 @@
+// Package repotest implements a conformance suite for the implementations of
+// service.Repository.
+package repotest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	service "github.com/gigi214/services_example/store_cmds/pkg/service"
+)
+
+// NewRepositoryFunc returns a new, empty Repository at each call. The suite
+// creates every entry with a unique ID, like StoreCmdsService does.
+type NewRepositoryFunc func() (service.Repository, error)
+
+// TestRepository runs the conformance suite against the repositories returned
+// by newRepo, each check in a subtest of t with a new repository. It is meant
+// to be called from the tests of every implementation:
+//
+//	func TestInMemRepository(t *testing.T) {
+//		repotest.TestRepository(t, service.NewInMemRepository)
+//	}
+func TestRepository(t *testing.T, newRepo NewRepositoryFunc) {
+	for _, c := range checks {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			repo, err := newRepo()
+			if err != nil {
+				t.Fatalf("creating repository: %v", err)
+			}
+			if err = c.fn(context.Background(), repo); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+var checks = []struct {
+	name string
+	fn   func(context.Context, service.Repository) error
+}{
+	{"Empty", checkEmpty},
+	{"CreateAndGetAll", checkCreateAndGetAll},
+	{"ConcurrentCreate", checkConcurrentCreate},
+	{"GetFromTo", checkGetFromTo},
+	{"QueryFilters", checkQueryFilters},
+	{"QueryPagination", checkQueryPagination},
+	{"GetAndDelete", checkGetAndDelete},
+	{"IdempotencyKey", checkIdempotencyKey},
+}
+
+// base is the timestamp of the first sample entry, the others follow by one second.
+var base = time.Date(2022, 9, 1, 10, 0, 0, 123456789, time.UTC)
+
+func samples() []*service.CmdExecutedEntry {
+	return []*service.CmdExecutedEntry{
+		{ID: "01", Cmd: "ls -l", TimestampExec: base, FinishedAt: base.Add(10 * time.Millisecond), Success: true, ExitCode: 0, Stdout: "total 0\n",
+			Duration: 10 * time.Millisecond, UserTime: time.Millisecond, SystemTime: 2 * time.Millisecond, PeakRSS: 2 << 20, Host: "a", InstanceID: "i1", Principal: "alice",
+			Cwd: "/tmp", Env: map[string]string{"LANG": "C", "TZ": "UTC"}, BatchID: "b1", BatchStep: "list"},
+		{ID: "02", Cmd: "cat missing", TimestampExec: base.Add(time.Second), FinishedAt: base.Add(time.Second + 5*time.Millisecond), Success: false, ExitCode: 1, Stderr: "cat: missing: No such file or directory\n",
+			Duration: 5 * time.Millisecond, UserTime: time.Millisecond, SystemTime: time.Millisecond, PeakRSS: 1 << 20, Host: "b", InstanceID: "i2", Principal: "bob",
+			CleanEnv: true, Stdin: []byte("missing\n"), LimitExceeded: "output"},
+		{ID: "03", Cmd: `grep -r "a b" /tmp`, TimestampExec: base.Add(2 * time.Second), FinishedAt: base.Add(3 * time.Second), Success: true, ExitCode: 0, Stdout: "x\n", Stderr: "y\n",
+			StdoutBytes: 2, StderrBytes: 3 << 20, StderrTruncated: true,
+			Duration: time.Second, UserTime: 300 * time.Millisecond, SystemTime: 600 * time.Millisecond, PeakRSS: 8 << 20, Host: "a", InstanceID: "i1", Principal: "alice",
+			BatchID: "b1", BatchStep: "search", ScheduleID: "s1",
+			TemplateName: "grep", TemplateParams: map[string]string{"pattern": "a b", "dir": "/tmp"}},
+	}
+}
+
+func checkEmpty(ctx context.Context, repo service.Repository) error {
+	res, err := repo.GetAllCmdExec(ctx)
+	if err != nil {
+		return err
+	}
+	if len(res) != 0 {
+		return fmt.Errorf("got %d entries, want 0", len(res))
+	}
+	return nil
+}
+
+func checkCreateAndGetAll(ctx context.Context, repo service.Repository) error {
+	want := samples()
+	if err := create(ctx, repo, want); err != nil {
+		return err
+	}
+	got, err := repo.GetAllCmdExec(ctx)
+	if err != nil {
+		return err
+	}
+	return compare(got, want)
+}
+
+func checkConcurrentCreate(ctx context.Context, repo service.Repository) error {
+	const n = 50
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, n)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- repo.CreateCmdExec(ctx, &service.CmdExecutedEntry{ID: fmt.Sprint("c", i), Cmd: fmt.Sprint("echo ", i), TimestampExec: base, Success: true})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	res, err := repo.GetAllCmdExec(ctx)
+	if err != nil {
+		return err
+	}
+	if len(res) != n {
+		return fmt.Errorf("got %d entries, want %d", len(res), n)
+	}
+	return nil
+}
+
+func create(ctx context.Context, repo service.Repository, entries []*service.CmdExecutedEntry) error {
+	for _, e := range entries {
+		if err := repo.CreateCmdExec(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkGetFromTo(ctx context.Context, repo service.Repository) error {
+	all := samples()
+	if err := create(ctx, repo, all); err != nil {
+		return err
+	}
+	// From is inclusive and to is exclusive.
+	got, err := repo.GetCmdExecFromTo(ctx, base, base.Add(2*time.Second))
+	if err != nil {
+		return err
+	}
+	return compare(got, all[:2])
+}
+
+func checkQueryFilters(ctx context.Context, repo service.Repository) error {
+	all := samples()
+	if err := create(ctx, repo, all); err != nil {
+		return err
+	}
+	one, yes := 1, true
+	cases := []struct {
+		name   string
+		filter service.QueryFilter
+		want   []*service.CmdExecutedEntry
+	}{
+		{"none", service.QueryFilter{}, all},
+		{"range", service.QueryFilter{From: base.Add(time.Second), To: base.Add(3 * time.Second)}, all[1:]},
+		{"cmd", service.QueryFilter{Cmd: "a b"}, all[2:]},
+		{"cmd_regex", service.QueryFilter{CmdRegex: "^(ls|cat) "}, all[:2]},
+		{"exit_code", service.QueryFilter{ExitCode: &one}, all[1:2]},
+		{"success", service.QueryFilter{Success: &yes}, []*service.CmdExecutedEntry{all[0], all[2]}},
+		{"host", service.QueryFilter{Host: "b"}, all[1:2]},
+		{"instance_id", service.QueryFilter{InstanceID: "i1"}, []*service.CmdExecutedEntry{all[0], all[2]}},
+		{"principal", service.QueryFilter{Principal: "bob"}, all[1:2]},
+		{"batch_id", service.QueryFilter{BatchID: "b1"}, []*service.CmdExecutedEntry{all[0], all[2]}},
+		{"schedule_id", service.QueryFilter{ScheduleID: "s1"}, all[2:3]},
+		{"template_name", service.QueryFilter{TemplateName: "grep"}, all[2:3]},
+		{"finished", service.QueryFilter{FinishedFrom: base.Add(time.Second), FinishedTo: base.Add(3 * time.Second)}, all[1:2]},
+		{"duration", service.QueryFilter{MinDuration: 5 * time.Millisecond, MaxDuration: 10 * time.Millisecond}, all[:2]},
+		{"user_time", service.QueryFilter{MinUserTime: 2 * time.Millisecond}, all[2:]},
+		{"system_time", service.QueryFilter{MaxSystemTime: time.Millisecond}, all[1:2]},
+		{"peak_rss", service.QueryFilter{MinPeakRSS: 2 << 20, MaxPeakRSS: 4 << 20}, all[:1]},
+		{"desc", service.QueryFilter{Order: service.SortDesc}, []*service.CmdExecutedEntry{all[2], all[1], all[0]}},
+	}
+	for _, c := range cases {
+		got, next, err := repo.Query(ctx, c.filter)
+		if err != nil {
+			return fmt.Errorf("%s: %w", c.name, err)
+		}
+		if next != "" {
+			return fmt.Errorf("%s: got next cursor %q on the last page", c.name, next)
+		}
+		if err = compare(got, c.want); err != nil {
+			return fmt.Errorf("%s: %w", c.name, err)
+		}
+	}
+	if _, _, err := repo.Query(ctx, service.QueryFilter{CmdRegex: "("}); !errors.Is(err, service.ErrInvalidQuery) {
+		return fmt.Errorf("invalid regex: got error %v, want ErrInvalidQuery", err)
+	}
+	return nil
+}
+
+func checkQueryPagination(ctx context.Context, repo service.Repository) error {
+	// Entries sharing a timestamp must neither be repeated nor skipped.
+	var all []*service.CmdExecutedEntry
+	for i := 0; i < 7; i++ {
+		all = append(all, &service.CmdExecutedEntry{ID: fmt.Sprint("p", i), Cmd: fmt.Sprint("echo ", i), TimestampExec: base.Add(time.Duration(i/2) * time.Second)})
+	}
+	if err := create(ctx, repo, all); err != nil {
+		return err
+	}
+	for _, order := range []service.SortOrder{service.SortAsc, service.SortDesc} {
+		var got []*service.CmdExecutedEntry
+		f := service.QueryFilter{Order: order, Limit: 3}
+		for page := 0; ; page++ {
+			if page > len(all) {
+				return fmt.Errorf("%s: pagination does not end", order)
+			}
+			res, next, err := repo.Query(ctx, f)
+			if err != nil {
+				return fmt.Errorf("%s: %w", order, err)
+			}
+			got = append(got, res...)
+			if next == "" {
+				break
+			}
+			f.Cursor = next
+		}
+		want := all
+		if order == service.SortDesc {
+			want = make([]*service.CmdExecutedEntry, len(all))
+			for i, e := range all {
+				want[len(all)-1-i] = e
+			}
+		}
+		if err := compare(got, want); err != nil {
+			return fmt.Errorf("%s: %w", order, err)
+		}
+	}
+	return nil
+}
+
+func checkGetAndDelete(ctx context.Context, repo service.Repository) error {
+	all := samples()
+	if err := create(ctx, repo, all); err != nil {
+		return err
+	}
+	got, err := repo.GetCmdExec(ctx, all[1].ID)
+	if err != nil {
+		return err
+	}
+	if err = compareEntry(got, all[1]); err != nil {
+		return err
+	}
+	if _, err = repo.GetCmdExec(ctx, "missing"); !errors.Is(err, service.ErrNotFound) {
+		return fmt.Errorf("get missing: got error %v, want ErrNotFound", err)
+	}
+
+	if err = repo.DeleteCmdExec(ctx, all[1].ID); err != nil {
+		return err
+	}
+	if err = repo.DeleteCmdExec(ctx, all[1].ID); !errors.Is(err, service.ErrNotFound) {
+		return fmt.Errorf("delete twice: got error %v, want ErrNotFound", err)
+	}
+	if _, err = repo.GetCmdExec(ctx, all[1].ID); !errors.Is(err, service.ErrNotFound) {
+		return fmt.Errorf("get deleted: got error %v, want ErrNotFound", err)
+	}
+
+	// The cursors must survive deletions.
+	page, next, err := repo.Query(ctx, service.QueryFilter{Limit: 1})
+	if err != nil {
+		return err
+	}
+	if err = repo.DeleteCmdExec(ctx, all[0].ID); err != nil {
+		return err
+	}
+	rest, _, err := repo.Query(ctx, service.QueryFilter{Limit: 1, Cursor: next})
+	if err != nil {
+		return err
+	}
+	return compare(append(page, rest...), []*service.CmdExecutedEntry{all[0], all[2]})
+}
+
+func checkIdempotencyKey(ctx context.Context, repo service.Repository) error {
+	first := &service.CmdExecutedEntry{ID: "k1", Cmd: "echo once", TimestampExec: base, Success: true, IdempotencyKey: "key"}
+	again := *first
+	again.ID = "k2"
+	other := &service.CmdExecutedEntry{ID: "k3", Cmd: "echo other", TimestampExec: base, Success: true}
+	if err := create(ctx, repo, []*service.CmdExecutedEntry{first, &again, other}); err != nil {
+		return err
+	}
+	if again.ID != first.ID {
+		return fmt.Errorf("duplicate key: got id %q, want %q", again.ID, first.ID)
+	}
+	got, err := repo.GetAllCmdExec(ctx)
+	if err != nil {
+		return err
+	}
+	return compare(got, []*service.CmdExecutedEntry{first, other})
+}
+
+// compare checks that got holds the same entries as want, in the same order.
+func compare(got, want []*service.CmdExecutedEntry) error {
+	if len(got) != len(want) {
+		return fmt.Errorf("got %d entries, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if err := compareEntry(got[i], want[i]); err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func compareEntry(got, want *service.CmdExecutedEntry) error {
+	switch {
+	case got.ID != want.ID:
+		return fmt.Errorf("id = %q, want %q", got.ID, want.ID)
+	case got.Cmd != want.Cmd:
+		return fmt.Errorf("cmd = %q, want %q", got.Cmd, want.Cmd)
+	case !got.TimestampExec.Equal(want.TimestampExec):
+		return fmt.Errorf("timestamp_exec = %v, want %v", got.TimestampExec, want.TimestampExec)
+	case got.Success != want.Success:
+		return fmt.Errorf("success = %v, want %v", got.Success, want.Success)
+	case got.ExitCode != want.ExitCode:
+		return fmt.Errorf("exit_code = %d, want %d", got.ExitCode, want.ExitCode)
+	case got.Stdout != want.Stdout:
+		return fmt.Errorf("stdout = %q, want %q", got.Stdout, want.Stdout)
+	case got.Stderr != want.Stderr:
+		return fmt.Errorf("stderr = %q, want %q", got.Stderr, want.Stderr)
+	case !got.FinishedAt.Equal(want.FinishedAt):
+		return fmt.Errorf("finished_at = %v, want %v", got.FinishedAt, want.FinishedAt)
+	case got.Duration != want.Duration || got.UserTime != want.UserTime || got.SystemTime != want.SystemTime:
+		return fmt.Errorf("times = %v/%v/%v, want %v/%v/%v", got.Duration, got.UserTime, got.SystemTime, want.Duration, want.UserTime, want.SystemTime)
+	case got.PeakRSS != want.PeakRSS:
+		return fmt.Errorf("peak_rss = %d, want %d", got.PeakRSS, want.PeakRSS)
+	case got.Host != want.Host:
+		return fmt.Errorf("host = %q, want %q", got.Host, want.Host)
+	case got.InstanceID != want.InstanceID:
+		return fmt.Errorf("instance_id = %q, want %q", got.InstanceID, want.InstanceID)
+	case got.Principal != want.Principal:
+		return fmt.Errorf("principal = %q, want %q", got.Principal, want.Principal)
+	case got.Cwd != want.Cwd:
+		return fmt.Errorf("cwd = %q, want %q", got.Cwd, want.Cwd)
+	case fmt.Sprint(got.Env) != fmt.Sprint(want.Env):
+		return fmt.Errorf("env = %v, want %v", got.Env, want.Env)
+	case got.CleanEnv != want.CleanEnv:
+		return fmt.Errorf("clean_env = %v, want %v", got.CleanEnv, want.CleanEnv)
+	case !bytes.Equal(got.Stdin, want.Stdin):
+		return fmt.Errorf("stdin = %q, want %q", got.Stdin, want.Stdin)
+	case got.StdoutBytes != want.StdoutBytes || got.StdoutTruncated != want.StdoutTruncated:
+		return fmt.Errorf("stdout size = %d/%v, want %d/%v", got.StdoutBytes, got.StdoutTruncated, want.StdoutBytes, want.StdoutTruncated)
+	case got.StderrBytes != want.StderrBytes || got.StderrTruncated != want.StderrTruncated:
+		return fmt.Errorf("stderr size = %d/%v, want %d/%v", got.StderrBytes, got.StderrTruncated, want.StderrBytes, want.StderrTruncated)
+	case got.LimitExceeded != want.LimitExceeded:
+		return fmt.Errorf("limit_exceeded = %q, want %q", got.LimitExceeded, want.LimitExceeded)
+	case got.BatchID != want.BatchID || got.BatchStep != want.BatchStep:
+		return fmt.Errorf("batch = %s/%s, want %s/%s", got.BatchID, got.BatchStep, want.BatchID, want.BatchStep)
+	case got.ScheduleID != want.ScheduleID:
+		return fmt.Errorf("schedule_id = %q, want %q", got.ScheduleID, want.ScheduleID)
+	case got.TemplateName != want.TemplateName || fmt.Sprint(got.TemplateParams) != fmt.Sprint(want.TemplateParams):
+		return fmt.Errorf("template = %s %v, want %s %v", got.TemplateName, got.TemplateParams, want.TemplateName, want.TemplateParams)
+	case got.IdempotencyKey != want.IdempotencyKey:
+		return fmt.Errorf("idempotency_key = %q, want %q", got.IdempotencyKey, want.IdempotencyKey)
+	}
+	return nil
+}
@@ -2,35 +2,74 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
+
+	errs "github.com/gigi214/services_example/pkg/errs"
+	ulid "github.com/oklog/ulid/v2"
 )
 
 // StoreCmdsService describes the service.
 type StoreCmdsService interface {
-	Store(ctx context.Context, timestamp_exec time.Time, cmd string, success bool, exit_code int, stdout string, stderr string) (err error)
+	Store(ctx context.Context, entry CmdExecutedEntry) (id string, err error)
 	GetFromTo(ctx context.Context, from time.Time, to time.Time) (res []*CmdExecutedEntry, err error)
+	Query(ctx context.Context, filter QueryFilter) (res []*CmdExecutedEntry, nextCursor string, err error)
+	Get(ctx context.Context, id string) (entry *CmdExecutedEntry, err error)
+	Delete(ctx context.Context, id string) (err error)
 }
 
 type basicStoreCmdsService struct {
 	r Repository
 }
 
-func (b *basicStoreCmdsService) Store(ctx context.Context, timestamp time.Time, cmd string, success bool, exit_code int, stdout string, stderr string) (err error) {
-	b.r.CreateCmdExec(ctx, &CmdExecutedEntry{
-		Cmd:           cmd,
-		TimestampExec: timestamp,
-		Success:       success,
-		ExitCode:      exit_code,
-		Stdout:        stdout,
-		Stderr:        stderr,
-	})
+func (b *basicStoreCmdsService) Store(ctx context.Context, entry CmdExecutedEntry) (id string, err error) {
+	// Every entry gets a new ULID, so that IDs sort by creation time. The
+	// repository keeps the ID of the first delivery of an idempotency key.
+	entry.ID = ulid.Make().String()
+	// The clients that keep the whole output may not send its size.
+	if n := int64(len(entry.Stdout)); entry.StdoutBytes < n {
+		entry.StdoutBytes = n
+	}
+	if n := int64(len(entry.Stderr)); entry.StderrBytes < n {
+		entry.StderrBytes = n
+	}
+	if err = b.r.CreateCmdExec(ctx, &entry); err != nil {
+		return "", unavailable(err)
+	}
 
-	return err
+	return entry.ID, nil
 }
 
 func (b *basicStoreCmdsService) GetFromTo(ctx context.Context, from time.Time, to time.Time) (res []*CmdExecutedEntry, err error) {
 	res, err = b.r.GetCmdExecFromTo(ctx, from, to)
-	return
+	return res, unavailable(err)
+}
+
+func (b *basicStoreCmdsService) Query(ctx context.Context, filter QueryFilter) (res []*CmdExecutedEntry, nextCursor string, err error) {
+	res, nextCursor, err = b.r.Query(ctx, filter)
+	return res, nextCursor, unavailable(err)
+}
+
+func (b *basicStoreCmdsService) Get(ctx context.Context, id string) (entry *CmdExecutedEntry, err error) {
+	entry, err = b.r.GetCmdExec(ctx, id)
+	return entry, unavailable(err)
+}
+
+func (b *basicStoreCmdsService) Delete(ctx context.Context, id string) (err error) {
+	err = b.r.DeleteCmdExec(ctx, id)
+	return unavailable(err)
+}
+
+// unavailable reports the failures of the repository as ErrUnavailable. The
+// errors of a known kind, like ErrNotFound or ErrInvalidQuery, are returned
+// as they are.
+func unavailable(err error) error {
+	var e *errs.Error
+	if err == nil || errors.As(err, &e) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", ErrUnavailable, err)
 }
 
 // NewBasicStoreCmdsService returns a naive, stateless implementation of StoreCmdsService.
@@ -0,0 +1,6 @@
+#!/usr/bin/env sh
+
+# The generated code targets github.com/apache/thrift v0.13.0, install the
+# compiler with:
+#  go install github.com/cloudwego/thriftgo@v0.3.0
+thriftgo -g go -o gen-go store_cmds.thrift
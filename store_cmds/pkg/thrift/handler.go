@@ -0,0 +1,287 @@
+package thrift
+
+import (
+	"context"
+	"time"
+
+	endpoint "github.com/gigi214/services_example/store_cmds/pkg/endpoint"
+	service "github.com/gigi214/services_example/store_cmds/pkg/service"
+	storecmds "github.com/gigi214/services_example/store_cmds/pkg/thrift/gen-go/storecmds"
+)
+
+type thriftServer struct {
+	endpoints endpoint.Endpoints
+}
+
+// NewThriftServer makes a set of endpoints available as a Thrift StoreCmdsService.
+func NewThriftServer(endpoints endpoint.Endpoints) storecmds.StoreCmdsService {
+	return &thriftServer{endpoints: endpoints}
+}
+
+func (s *thriftServer) Store(ctx context.Context, entry *storecmds.CmdExecutedEntry) (*storecmds.StoreReply, error) {
+	e := EntryFromThrift(entry)
+	if e == nil {
+		return nil, endpoint.ErrInvalidInput
+	}
+	response, err := s.endpoints.StoreEndpoint(ctx, endpoint.StoreRequest{
+		Cmd:             e.Cmd,
+		TimestampExec:   e.TimestampExec,
+		FinishedAt:      e.FinishedAt,
+		Success:         e.Success,
+		ExitCode:        e.ExitCode,
+		Stdout:          e.Stdout,
+		Stderr:          e.Stderr,
+		Duration:        e.Duration,
+		UserTime:        e.UserTime,
+		SystemTime:      e.SystemTime,
+		PeakRSS:         e.PeakRSS,
+		Host:            e.Host,
+		InstanceID:      e.InstanceID,
+		IdempotencyKey:  e.IdempotencyKey,
+		Principal:       e.Principal,
+		Cwd:             e.Cwd,
+		Env:             e.Env,
+		CleanEnv:        e.CleanEnv,
+		Stdin:           e.Stdin,
+		LimitExceeded:   e.LimitExceeded,
+		StdoutBytes:     e.StdoutBytes,
+		StdoutTruncated: e.StdoutTruncated,
+		StderrBytes:     e.StderrBytes,
+		StderrTruncated: e.StderrTruncated,
+		BatchID:         e.BatchID,
+		BatchStep:       e.BatchStep,
+		ScheduleID:      e.ScheduleID,
+		TemplateName:    e.TemplateName,
+		TemplateParams:  e.TemplateParams,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := response.(endpoint.StoreResponse)
+	return &storecmds.StoreReply{ID: resp.ID, Err: errString(resp.Err)}, nil
+}
+
+func (s *thriftServer) GetFromTo(ctx context.Context, fromTs int64, toTs int64) (*storecmds.GetFromToReply, error) {
+	response, err := s.endpoints.GetFromToEndpoint(ctx, endpoint.GetFromToRequest{
+		From: FromUnixNano(fromTs),
+		To:   FromUnixNano(toTs),
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := response.(endpoint.GetFromToResponse)
+	return &storecmds.GetFromToReply{Res: entriesToThrift(resp.Res), Err: errString(resp.Err)}, nil
+}
+
+func (s *thriftServer) Query(ctx context.Context, filter *storecmds.QueryFilter) (*storecmds.QueryReply, error) {
+	response, err := s.endpoints.QueryEndpoint(ctx, endpoint.QueryRequest{Filter: FilterFromThrift(filter)})
+	if err != nil {
+		return nil, err
+	}
+	resp := response.(endpoint.QueryResponse)
+	return &storecmds.QueryReply{
+		Res:        entriesToThrift(resp.Res),
+		NextCursor: resp.NextCursor,
+		Err:        errString(resp.Err),
+	}, nil
+}
+
+func (s *thriftServer) Get(ctx context.Context, id string) (*storecmds.GetReply, error) {
+	response, err := s.endpoints.GetEndpoint(ctx, endpoint.GetRequest{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	resp := response.(endpoint.GetResponse)
+	return &storecmds.GetReply{Entry: EntryToThrift(resp.Entry), Err: errString(resp.Err)}, nil
+}
+
+func (s *thriftServer) Delete(ctx context.Context, id string) (*storecmds.DeleteReply, error) {
+	response, err := s.endpoints.DeleteEndpoint(ctx, endpoint.DeleteRequest{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	resp := response.(endpoint.DeleteResponse)
+	return &storecmds.DeleteReply{Err: errString(resp.Err)}, nil
+}
+
+// EntryToThrift converts an entry to its Thrift representation, nil stays nil.
+func EntryToThrift(e *service.CmdExecutedEntry) *storecmds.CmdExecutedEntry {
+	if e == nil {
+		return nil
+	}
+	return &storecmds.CmdExecutedEntry{
+		ID:              e.ID,
+		Cmd:             e.Cmd,
+		TimestampExec:   UnixNano(e.TimestampExec),
+		FinishedAt:      UnixNano(e.FinishedAt),
+		Success:         e.Success,
+		ExitCode:        int32(e.ExitCode),
+		Stdout:          e.Stdout,
+		Stderr:          e.Stderr,
+		DurationNs:      int64(e.Duration),
+		UserTimeNs:      int64(e.UserTime),
+		SystemTimeNs:    int64(e.SystemTime),
+		PeakRssBytes:    e.PeakRSS,
+		Host:            e.Host,
+		InstanceID:      e.InstanceID,
+		IdempotencyKey:  e.IdempotencyKey,
+		Principal:       e.Principal,
+		Cwd:             e.Cwd,
+		Env:             e.Env,
+		CleanEnv:        e.CleanEnv,
+		Stdin:           e.Stdin,
+		LimitExceeded:   e.LimitExceeded,
+		StdoutBytes:     e.StdoutBytes,
+		StdoutTruncated: e.StdoutTruncated,
+		StderrBytes:     e.StderrBytes,
+		StderrTruncated: e.StderrTruncated,
+		BatchID:         e.BatchID,
+		BatchStep:       e.BatchStep,
+		ScheduleID:      e.ScheduleID,
+		TemplateName:    e.TemplateName,
+		TemplateParams:  e.TemplateParams,
+	}
+}
+
+// EntryFromThrift converts a Thrift entry back, nil stays nil.
+func EntryFromThrift(e *storecmds.CmdExecutedEntry) *service.CmdExecutedEntry {
+	if e == nil {
+		return nil
+	}
+	return &service.CmdExecutedEntry{
+		ID:              e.ID,
+		Cmd:             e.Cmd,
+		TimestampExec:   FromUnixNano(e.TimestampExec),
+		FinishedAt:      FromUnixNano(e.FinishedAt),
+		Success:         e.Success,
+		ExitCode:        int(e.ExitCode),
+		Stdout:          e.Stdout,
+		Stderr:          e.Stderr,
+		Duration:        time.Duration(e.DurationNs),
+		UserTime:        time.Duration(e.UserTimeNs),
+		SystemTime:      time.Duration(e.SystemTimeNs),
+		PeakRSS:         e.PeakRssBytes,
+		Host:            e.Host,
+		InstanceID:      e.InstanceID,
+		IdempotencyKey:  e.IdempotencyKey,
+		Principal:       e.Principal,
+		Cwd:             e.Cwd,
+		Env:             e.Env,
+		CleanEnv:        e.CleanEnv,
+		Stdin:           e.Stdin,
+		LimitExceeded:   e.LimitExceeded,
+		StdoutBytes:     e.StdoutBytes,
+		StdoutTruncated: e.StdoutTruncated,
+		StderrBytes:     e.StderrBytes,
+		StderrTruncated: e.StderrTruncated,
+		BatchID:         e.BatchID,
+		BatchStep:       e.BatchStep,
+		ScheduleID:      e.ScheduleID,
+		TemplateName:    e.TemplateName,
+		TemplateParams:  e.TemplateParams,
+	}
+}
+
+func entriesToThrift(entries []*service.CmdExecutedEntry) []*storecmds.CmdExecutedEntry {
+	res := make([]*storecmds.CmdExecutedEntry, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, EntryToThrift(e))
+	}
+	return res
+}
+
+// FilterToThrift converts a query filter to its Thrift representation.
+func FilterToThrift(f service.QueryFilter) *storecmds.QueryFilter {
+	res := &storecmds.QueryFilter{
+		FromTs:          UnixNano(f.From),
+		ToTs:            UnixNano(f.To),
+		Cmd:             f.Cmd,
+		CmdRegex:        f.CmdRegex,
+		Success:         f.Success,
+		Host:            f.Host,
+		InstanceID:      f.InstanceID,
+		Principal:       f.Principal,
+		BatchID:         f.BatchID,
+		ScheduleID:      f.ScheduleID,
+		TemplateName:    f.TemplateName,
+		FinishedFrom:    UnixNano(f.FinishedFrom),
+		FinishedTo:      UnixNano(f.FinishedTo),
+		MinDurationNs:   int64(f.MinDuration),
+		MaxDurationNs:   int64(f.MaxDuration),
+		MinUserTimeNs:   int64(f.MinUserTime),
+		MaxUserTimeNs:   int64(f.MaxUserTime),
+		MinSystemTimeNs: int64(f.MinSystemTime),
+		MaxSystemTimeNs: int64(f.MaxSystemTime),
+		MinPeakRss:      f.MinPeakRSS,
+		MaxPeakRss:      f.MaxPeakRSS,
+		Order:           string(f.Order),
+		Limit:           int32(f.Limit),
+		Cursor:          f.Cursor,
+	}
+	if f.ExitCode != nil {
+		exitCode := int32(*f.ExitCode)
+		res.ExitCode = &exitCode
+	}
+	return res
+}
+
+// FilterFromThrift converts a Thrift query filter back, nil matches anything.
+func FilterFromThrift(f *storecmds.QueryFilter) service.QueryFilter {
+	if f == nil {
+		return service.QueryFilter{}
+	}
+	res := service.QueryFilter{
+		From:          FromUnixNano(f.FromTs),
+		To:            FromUnixNano(f.ToTs),
+		Cmd:           f.Cmd,
+		CmdRegex:      f.CmdRegex,
+		Success:       f.Success,
+		Host:          f.Host,
+		InstanceID:    f.InstanceID,
+		Principal:     f.Principal,
+		BatchID:       f.BatchID,
+		ScheduleID:    f.ScheduleID,
+		TemplateName:  f.TemplateName,
+		FinishedFrom:  FromUnixNano(f.FinishedFrom),
+		FinishedTo:    FromUnixNano(f.FinishedTo),
+		MinDuration:   time.Duration(f.MinDurationNs),
+		MaxDuration:   time.Duration(f.MaxDurationNs),
+		MinUserTime:   time.Duration(f.MinUserTimeNs),
+		MaxUserTime:   time.Duration(f.MaxUserTimeNs),
+		MinSystemTime: time.Duration(f.MinSystemTimeNs),
+		MaxSystemTime: time.Duration(f.MaxSystemTimeNs),
+		MinPeakRSS:    f.MinPeakRss,
+		MaxPeakRSS:    f.MaxPeakRss,
+		Order:         service.SortOrder(f.Order),
+		Limit:         int(f.Limit),
+		Cursor:        f.Cursor,
+	}
+	if f.ExitCode != nil {
+		exitCode := int(*f.ExitCode)
+		res.ExitCode = &exitCode
+	}
+	return res
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// UnixNano returns t in Unix nanoseconds, 0 for the zero time.
+func UnixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// FromUnixNano converts Unix nanoseconds to a time.Time, the zero time for 0.
+func FromUnixNano(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns).UTC()
+}
@@ -0,0 +1,106 @@
+namespace go storecmds
+
+// Times are Unix nanoseconds, 0 for the zero time, and durations nanoseconds.
+
+struct CmdExecutedEntry {
+	1: string id
+	2: string cmd
+	3: i64 timestamp_exec
+	4: i64 finished_at
+	5: bool success
+	6: i32 exit_code
+	7: string stdout
+	8: string stderr
+	9: i64 duration_ns
+	10: i64 user_time_ns
+	11: i64 system_time_ns
+	12: i64 peak_rss_bytes
+	13: string host
+	14: string instance_id
+	15: string idempotency_key
+	16: string principal
+	// cwd, env, clean_env and stdin are the environment the command ran in.
+	17: string cwd
+	18: map<string, string> env
+	19: bool clean_env
+	20: binary stdin
+	// limit_exceeded is the limit that stopped the command, such as "cpu_time".
+	21: string limit_exceeded
+	// stdout_bytes and stderr_bytes are the sizes of the whole output, stdout
+	// and stderr only hold its head and its tail when it is truncated.
+	22: i64 stdout_bytes
+	23: bool stdout_truncated
+	24: i64 stderr_bytes
+	25: bool stderr_truncated
+	// batch_id and batch_step link the execution to a step of a batch.
+	26: string batch_id
+	27: string batch_step
+	// schedule_id links the execution to the schedule that ran it.
+	28: string schedule_id
+	// template_name and template_params are the command template the command
+	// was rendered from and its parameters.
+	29: string template_name
+	30: map<string, string> template_params
+}
+
+struct StoreReply {
+	1: string id
+	2: string err
+}
+
+struct GetFromToReply {
+	1: list<CmdExecutedEntry> res
+	2: string err
+}
+
+struct QueryFilter {
+	1: i64 from_ts
+	2: i64 to_ts
+	3: string cmd
+	4: string cmd_regex
+	5: optional i32 exit_code
+	6: optional bool success
+	7: string host
+	8: string instance_id
+	9: i64 finished_from
+	10: i64 finished_to
+	11: i64 min_duration_ns
+	12: i64 max_duration_ns
+	13: i64 min_user_time_ns
+	14: i64 max_user_time_ns
+	15: i64 min_system_time_ns
+	16: i64 max_system_time_ns
+	17: i64 min_peak_rss
+	18: i64 max_peak_rss
+	19: string order
+	20: i32 limit
+	21: string cursor
+	22: string principal
+	23: string batch_id
+	24: string schedule_id
+	25: string template_name
+}
+
+struct QueryReply {
+	1: list<CmdExecutedEntry> res
+	2: string next_cursor
+	3: string err
+}
+
+struct GetReply {
+	1: CmdExecutedEntry entry
+	2: string err
+}
+
+struct DeleteReply {
+	1: string err
+}
+
+service StoreCmdsService {
+	// Store ignores the id of entry, and returns the one it is given.
+	StoreReply Store(1: CmdExecutedEntry entry)
+	GetFromToReply GetFromTo(1: i64 from_ts, 2: i64 to_ts)
+	QueryReply Query(1: QueryFilter filter)
+	GetReply Get(1: string id)
+	DeleteReply Delete(1: string id)
+}
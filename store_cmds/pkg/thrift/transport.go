@@ -0,0 +1,86 @@
+package thrift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	thrift "github.com/apache/thrift/lib/go/thrift"
+	auth "github.com/gigi214/services_example/pkg/auth"
+)
+
+// NewProtocolFactory returns the factory of the protocol named binary,
+// compact, json, simplejson or header. The header protocol is the only one
+// that carries credentials, in its headers, see AuthProcessor. It frames the
+// messages itself, the transport should not be framed.
+func NewProtocolFactory(protocol string) (thrift.TProtocolFactory, error) {
+	switch protocol {
+	case "header":
+		return thrift.NewTHeaderProtocolFactory(), nil
+	case "binary":
+		return thrift.NewTBinaryProtocolFactoryDefault(), nil
+	case "compact":
+		return thrift.NewTCompactProtocolFactory(), nil
+	case "json":
+		return thrift.NewTJSONProtocolFactory(), nil
+	case "simplejson":
+		return thrift.NewTSimpleJSONProtocolFactory(), nil
+	}
+	return nil, fmt.Errorf("unsupported thrift protocol %q", protocol)
+}
+
+// NewTransportFactory returns a factory of transports buffered with buffer
+// bytes when it is positive, and framed when framed is set. Clients and
+// servers must agree on the framing.
+func NewTransportFactory(buffer int, framed bool) thrift.TTransportFactory {
+	var factory thrift.TTransportFactory
+	if buffer > 0 {
+		factory = thrift.NewTBufferedTransportFactory(buffer)
+	} else {
+		factory = thrift.NewTTransportFactory()
+	}
+	if framed {
+		factory = thrift.NewTFramedTransportFactory(factory)
+	}
+	return factory
+}
+
+// AuthProcessor returns a thrift.TProcessor that authenticates every request
+// with the API key or the bearer token of its THeader headers, named like the
+// gRPC metadata, before p processes it, like the AuthToContext of the HTTP and
+// gRPC transports. The requests of the other protocols have no headers, and
+// are rejected by auth.Middleware.
+func AuthProcessor(p thrift.TProcessor, a *auth.Authenticator) thrift.TProcessor {
+	return authProcessor{p, a}
+}
+
+type authProcessor struct {
+	thrift.TProcessor
+	authenticator *auth.Authenticator
+}
+
+func (p authProcessor) Process(ctx context.Context, in, out thrift.TProtocol) (bool, thrift.TException) {
+	md := map[string][]string{}
+	for _, key := range thrift.GetReadHeaderList(ctx) {
+		if v, ok := thrift.GetHeader(ctx, key); ok {
+			md[strings.ToLower(key)] = []string{v}
+		}
+	}
+	principal, err := p.authenticator.Metadata(md)
+	return p.TProcessor.Process(auth.ToContext(ctx, principal, err), in, out)
+}
+
+// CredentialsToContext returns a copy of ctx that makes the THeader protocol
+// send credentials in the headers of a request.
+func CredentialsToContext(ctx context.Context, credentials auth.Credentials) (context.Context, error) {
+	md := map[string][]string{}
+	if err := credentials.SetMetadata(md); err != nil {
+		return ctx, err
+	}
+	keys := make([]string, 0, len(md))
+	for key, v := range md {
+		ctx = thrift.SetHeader(ctx, key, v[0])
+		keys = append(keys, key)
+	}
+	return thrift.SetWriteHeaderList(ctx, keys), nil
+}